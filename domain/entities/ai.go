@@ -8,8 +8,22 @@ type AIRequest struct {
 
 // Response da IA
 type AIResponse struct {
-	OriginalText string `json:"original_text"`
-	GeneratedText string `json:"generated_text"`
-	Prompt       string `json:"prompt"`
-	Model        string `json:"model"`
+	OriginalText  string              `json:"original_text"`
+	GeneratedText string              `json:"generated_text"`
+	Prompt        string              `json:"prompt"`
+	Model         string              `json:"model"`
+	Metadata      *AIResponseMetadata `json:"metadata,omitempty"`
+}
+
+// Dados da chamada ao provedor de IA
+type AIResponseMetadata struct {
+	Provider         string `json:"provider"`
+	TemplateName     string `json:"template_name,omitempty"`
+	TemplateVersion  int    `json:"template_version,omitempty"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+	TotalTokens      int    `json:"total_tokens"`
+	LatencyMs        int64  `json:"latency_ms"`    // Inclui as novas tentativas
+	FinishReason     string `json:"finish_reason"` // Como informado pelo provedor (ex.: stop, length, STOP, MAX_TOKENS)
+	Attempts         int    `json:"attempts"`
 }
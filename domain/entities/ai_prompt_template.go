@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Parâmetros de modelo; nil/vazio usa o padrão da seção [ai]
+type AIModelParams struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	MaxTokens   *int     `json:"max_tokens,omitempty"`
+	Model       string   `json:"model,omitempty"`
+}
+
+// Template de prompt gerenciado pelos administradores; variáveis no formato {{nome}}
+type AIPromptTemplate struct {
+	ID              int64     `json:"id"`
+	Name            string    `json:"name"` // Usado pelos clientes para chamar o template
+	Description     string    `json:"description"`
+	Template        string    `json:"template"`
+	Variables       []string  `json:"variables"` // Extraídas do template
+	Version         int       `json:"version"`
+	Active          bool      `json:"active"`
+	CreatedByUserID *int64    `json:"created_by_user_id,omitempty"` // nil para os templates iniciais
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	AIModelParams
+}
+
+// Versão gravada a cada alteração do template
+type AIPromptTemplateVersion struct {
+	TemplateID      int64     `json:"template_id"`
+	Version         int       `json:"version"`
+	Template        string    `json:"template"`
+	Variables       []string  `json:"variables"`
+	CreatedByUserID *int64    `json:"created_by_user_id,omitempty"`
+	CreatedByName   string    `json:"created_by_name,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	AIModelParams
+}
+
+type CreateAIPromptTemplateRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Template    string `json:"template"`
+	AIModelParams
+}
+
+type UpdateAIPromptTemplateRequest struct {
+	Description *string  `json:"description,omitempty"`
+	Template    *string  `json:"template,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	MaxTokens   *int     `json:"max_tokens,omitempty"`
+	Model       *string  `json:"model,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+}
+
+// Request para executar um template: os campos da iniciativa preenchem as variáveis
+type RunAIPromptTemplateRequest struct {
+	Name      string            `json:"name"`
+	Variables map[string]string `json:"variables"` // Ex.: title, description, benefits, type, sector
+}
+
+// Erro quando as variáveis enviadas não correspondem às do template
+type AIPromptVariablesError struct {
+	Missing []string
+	Unknown []string
+}
+
+func (e *AIPromptVariablesError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "faltando "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Unknown) > 0 {
+		parts = append(parts, "desconhecidas "+strings.Join(e.Unknown, ", "))
+	}
+	return fmt.Sprintf("variáveis inválidas para o template: %s", strings.Join(parts, "; "))
+}
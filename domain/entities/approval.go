@@ -0,0 +1,95 @@
+package entities
+
+import "time"
+
+// Item da caixa de aprovações unificada (iniciativas, cancelamentos e mudanças de priorização)
+type ApprovalItem struct {
+	Type              string `json:"type"` // initiative, cancellation, prioritization_change
+	TypeLabel         string `json:"type_label"`
+	ID                int64  `json:"id"` // ID usado na decisão (iniciativa ou solicitação)
+	Subject           string `json:"subject"`
+	InitiativeID      *int64 `json:"initiative_id,omitempty"`
+	RequestedByUserID int64  `json:"requested_by_user_id"`
+	RequestedByName   string `json:"requested_by_name"`
+	Reason            string `json:"reason,omitempty"`
+	PendingSince      string `json:"pending_since"`
+	TimeAgo           string `json:"time_ago"`
+	AgeHours          int    `json:"age_hours"`
+	Overdue           bool   `json:"overdue"`
+	EscalationLevel   int    `json:"escalation_level"`
+}
+
+// Contadores para o badge do dashboard
+type ApprovalCounts struct {
+	Initiative           int `json:"initiative"`
+	Cancellation         int `json:"cancellation"`
+	PrioritizationChange int `json:"prioritization_change"`
+	Overdue              int `json:"overdue"`
+	Total                int `json:"total"`
+}
+
+type ApprovalInbox struct {
+	Items  []*ApprovalItem `json:"items"`
+	Counts ApprovalCounts  `json:"counts"`
+}
+
+// Decisão uniforme, encaminhada para a revisão específica de cada tipo
+type ApprovalDecisionRequest struct {
+	Approved       bool                  `json:"approved"`
+	Decision       string                `json:"decision,omitempty"` // return: apenas iniciativas
+	Reason         string                `json:"reason"`
+	ChangeRequests []ReviewChangeRequest `json:"change_requests,omitempty"`
+	Force          bool                  `json:"force,omitempty"` // prioritization_change: aplica mesmo com conflito
+}
+
+// Status de cada etapa do fluxo de aprovação
+const (
+	ApprovalStepWaiting  = "Aguardando" // Fluxo sequencial: etapa anterior ainda não concluída
+	ApprovalStepPending  = "Pendente"
+	ApprovalStepApproved = "Aprovada"
+	ApprovalStepRejected = "Reprovada"
+)
+
+// Status do fluxo como um todo
+const (
+	ApprovalChainInProgress = "Em andamento"
+	ApprovalChainApproved   = "Aprovada"
+	ApprovalChainRejected   = "Reprovada"
+)
+
+// Decisão de um aprovador em uma etapa do fluxo
+type ApprovalDecision struct {
+	ID           int64     `json:"id"`
+	InitiativeID int64     `json:"initiative_id"`
+	StepIndex    int       `json:"step_index"`
+	StepName     string    `json:"step_name"`
+	UserID       int64     `json:"user_id"`
+	UserName     string    `json:"user_name"`
+	Round        int       `json:"round"` // Rodada de revisão (reenvios reiniciam o fluxo)
+	Approved     bool      `json:"approved"`
+	Reason       string    `json:"reason"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ApprovalChainStep struct {
+	Index      int                 `json:"index"`
+	Name       string              `json:"name"`
+	AssignedTo string              `json:"assigned_to"` // Tipo de usuário ou lista de emails
+	Quorum     string              `json:"quorum"`
+	Required   int                 `json:"required"` // Aprovações necessárias
+	Approvals  int                 `json:"approvals"`
+	Status     string              `json:"status"`
+	Decisions  []*ApprovalDecision `json:"decisions"`
+	// Etapa sem aprovadores elegíveis: decidida pelos administradores
+	AdminFallback bool `json:"admin_fallback,omitempty"`
+}
+
+// Estado do fluxo de aprovação de uma iniciativa
+type ApprovalChain struct {
+	InitiativeID   int64                `json:"initiative_id"`
+	InitiativeType string               `json:"initiative_type"`
+	Mode           string               `json:"mode"`
+	Status         string               `json:"status"`
+	Steps          []*ApprovalChainStep `json:"steps"`
+	CanDecide      bool                 `json:"can_decide"` // O usuário atual pode decidir alguma etapa pendente
+}
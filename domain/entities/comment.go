@@ -5,15 +5,56 @@ import "time"
 type Comment struct {
 	ID           int64     `json:"id"`
 	InitiativeID int64     `json:"initiative_id"`
+	ParentID     *int64    `json:"parent_id,omitempty"` // Resposta a outro comentário
 	UserID       int64     `json:"user_id"`
 	UserName     string    `json:"user_name"`
 	Content      string    `json:"content"`
+	ContentHTML  string    `json:"content_html"` // Markdown renderizado e sanitizado
+	Visibility   string    `json:"visibility"`   // public ou internal (somente revisores)
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+
+	RevisionCount int        `json:"revision_count"` // Quantidade de edições
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy     *int64     `json:"deleted_by,omitempty"`
+
+	Mentions []*CommentMention `json:"mentions,omitempty"`
+}
+
+// Visibilidade do comentário
+const (
+	CommentVisibilityPublic   = "public"
+	CommentVisibilityInternal = "internal" // Nota interna: visível apenas para revisores, nunca para o dono
+)
+
+// Texto exibido no lugar de um comentário removido
+const CommentRemovedPlaceholder = "comentário removido"
+
+// Versão anterior do conteúdo, gravada a cada edição
+type CommentRevision struct {
+	ID           int64     `json:"id"`
+	CommentID    int64     `json:"comment_id"`
+	Content      string    `json:"content"`
+	EditedBy     int64     `json:"edited_by"`
+	EditedByName string    `json:"edited_by_name"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type CommentRevisionResponse struct {
+	ID           int64  `json:"id"`
+	CommentID    int64  `json:"comment_id"`
+	Content      string `json:"content"`
+	ContentHTML  string `json:"content_html"`
+	EditedBy     int64  `json:"edited_by"`
+	EditedByName string `json:"edited_by_name"`
+	CreatedAt    string `json:"created_at"`
+	TimeAgo      string `json:"time_ago"`
 }
 
 type CreateCommentRequest struct {
-	Content string `json:"content"`
+	Content    string `json:"content"`
+	ParentID   *int64 `json:"parent_id,omitempty"`
+	Visibility string `json:"visibility,omitempty"` // Padrão: public
 }
 
 type UpdateCommentRequest struct {
@@ -23,9 +64,49 @@ type UpdateCommentRequest struct {
 type CommentListResponse struct {
 	ID           int64  `json:"id"`
 	InitiativeID int64  `json:"initiative_id"`
+	ParentID     *int64 `json:"parent_id,omitempty"`
 	UserID       int64  `json:"user_id"`
 	UserName     string `json:"user_name"`
 	Content      string `json:"content"`
+	ContentHTML  string `json:"content_html"`
+	Visibility   string `json:"visibility"`
 	CreatedAt    string `json:"created_at"`
 	UpdatedAt    string `json:"updated_at"`
+
+	Edited        bool   `json:"edited"`
+	EditedAt      string `json:"edited_at,omitempty"`
+	RevisionCount int    `json:"revision_count"`
+	Deleted       bool   `json:"deleted"` // Tombstone: conteúdo substituído por "comentário removido"
+
+	Mentions   []*CommentMention         `json:"mentions"`
+	Reactions  []*CommentReactionSummary `json:"reactions"`
+	ReplyCount int                       `json:"reply_count"`
+	Replies    []*CommentListResponse    `json:"replies"`
 }
+
+// Usuário mencionado com @ no conteúdo do comentário
+type CommentMention struct {
+	CommentID int64  `json:"-"`
+	UserID    int64  `json:"user_id"`
+	UserName  string `json:"user_name"`
+}
+
+type CommentReaction struct {
+	CommentID int64     `json:"comment_id"`
+	UserID    int64     `json:"user_id"`
+	Emoji     string    `json:"emoji"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CommentReactionRequest struct {
+	Emoji string `json:"emoji"`
+}
+
+type CommentReactionSummary struct {
+	Emoji       string `json:"emoji"`
+	Count       int    `json:"count"`
+	ReactedByMe bool   `json:"reacted_by_me"`
+}
+
+// Reações permitidas nos comentários
+var AllowedCommentReactions = []string{"👍", "👎", "❤️", "🎉", "😄", "😕", "🚀", "👀"}
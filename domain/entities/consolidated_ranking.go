@@ -0,0 +1,68 @@
+package entities
+
+import "time"
+
+// Status do ranking consolidado
+const (
+	ConsolidatedRankingDraft    = "Rascunho"
+	ConsolidatedRankingPending  = "Pendente" // Enviado para aprovação do comitê
+	ConsolidatedRankingApproved = "Aprovada" // Bloqueado
+	ConsolidatedRankingRejected = "Reprovada"
+)
+
+// Ranking único da empresa, gerado a partir das priorizações de cada setor
+type ConsolidatedRanking struct {
+	ID                int64                      `json:"id"`
+	Year              int                        `json:"year"`
+	Strategy          string                     `json:"strategy"`
+	Status            string                     `json:"status"`
+	IsLocked          bool                       `json:"is_locked"`
+	Items             []*ConsolidatedRankingItem `json:"items"`
+	Pins              []*RankingPin              `json:"pins"`
+	DroppedPins       []*RankingPin              `json:"dropped_pins,omitempty"` // Fixações herdadas descartadas na geração (não persistido)
+	GeneratedByUserID int64                      `json:"generated_by_user_id"`
+	GeneratedByName   string                     `json:"generated_by_name"`
+	GeneratedAt       time.Time                  `json:"generated_at"`
+	SubmittedByUserID *int64                     `json:"submitted_by_user_id,omitempty"`
+	SubmittedAt       *time.Time                 `json:"submitted_at,omitempty"`
+	ReviewedByUserID  *int64                     `json:"reviewed_by_user_id,omitempty"`
+	ReviewedByName    string                     `json:"reviewed_by_name,omitempty"`
+	ReviewReason      string                     `json:"review_reason,omitempty"`
+	ReviewedAt        *time.Time                 `json:"reviewed_at,omitempty"`
+	CreatedAt         time.Time                  `json:"created_at"`
+	UpdatedAt         time.Time                  `json:"updated_at"`
+}
+
+type ConsolidatedRankingItem struct {
+	Position        int     `json:"position"`
+	InitiativeID    int64   `json:"initiative_id"`
+	InitiativeTitle string  `json:"initiative_title"`
+	SectorID        int64   `json:"sector_id"`
+	SectorName      string  `json:"sector_name"`
+	SectorPosition  int     `json:"sector_position"` // Posição na priorização do setor
+	SectorWeight    float64 `json:"sector_weight"`
+	Score           float64 `json:"score"`
+	Pinned          bool    `json:"pinned"`
+	Explanation     string  `json:"explanation"` // Por que a iniciativa ficou nesta posição
+}
+
+// Posição fixada manualmente por um administrador
+type RankingPin struct {
+	InitiativeID int64 `json:"initiative_id"`
+	Position     int   `json:"position"`
+}
+
+type GenerateConsolidatedRankingRequest struct {
+	Year     int           `json:"year"`
+	Strategy string        `json:"strategy,omitempty"` // Padrão: prioritization.consolidation_strategy
+	Pins     []*RankingPin `json:"pins"`               // Ausente: mantém as fixações atuais; []: remove todas
+}
+
+type ReviewConsolidatedRankingRequest struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason"`
+}
+
+type ReopenConsolidatedRankingRequest struct {
+	Reason string `json:"reason"`
+}
@@ -7,10 +7,18 @@ type Initiative struct {
 	Title               string                      `json:"title"`
 	Description         string                      `json:"description"`
 	Benefits            string                      `json:"benefits"`
+	DescriptionHTML     string                      `json:"description_html"` // Markdown renderizado e sanitizado
+	BenefitsHTML        string                      `json:"benefits_html"`
+	DescriptionText     string                      `json:"-"` // Texto puro usado na busca
+	BenefitsText        string                      `json:"-"`
 	Status              string                      `json:"status"`
 	Type                string                      `json:"type"`
 	Priority            string                      `json:"priority"`
 	Sector              string                      `json:"sector"`
+	Score               *float64                    `json:"score,omitempty"`          // Pontuação ponderada (0 a 100) calculada pelos critérios
+	EffortDays          *float64                    `json:"effort_days,omitempty"`    // Esforço estimado em pessoa-dia
+	EstimatedCost       *float64                    `json:"estimated_cost,omitempty"` // Custo estimado (R$)
+	RequiredSkill       string                      `json:"required_skill,omitempty"` // Equipe/competência necessária
 	OwnerID             int64                       `json:"owner_id"`
 	OwnerName           string                      `json:"owner_name"`
 	Deadline            *time.Time                  `json:"deadline,omitempty"`
@@ -41,9 +49,16 @@ type InitiativeListResponse struct {
 	Type                string                      `json:"type"`
 	Priority            string                      `json:"priority"`
 	Sector              string                      `json:"sector"`
+	Score               *float64                    `json:"score,omitempty"`
+	EffortDays          *float64                    `json:"effort_days,omitempty"`
+	EstimatedCost       *float64                    `json:"estimated_cost,omitempty"`
+	RequiredSkill       string                      `json:"required_skill,omitempty"`
 	OwnerName           string                      `json:"owner_name"`
 	Date                string                      `json:"date"`
 	CancellationRequest *InitiativeCancellationInfo `json:"cancellation_request,omitempty"` // NOVO
+	AgeHours            int                         `json:"age_hours,omitempty"`            // Iniciativas submetidas: tempo aguardando revisão
+	Overdue             bool                        `json:"overdue,omitempty"`              // Passou do SLA de revisão
+	FitsCapacity        *bool                       `json:"fits_capacity,omitempty"`        // Priorização: acima da linha de corte
 }
 
 type CreateInitiativeRequest struct {
@@ -54,6 +69,10 @@ type CreateInitiativeRequest struct {
 	Priority    string  `json:"priority"`
 	Sector      string  `json:"sector"`
 	Deadline    *string `json:"deadline,omitempty"`
+
+	EffortDays    *float64 `json:"effort_days,omitempty"`
+	EstimatedCost *float64 `json:"estimated_cost,omitempty"`
+	RequiredSkill string   `json:"required_skill,omitempty"`
 }
 
 type UpdateInitiativeRequest struct {
@@ -64,6 +83,10 @@ type UpdateInitiativeRequest struct {
 	Priority    *string `json:"priority,omitempty"`
 	Sector      *string `json:"sector,omitempty"`
 	Deadline    *string `json:"deadline,omitempty"`
+
+	EffortDays    *float64 `json:"effort_days,omitempty"`    // 0 remove a estimativa
+	EstimatedCost *float64 `json:"estimated_cost,omitempty"` // 0 remove a estimativa
+	RequiredSkill *string  `json:"required_skill,omitempty"`
 }
 
 type ChangeInitiativeStatusRequest struct {
@@ -109,6 +132,8 @@ const (
 )
 
 type ReviewInitiativeRequest struct {
-	Approved bool   `json:"approved"` // true = aprovar, false = reprovar
-	Reason   string `json:"reason"`   // Justificativa obrigatória
+	Approved       bool                  `json:"approved"`                  // true = aprovar, false = reprovar
+	Decision       string                `json:"decision,omitempty"`        // approve, reject ou return (tem precedência sobre approved)
+	Reason         string                `json:"reason"`                    // Justificativa obrigatória
+	ChangeRequests []ReviewChangeRequest `json:"change_requests,omitempty"` // Pedidos de ajuste (decision = return)
 }
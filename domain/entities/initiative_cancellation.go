@@ -5,21 +5,28 @@ import "time"
 type InitiativeCancellationRequest struct {
 	ID                int64      `json:"id"`
 	InitiativeID      int64      `json:"initiative_id"`
+	InitiativeTitle   string     `json:"initiative_title,omitempty"`
 	RequestedByUserID int64      `json:"requested_by_user_id"`
 	RequestedByName   string     `json:"requested_by_name"`
 	Reason            string     `json:"reason"`
-	Status            string     `json:"status"` // Pendente, Aprovada, Reprovada
+	Status            string     `json:"status"` // Pendente, Aprovada, Reprovada, Retirada
 	ReviewedByUserID  *int64     `json:"reviewed_by_user_id,omitempty"`
 	ReviewedByName    string     `json:"reviewed_by_name,omitempty"`
 	ReviewReason      string     `json:"review_reason,omitempty"`
 	CreatedAt         time.Time  `json:"created_at"`
 	ReviewedAt        *time.Time `json:"reviewed_at,omitempty"`
+	WithdrawnAt       *time.Time `json:"withdrawn_at,omitempty"`
+	WithdrawReason    string     `json:"withdraw_reason,omitempty"`
 }
 
 type RequestCancellationRequest struct {
 	Reason string `json:"reason"`
 }
 
+type WithdrawCancellationRequest struct {
+	Reason string `json:"reason"` // Opcional
+}
+
 type ReviewCancellationRequest struct {
 	Approved bool   `json:"approved"`
 	Reason   string `json:"reason"`
@@ -38,11 +45,29 @@ type CancellationRequestResponse struct {
 	CreatedAt         string `json:"created_at"`
 	ReviewedAt        string `json:"reviewed_at,omitempty"`
 	TimeAgo           string `json:"time_ago"`
+	AgeHours          int    `json:"age_hours"` // Há quanto tempo aguarda decisão
+	Overdue           bool   `json:"overdue"`   // Passou do SLA
+
+	// Preenchidos na listagem das solicitações do próprio usuário
+	NewRequestAllowedAt string                      `json:"new_request_allowed_at,omitempty"` // Fim da espera após reprovação
+	History             []*CancellationRequestEvent `json:"history,omitempty"`
+}
+
+// Evento na linha do tempo de uma solicitação de cancelamento
+type CancellationRequestEvent struct {
+	Status   string `json:"status"` // Pendente (criação), Aprovada, Reprovada ou Retirada
+	UserID   int64  `json:"user_id"`
+	UserName string `json:"user_name"`
+	Reason   string `json:"reason,omitempty"`
+	Date     string `json:"date"`
+	TimeAgo  string `json:"time_ago"`
 }
 
 // Status de solicitação de cancelamento
 const (
-	CancellationStatusPending  = "Pendente"
-	CancellationStatusApproved = "Aprovada"
-	CancellationStatusRejected = "Reprovada"
+	CancellationStatusPending   = "Pendente"
+	CancellationStatusApproved  = "Aprovada"
+	CancellationStatusRejected  = "Reprovada"
+	CancellationStatusWithdrawn = "Retirada" // Retirada pelo próprio solicitante
+	CancellationStatusExpired   = "Expirada" // Sem decisão dentro do prazo
 )
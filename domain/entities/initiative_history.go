@@ -14,13 +14,22 @@ type InitiativeHistory struct {
 }
 
 type InitiativeHistoryResponse struct {
-	ID           int64  `json:"id"`
-	InitiativeID int64  `json:"initiative_id"`
-	UserID       int64  `json:"user_id"`
-	UserName     string `json:"user_name"`
-	OldStatus    string `json:"old_status"`
-	NewStatus    string `json:"new_status"`
-	Reason       string `json:"reason,omitempty"`
-	CreatedAt    string `json:"created_at"`
-	TimeAgo      string `json:"time_ago"`
+	ID           int64          `json:"id"`
+	InitiativeID int64          `json:"initiative_id"`
+	Kind         string         `json:"kind"` // status ou edit
+	UserID       int64          `json:"user_id"`
+	UserName     string         `json:"user_name"`
+	OldStatus    string         `json:"old_status"`
+	NewStatus    string         `json:"new_status"`
+	Reason       string         `json:"reason,omitempty"`
+	Version      int            `json:"version,omitempty"` // Edições: versão gerada
+	Changes      []*FieldChange `json:"changes,omitempty"`
+	CreatedAt    string         `json:"created_at"`
+	TimeAgo      string         `json:"time_ago"`
 }
+
+// Tipos de entrada na linha do tempo da iniciativa
+const (
+	HistoryKindStatus = "status"
+	HistoryKindEdit   = "edit"
+)
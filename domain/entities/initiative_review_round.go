@@ -0,0 +1,76 @@
+package entities
+
+import "time"
+
+// Decisões possíveis na revisão de uma iniciativa
+const (
+	ReviewDecisionApprove = "approve"
+	ReviewDecisionReject  = "reject"
+	ReviewDecisionReturn  = "return" // Devolver ao dono para ajustes (status "Devolvida")
+)
+
+// Campos editáveis da iniciativa (usados nos pedidos de ajuste e nos diffs)
+const (
+	InitiativeFieldTitle       = "title"
+	InitiativeFieldDescription = "description"
+	InitiativeFieldBenefits    = "benefits"
+	InitiativeFieldType        = "type"
+	InitiativeFieldPriority    = "priority"
+	InitiativeFieldSector      = "sector"
+	InitiativeFieldDeadline    = "deadline"
+	InitiativeFieldEffort      = "effort_days"
+	InitiativeFieldCost        = "estimated_cost"
+	InitiativeFieldSkill       = "required_skill"
+	InitiativeFieldGeneral     = "general" // Pedido que não se refere a um campo específico
+)
+
+// Conteúdo da iniciativa em um momento (submissão ou versão)
+type InitiativeSnapshot struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Benefits    string  `json:"benefits"`
+	Type        string  `json:"type"`
+	Priority    string  `json:"priority"`
+	Sector      string  `json:"sector"`
+	Deadline    *string `json:"deadline,omitempty"` // YYYY-MM-DD
+
+	EffortDays    *float64 `json:"effort_days,omitempty"`
+	EstimatedCost *float64 `json:"estimated_cost,omitempty"`
+	RequiredSkill string   `json:"required_skill,omitempty"`
+}
+
+// Alteração de um campo entre duas versões
+type FieldChange struct {
+	Field    string `json:"field"`
+	Label    string `json:"label"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// Pedido de ajuste feito pelo revisor ao devolver a iniciativa
+type ReviewChangeRequest struct {
+	Field   string `json:"field"`
+	Comment string `json:"comment"`
+}
+
+// Rodada de revisão: cada submissão (ou reenvio) abre uma rodada; a devolução a encerra
+type InitiativeReviewRound struct {
+	ID                int64                 `json:"id"`
+	InitiativeID      int64                 `json:"initiative_id"`
+	Round             int                   `json:"round"`
+	Snapshot          InitiativeSnapshot    `json:"snapshot"`
+	SubmittedByUserID int64                 `json:"submitted_by_user_id"`
+	SubmittedByName   string                `json:"submitted_by_name"`
+	SubmitComment     string                `json:"submit_comment,omitempty"`
+	SubmittedAt       time.Time             `json:"submitted_at"`
+	ReturnedByUserID  *int64                `json:"returned_by_user_id,omitempty"`
+	ReturnedByName    string                `json:"returned_by_name,omitempty"`
+	ReturnReason      string                `json:"return_reason,omitempty"`
+	ChangeRequests    []ReviewChangeRequest `json:"change_requests"`
+	ReturnedAt        *time.Time            `json:"returned_at,omitempty"`
+	Changes           []*FieldChange        `json:"changes,omitempty"` // Diferenças em relação à rodada anterior
+}
+
+type ResubmitInitiativeRequest struct {
+	Comment string `json:"comment"` // Resposta do dono aos pedidos de ajuste
+}
@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+// Versão do conteúdo da iniciativa, gravada na criação e a cada edição
+type InitiativeVersion struct {
+	ID                  int64              `json:"id"`
+	InitiativeID        int64              `json:"initiative_id"`
+	Version             int                `json:"version"`
+	Snapshot            InitiativeSnapshot `json:"snapshot"`
+	EditedByUserID      int64              `json:"edited_by_user_id"`
+	EditedByName        string             `json:"edited_by_name"`
+	RestoredFromVersion *int               `json:"restored_from_version,omitempty"`
+	CreatedAt           time.Time          `json:"created_at"`
+	Changes             []*FieldChange     `json:"changes,omitempty"` // Diferenças em relação à versão anterior
+}
+
+type InitiativeVersionDiff struct {
+	InitiativeID int64          `json:"initiative_id"`
+	FromVersion  int            `json:"from_version"`
+	ToVersion    int            `json:"to_version"`
+	Changes      []*FieldChange `json:"changes"`
+}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Tentativa de login (auditoria)
+type LoginAttempt struct {
+	ID            int64     `json:"id"`
+	Email         string    `json:"email"`
+	IPAddress     string    `json:"ip_address"`
+	Success       bool      `json:"success"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Contador de falhas e bloqueio por conta ou por IP
+type LoginLockout struct {
+	Scope        string     `json:"scope"` // account, ip
+	Identifier   string     `json:"identifier"`
+	FailedCount  int        `json:"failed_count"`
+	LockedUntil  *time.Time `json:"locked_until,omitempty"`
+	LastFailedAt time.Time  `json:"last_failed_at"`
+}
+
+type LoginAttemptFilter struct {
+	Email      string
+	IPAddress  string
+	OnlyFailed bool
+	Limit      int
+}
+
+// Erro retornado quando o login está temporariamente bloqueado
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	seconds := int(e.RetryAfter.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	return fmt.Sprintf("muitas tentativas de login. Tente novamente em %d segundo(s)", seconds)
+}
+
+// Escopos de bloqueio
+const (
+	LoginLockoutScopeAccount = "account"
+	LoginLockoutScopeIP      = "ip"
+)
+
+// Motivos de falha registrados na auditoria
+const (
+	LoginFailureInvalidCredentials = "credenciais inválidas"
+	LoginFailureThrottled          = "bloqueado por excesso de tentativas"
+	LoginFailureInvalidTwoFactor   = "código de verificação inválido"
+	LoginFailureLocalLoginDisabled = "login local desabilitado"
+)
@@ -0,0 +1,47 @@
+package entities
+
+import "time"
+
+// Tipos de notificação
+const (
+	NotificationTypeCommentMention = "comment_mention"
+	NotificationTypeCommentReply   = "comment_reply"
+	NotificationTypeReviewOverdue  = "review_overdue" // Decisão pendente além do SLA (escalonamento)
+	NotificationTypeReviewExpired  = "review_expired" // Solicitação expirada sem decisão
+	NotificationTypeApprovalStep   = "approval_step"  // Etapa do fluxo de aprovação aguardando o usuário
+	NotificationTypeReturned       = "initiative_returned"
+	NotificationTypeResubmitted    = "initiative_resubmitted"
+	NotificationTypeRankingReview  = "consolidated_ranking_review" // Ranking consolidado aguardando o comitê
+
+	NotificationTypePrioritizationSubmitted = "prioritization_submitted"
+	NotificationTypePrioritizationReopened  = "prioritization_reopened" // Priorização enviada voltou para rascunho
+)
+
+type Notification struct {
+	ID           int64      `json:"id"`
+	UserID       int64      `json:"user_id"`
+	Type         string     `json:"type"`
+	Title        string     `json:"title"`
+	Message      string     `json:"message"`
+	InitiativeID *int64     `json:"initiative_id,omitempty"`
+	CommentID    *int64     `json:"comment_id,omitempty"`
+	ReadAt       *time.Time `json:"read_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type NotificationFilter struct {
+	UnreadOnly bool
+	Limit      int
+}
+
+type NotificationListResponse struct {
+	ID           int64  `json:"id"`
+	Type         string `json:"type"`
+	Title        string `json:"title"`
+	Message      string `json:"message"`
+	InitiativeID *int64 `json:"initiative_id,omitempty"`
+	CommentID    *int64 `json:"comment_id,omitempty"`
+	Read         bool   `json:"read"`
+	CreatedAt    string `json:"created_at"`
+	TimeAgo      string `json:"time_ago"`
+}
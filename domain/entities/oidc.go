@@ -0,0 +1,25 @@
+package entities
+
+// Claims extraídas do ID token já validado
+type OIDCClaims struct {
+	Issuer        string   `json:"iss"`
+	Subject       string   `json:"sub"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Name          string   `json:"name"`
+	Groups        []string `json:"groups,omitempty"`
+	Sector        string   `json:"sector,omitempty"`
+}
+
+// Estado do fluxo authorization code + PKCE, guardado em cookie assinado até o callback
+type OIDCFlowState struct {
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// Início do login: URL do provedor e o estado que precisa voltar no callback
+type OIDCAuthRequest struct {
+	AuthorizationURL string         `json:"authorization_url"`
+	Flow             *OIDCFlowState `json:"-"`
+}
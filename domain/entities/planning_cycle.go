@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Situação do ciclo de planejamento, calculada a partir da janela
+const (
+	PlanningCycleScheduled = "Agendado"
+	PlanningCycleOpen      = "Aberto"
+	PlanningCycleClosed    = "Encerrado"
+)
+
+// Janela em que os setores podem salvar a priorização do ano
+type PlanningCycle struct {
+	ID              int64      `json:"id"`
+	Year            int        `json:"year"`
+	Quarter         *int       `json:"quarter,omitempty"` // 1 a 4; ausente quando o ciclo vale para o ano todo
+	Name            string     `json:"name"`
+	OpensAt         time.Time  `json:"opens_at"`
+	ClosesAt        time.Time  `json:"closes_at"`
+	SectorIDs       []int64    `json:"sector_ids"` // Setores participantes; vazio = todos os setores ativos
+	Status          string     `json:"status"`
+	OpenedAt        *time.Time `json:"opened_at,omitempty"` // Quando a abertura foi processada (reabertura e carry-forward)
+	ClosedAt        *time.Time `json:"closed_at,omitempty"` // Quando os rascunhos foram enviados e bloqueados
+	CreatedByUserID int64      `json:"created_by_user_id"`
+	CreatedByName   string     `json:"created_by_name"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// StatusAt calcula a situação do ciclo no instante informado
+func (c *PlanningCycle) StatusAt(now time.Time) string {
+	switch {
+	case c.ClosedAt != nil || !now.Before(c.ClosesAt):
+		return PlanningCycleClosed
+	case now.Before(c.OpensAt):
+		return PlanningCycleScheduled
+	default:
+		return PlanningCycleOpen
+	}
+}
+
+// IncludesSector indica se o setor participa do ciclo
+func (c *PlanningCycle) IncludesSector(sectorID int64) bool {
+	return len(c.SectorIDs) == 0 || slices.Contains(c.SectorIDs, sectorID)
+}
+
+type CreatePlanningCycleRequest struct {
+	Year      int       `json:"year"`
+	Quarter   *int      `json:"quarter,omitempty"`
+	Name      string    `json:"name,omitempty"` // Padrão: "Planejamento <ano>" ou "Planejamento <ano> T<trimestre>"
+	OpensAt   time.Time `json:"opens_at"`
+	ClosesAt  time.Time `json:"closes_at"`
+	SectorIDs []int64   `json:"sector_ids"`
+}
+
+// Campos ausentes mantêm o valor atual; ciclos encerrados não podem ser alterados
+type UpdatePlanningCycleRequest struct {
+	Name      *string    `json:"name,omitempty"`
+	OpensAt   *time.Time `json:"opens_at,omitempty"`
+	ClosesAt  *time.Time `json:"closes_at,omitempty"`
+	SectorIDs []int64    `json:"sector_ids,omitempty"`
+}
+
+// Situação de cada setor participante no ciclo
+type PlanningCycleSectorStatus struct {
+	SectorID         int64      `json:"sector_id"`
+	SectorName       string     `json:"sector_name"`
+	PrioritizationID *int64     `json:"prioritization_id,omitempty"`
+	Status           string     `json:"status,omitempty"` // Rascunho ou Enviada; vazio = sem priorização
+	Submitted        bool       `json:"submitted"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+type PlanningCycleOverview struct {
+	Cycle          *PlanningCycle               `json:"cycle"`
+	Sectors        []*PlanningCycleSectorStatus `json:"sectors"`
+	SubmittedCount int                          `json:"submitted_count"`
+	PendingSectors []string                     `json:"pending_sectors"` // Setores que ainda não enviaram
+	// Rascunhos incompletos ou inválidos que ficaram sem envio no encerramento
+	InvalidDrafts []*PlanningCycleInvalidDraft `json:"invalid_drafts,omitempty"`
+}
+
+// Rascunho que não passou na validação de envio ao encerrar o ciclo
+type PlanningCycleInvalidDraft struct {
+	SectorID         int64                 `json:"sector_id"`
+	SectorName       string                `json:"sector_name"`
+	PrioritizationID int64                 `json:"prioritization_id"`
+	Issues           []*PriorityOrderIssue `json:"issues"`
+}
+
+// Resultado do processamento periódico dos ciclos
+type PlanningCycleRunResult struct {
+	Opened      int `json:"opened"`
+	Closed      int `json:"closed"`
+	Locked      int `json:"locked"`       // Rascunhos enviados automaticamente no encerramento
+	Invalid     int `json:"invalid"`      // Rascunhos mantidos sem envio por estarem incompletos ou inválidos
+	CarriedOver int `json:"carried_over"` // Rascunhos criados com iniciativas do ciclo anterior
+}
+
+// Erro retornado quando a priorização é salva fora da janela do ciclo
+type PlanningWindowError struct {
+	Year  int
+	Cycle *PlanningCycle // Próximo ciclo do setor no ano, se houver
+}
+
+func (e *PlanningWindowError) Error() string {
+	if e.Cycle != nil && e.Cycle.StatusAt(time.Now()) == PlanningCycleScheduled {
+		return fmt.Sprintf("a priorização de %s abre em %s", e.Cycle.Name, e.Cycle.OpensAt.Format("02/01/2006 15:04"))
+	}
+	return fmt.Sprintf("não há ciclo de planejamento aberto para o seu setor em %d", e.Year)
+}
@@ -1,6 +1,9 @@
 package entities
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Priorização de iniciativas por setor
 type InitiativePrioritization struct {
@@ -9,22 +12,44 @@ type InitiativePrioritization struct {
 	SectorName      string    `json:"sector_name"`
 	Year            int       `json:"year"`
 	PriorityOrder   []int64   `json:"priority_order"` // Array com IDs das iniciativas ordenadas
+	UnrankedIDs     []int64   `json:"unranked_ids"`   // Elegíveis marcadas explicitamente como fora do ranking
 	IsLocked        bool      `json:"is_locked"`      // Se está bloqueada para edição
+	Status          string    `json:"status"`         // Rascunho (visível só para o setor) ou Enviada
 	CreatedByUserID int64     `json:"created_by_user_id"`
 	CreatedByName   string    `json:"created_by_name"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
+
+	SubmittedByUserID *int64     `json:"submitted_by_user_id,omitempty"`
+	SubmittedAt       *time.Time `json:"submitted_at,omitempty"`
+	ReopenedByUserID  *int64     `json:"reopened_by_user_id,omitempty"`
+	ReopenReason      string     `json:"reopen_reason,omitempty"`
+	ReopenedAt        *time.Time `json:"reopened_at,omitempty"`
 }
 
+// Status da priorização do setor
+const (
+	PrioritizationStatusDraft     = "Rascunho"
+	PrioritizationStatusSubmitted = "Enviada" // Bloqueada; mudanças por solicitação ou reabertura
+)
+
 // Request para salvar priorização
 type SavePrioritizationRequest struct {
 	Year          int     `json:"year"`
-	PriorityOrder []int64 `json:"priority_order"` // Array com IDs das iniciativas na ordem
+	PriorityOrder []int64 `json:"priority_order"`         // Array com IDs das iniciativas na ordem
+	UnrankedIDs   []int64 `json:"unranked_ids,omitempty"` // Elegíveis que ficam fora do ranking
+	Reason        string  `json:"reason,omitempty"`
+}
+
+// Request para reabrir uma priorização enviada (admin/manager)
+type ReopenPrioritizationRequest struct {
+	Reason string `json:"reason"`
 }
 
 // Request para solicitar mudança de priorização
 type RequestPrioritizationChangeRequest struct {
 	NewPriorityOrder []int64 `json:"new_priority_order"`
+	NewUnrankedIDs   []int64 `json:"new_unranked_ids,omitempty"`
 	Reason           string  `json:"reason"`
 }
 
@@ -35,6 +60,8 @@ type PrioritizationChangeRequest struct {
 	RequestedByUserID int64      `json:"requested_by_user_id"`
 	RequestedByName   string     `json:"requested_by_name"`
 	NewPriorityOrder  []int64    `json:"new_priority_order"`
+	NewUnrankedIDs    []int64    `json:"new_unranked_ids,omitempty"`
+	BaseOrder         []int64    `json:"base_priority_order,omitempty"` // Ordem vigente quando a mudança foi solicitada
 	Reason            string     `json:"reason"`
 	Status            string     `json:"status"` // Pendente, Aprovada, Reprovada
 	ReviewedByUserID  *int64     `json:"reviewed_by_user_id,omitempty"`
@@ -42,12 +69,49 @@ type PrioritizationChangeRequest struct {
 	ReviewReason      string     `json:"review_reason,omitempty"`
 	CreatedAt         time.Time  `json:"created_at"`
 	ReviewedAt        *time.Time `json:"reviewed_at,omitempty"`
+	AppliedByUserID   *int64     `json:"applied_by_user_id,omitempty"`
+	AppliedByName     string     `json:"applied_by_name,omitempty"`
+	ReplacedOrder     []int64    `json:"replaced_priority_order,omitempty"` // Ordem substituída na aplicação
+	AppliedAt         *time.Time `json:"applied_at,omitempty"`
+
+	// Preenchidos na listagem de pendentes
+	AgeHours int  `json:"age_hours"`
+	Overdue  bool `json:"overdue"`
 }
 
 // Request para revisar solicitação de mudança
 type ReviewPrioritizationChangeRequest struct {
 	Approved bool   `json:"approved"`
 	Reason   string `json:"reason"`
+	Force    bool   `json:"force,omitempty"` // Aplica mesmo que a ordem tenha mudado desde a solicitação
+}
+
+// Comparação em três vias quando a priorização mudou entre a solicitação e a revisão
+type PrioritizationConflict struct {
+	RequestID        int64                   `json:"request_id"`
+	PrioritizationID int64                   `json:"prioritization_id"`
+	BaseOrder        []int64                 `json:"base_priority_order"`     // Quando a mudança foi solicitada
+	CurrentOrder     []int64                 `json:"current_priority_order"`  // Salva atualmente
+	ProposedOrder    []int64                 `json:"proposed_priority_order"` // Proposta pelo solicitante
+	Positions        []*PriorityPositionDiff `json:"positions"`
+}
+
+// Posição (1..N) de uma iniciativa em cada versão da ordem; nil quando ausente
+type PriorityPositionDiff struct {
+	InitiativeID     int64 `json:"initiative_id"`
+	BasePosition     *int  `json:"base_position,omitempty"`
+	CurrentPosition  *int  `json:"current_position,omitempty"`
+	ProposedPosition *int  `json:"proposed_position,omitempty"`
+	Conflict         bool  `json:"conflict"` // Alterada pelos dois lados com resultados diferentes
+}
+
+// Erro retornado quando a aprovação não pode ser aplicada sem sobrescrever alterações
+type PrioritizationConflictError struct {
+	Conflict *PrioritizationConflict
+}
+
+func (e *PrioritizationConflictError) Error() string {
+	return "a priorização foi alterada desde a solicitação. Revise as diferenças e aprove novamente com force=true ou reprove a solicitação"
 }
 
 // Response com iniciativas priorizadas (com dados completos)
@@ -57,11 +121,16 @@ type PrioritizationWithInitiatives struct {
 	SectorName      string                    `json:"sector_name"`
 	Year            int                       `json:"year"`
 	IsLocked        bool                      `json:"is_locked"`
+	Status          string                    `json:"status,omitempty"`
 	Initiatives     []*InitiativeListResponse `json:"initiatives"` // Iniciativas na ordem de prioridade
+	UnrankedIDs     []int64                   `json:"unranked_ids,omitempty"`
 	CreatedByUserID int64                     `json:"created_by_user_id"`
 	CreatedByName   string                    `json:"created_by_name"`
 	CreatedAt       string                    `json:"created_at"`
 	UpdatedAt       string                    `json:"updated_at"`
+	SubmittedAt     *time.Time                `json:"submitted_at,omitempty"`
+	ReopenReason    string                    `json:"reopen_reason,omitempty"`
+	Capacity        *PrioritizationCapacity   `json:"capacity,omitempty"` // Linha de corte; nil se o setor não tem capacidade definida
 }
 
 // Response para admin/manager com todos os setores
@@ -70,9 +139,42 @@ type AllSectorsPrioritization struct {
 	Sectors []*PrioritizationWithInitiatives `json:"sectors"`
 }
 
+// Motivos de rejeição de um ID na ordem de prioridade
+const (
+	PriorityIssueDuplicated        = "duplicated"
+	PriorityIssueRankedAndUnranked = "ranked_and_unranked"
+	PriorityIssueNotFound          = "not_found"
+	PriorityIssueOtherSector       = "other_sector"
+	PriorityIssueIneligibleStatus  = "ineligible_status"
+	PriorityIssueMissing           = "missing"
+)
+
+type PriorityOrderIssue struct {
+	InitiativeID int64  `json:"initiative_id"`
+	Reason       string `json:"reason"`
+	Message      string `json:"message"`
+}
+
+// Erro retornado quando a ordem de prioridade não corresponde às iniciativas elegíveis do setor
+type PriorityOrderValidationError struct {
+	Issues []*PriorityOrderIssue
+}
+
+func (e *PriorityOrderValidationError) Error() string {
+	return fmt.Sprintf("ordem de prioridade inválida: %d problema(s) encontrado(s)", len(e.Issues))
+}
+
+// Apenas iniciativas aprovadas, em execução ou em análise entram na priorização
+func IsPrioritizationEligible(status string) bool {
+	return status == StatusApproved ||
+		status == StatusInExecution ||
+		status == StatusInAnalysis
+}
+
 // Status da solicitação de mudança
 const (
 	PrioritizationChangeStatusPending  = "Pendente"
 	PrioritizationChangeStatusApproved = "Aprovada"
 	PrioritizationChangeStatusRejected = "Reprovada"
+	PrioritizationChangeStatusExpired  = "Expirada" // Sem decisão dentro do prazo
 )
@@ -0,0 +1,63 @@
+package entities
+
+import "time"
+
+// Origem de cada versão da priorização
+const (
+	PrioritizationVersionSourceSave          = "save"
+	PrioritizationVersionSourceDraft         = "draft" // Salvamento de rascunho com ordem alterada
+	PrioritizationVersionSourceChangeRequest = "change_request"
+	PrioritizationVersionSourceRollback      = "rollback"
+	PrioritizationVersionSourceCarryForward  = "carry_forward" // Rascunho criado na abertura do ciclo
+	PrioritizationVersionSourceSubmit        = "submit"
+	PrioritizationVersionSourceReopen        = "reopen" // Volta para rascunho, com a justificativa
+)
+
+// Versão da ordem de prioridade, gravada a cada salvamento, mudança aplicada ou rollback
+type PrioritizationVersion struct {
+	ID                  int64     `json:"id"`
+	PrioritizationID    int64     `json:"prioritization_id"`
+	Version             int       `json:"version"`
+	PriorityOrder       []int64   `json:"priority_order"`
+	UnrankedIDs         []int64   `json:"unranked_ids,omitempty"` // nil em versões anteriores ao registro das não ranqueadas
+	Source              string    `json:"source"`
+	ChangeRequestID     *int64    `json:"change_request_id,omitempty"`
+	RestoredFromVersion *int      `json:"restored_from_version,omitempty"`
+	Reason              string    `json:"reason,omitempty"`
+	CreatedByUserID     int64     `json:"created_by_user_id"`
+	CreatedByName       string    `json:"created_by_name"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// Tipo de movimentação de uma iniciativa entre duas versões
+const (
+	PriorityMoveUp        = "up"
+	PriorityMoveDown      = "down"
+	PriorityMoveAdded     = "added"
+	PriorityMoveRemoved   = "removed"
+	PriorityMoveUnchanged = "unchanged"
+)
+
+type PriorityMove struct {
+	InitiativeID    int64  `json:"initiative_id"`
+	InitiativeTitle string `json:"initiative_title,omitempty"`
+	FromPosition    *int   `json:"from_position,omitempty"`
+	ToPosition      *int   `json:"to_position,omitempty"`
+	Delta           int    `json:"delta"` // Positivo: subiu N posições
+	Move            string `json:"move"`
+}
+
+type PrioritizationVersionDiff struct {
+	PrioritizationID int64           `json:"prioritization_id"`
+	FromVersion      int             `json:"from_version"`
+	ToVersion        int             `json:"to_version"`
+	Moves            []*PriorityMove `json:"moves"`
+	MovedUp          int             `json:"moved_up"`
+	MovedDown        int             `json:"moved_down"`
+	Added            int             `json:"added"`
+	Removed          int             `json:"removed"`
+}
+
+type RollbackPrioritizationRequest struct {
+	Reason string `json:"reason"`
+}
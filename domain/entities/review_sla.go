@@ -0,0 +1,39 @@
+package entities
+
+import "time"
+
+// Tipos de item que aguardam decisão de um revisor
+const (
+	ReviewItemInitiative           = "initiative"            // Iniciativa em "Submetida"
+	ReviewItemCancellation         = "cancellation"          // Solicitação de cancelamento pendente
+	ReviewItemPrioritizationChange = "prioritization_change" // Solicitação de mudança de priorização pendente
+)
+
+func IsValidReviewItemType(itemType string) bool {
+	return itemType == ReviewItemInitiative ||
+		itemType == ReviewItemCancellation ||
+		itemType == ReviewItemPrioritizationChange
+}
+
+// Item pendente de decisão, usado na verificação de SLA
+type PendingReviewItem struct {
+	Type              string    `json:"type"`
+	ID                int64     `json:"id"`
+	Subject           string    `json:"subject"`
+	InitiativeID      *int64    `json:"initiative_id,omitempty"`
+	InitiativeType    string    `json:"initiative_type,omitempty"`
+	SectorID          *int64    `json:"sector_id,omitempty"`
+	RequestedByUserID int64     `json:"requested_by_user_id"`
+	RequestedByName   string    `json:"requested_by_name"`
+	Reason            string    `json:"reason,omitempty"`
+	PendingSince      time.Time `json:"pending_since"`    // Submissão da rodada atual ou criação da solicitação
+	Round             int       `json:"round"`            // Rodada de revisão (sempre 1 para solicitações)
+	EscalationLevel   int       `json:"escalation_level"` // Último nível de revisores notificado na rodada
+}
+
+// Resultado de uma execução da verificação de SLA
+type ReviewSLARunResult struct {
+	Checked   int `json:"checked"`
+	Escalated int `json:"escalated"`
+	Expired   int `json:"expired"`
+}
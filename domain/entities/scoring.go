@@ -0,0 +1,76 @@
+package entities
+
+import "time"
+
+// Escala das notas por critério
+const (
+	ScoreMin = 1
+	ScoreMax = 5
+)
+
+// Critério de pontuação definido pelos administradores (ex.: alinhamento estratégico, esforço)
+type ScoringCriterion struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Weight      float64   `json:"weight"`
+	Inverted    bool      `json:"inverted"` // Nota alta é ruim (ex.: risco, esforço)
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type CreateScoringCriterionRequest struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Weight      float64 `json:"weight"`
+	Inverted    bool    `json:"inverted"`
+}
+
+type UpdateScoringCriterionRequest struct {
+	Name        *string  `json:"name,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Weight      *float64 `json:"weight,omitempty"`
+	Inverted    *bool    `json:"inverted,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+}
+
+// Nota de um revisor para a iniciativa em um critério
+type InitiativeCriterionScore struct {
+	InitiativeID  int64     `json:"initiative_id"`
+	CriterionID   int64     `json:"criterion_id"`
+	CriterionName string    `json:"criterion_name"`
+	ReviewerID    int64     `json:"reviewer_id"`
+	ReviewerName  string    `json:"reviewer_name"`
+	Score         int       `json:"score"`
+	Comment       string    `json:"comment,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type CriterionScoreInput struct {
+	CriterionID int64  `json:"criterion_id"`
+	Score       int    `json:"score"`
+	Comment     string `json:"comment,omitempty"`
+}
+
+type ScoreInitiativeRequest struct {
+	Scores []CriterionScoreInput `json:"scores"`
+}
+
+// Média das notas de um critério e sua contribuição para a pontuação final
+type CriterionScoreSummary struct {
+	CriterionID  int64   `json:"criterion_id"`
+	Name         string  `json:"name"`
+	Weight       float64 `json:"weight"`
+	Inverted     bool    `json:"inverted"`
+	Average      float64 `json:"average"`
+	Reviewers    int     `json:"reviewers"`
+	Contribution float64 `json:"contribution"` // Pontos (0 a 100) somados à pontuação final
+}
+
+type InitiativeScoreBreakdown struct {
+	InitiativeID int64                       `json:"initiative_id"`
+	Score        *float64                    `json:"score"`
+	Criteria     []*CriterionScoreSummary    `json:"criteria"`
+	Scores       []*InitiativeCriterionScore `json:"scores"`
+}
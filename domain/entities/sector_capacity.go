@@ -0,0 +1,36 @@
+package entities
+
+import "time"
+
+// Capacidade de entrega do setor em um ano de planejamento
+type SectorCapacity struct {
+	SectorID        int64     `json:"sector_id"`
+	SectorName      string    `json:"sector_name"`
+	Year            int       `json:"year"`
+	CapacityDays    float64   `json:"capacity_days"`    // Pessoas-dia disponíveis
+	Budget          *float64  `json:"budget,omitempty"` // Orçamento; nil = sem limite de custo
+	UpdatedByUserID int64     `json:"updated_by_user_id"`
+	UpdatedByName   string    `json:"updated_by_name"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Request para definir a capacidade de um setor (admin/manager)
+type SetSectorCapacityRequest struct {
+	SectorID     int64    `json:"sector_id"`
+	Year         int      `json:"year"`
+	CapacityDays float64  `json:"capacity_days"`
+	Budget       *float64 `json:"budget,omitempty"`
+}
+
+// Linha de corte da priorização: até onde a ordem cabe na capacidade do setor
+type PrioritizationCapacity struct {
+	CapacityDays     float64  `json:"capacity_days"`
+	Budget           *float64 `json:"budget,omitempty"`
+	UsedDays         float64  `json:"used_days"`       // Esforço acumulado das iniciativas acima do corte
+	UsedCost         float64  `json:"used_cost"`       // Custo acumulado das iniciativas acima do corte
+	CutLine          int      `json:"cut_line"`        // Quantidade de iniciativas que cabem (as N primeiras)
+	UnestimatedIDs   []int64  `json:"unestimated_ids"` // Ranqueadas sem esforço estimado (contam como zero)
+	UnestimatedCount int      `json:"unestimated_count"`
+	RemainingDays    float64  `json:"remaining_days"`
+	RemainingBudget  *float64 `json:"remaining_budget,omitempty"`
+}
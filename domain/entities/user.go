@@ -11,6 +11,12 @@ type User struct {
 	SectorName string    `json:"sector_name,omitempty"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
+
+	PasswordChangedAt  time.Time `json:"-"`
+	MustChangePassword bool      `json:"must_change_password"` // Senha expirada: precisa trocar antes de continuar
+
+	TwoFactorEnabled  bool `json:"two_factor_enabled"`
+	TwoFactorRequired bool `json:"two_factor_required"` // Algum tipo do usuário exige 2FA
 }
 
 type LoginRequest struct {
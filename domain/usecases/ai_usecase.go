@@ -7,4 +7,11 @@ import (
 
 type AIUseCase interface {
 	ProcessText(ctx context.Context, req *entities.AIRequest, userID int64) (*entities.AIResponse, error)
+
+	// Templates de prompt
+	RunPromptTemplate(ctx context.Context, req *entities.RunAIPromptTemplateRequest, userID int64) (*entities.AIResponse, error)
+	ListPromptTemplates(ctx context.Context, userID int64) ([]*entities.AIPromptTemplate, error)
+	CreatePromptTemplate(ctx context.Context, req *entities.CreateAIPromptTemplateRequest, userID int64) (*entities.AIPromptTemplate, error)
+	UpdatePromptTemplate(ctx context.Context, templateID int64, req *entities.UpdateAIPromptTemplateRequest, userID int64) (*entities.AIPromptTemplate, error)
+	ListPromptTemplateVersions(ctx context.Context, templateID int64) ([]*entities.AIPromptTemplateVersion, error)
 }
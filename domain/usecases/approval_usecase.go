@@ -0,0 +1,12 @@
+package usecases
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type ApprovalUseCase interface {
+	ListApprovals(ctx context.Context, userID int64, itemType string) (*entities.ApprovalInbox, error)
+	CountApprovals(ctx context.Context, userID int64) (*entities.ApprovalCounts, error)
+	Decide(ctx context.Context, itemType string, itemID int64, req *entities.ApprovalDecisionRequest, userID int64) error
+}
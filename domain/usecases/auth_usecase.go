@@ -6,7 +6,8 @@ import (
 )
 
 type AuthUseCase interface {
-	Login(ctx context.Context, email, password string) (*entities.User, string, error)
+	Login(ctx context.Context, email, password, ipAddress string) (*entities.User, string, error)
 	GetUserByID(ctx context.Context, userID int64) (*entities.User, error)
 	ValidateCredentials(ctx context.Context, email, password string) (*entities.User, error)
+	VerifyTwoFactor(ctx context.Context, userID int64, code, recoveryCode, ipAddress string) (*entities.User, error)
 }
@@ -10,4 +10,6 @@ type CancellationUseCase interface {
 	ReviewCancellation(ctx context.Context, requestID int64, req *entities.ReviewCancellationRequest, userID int64) error
 	ListPendingCancellations(ctx context.Context, userID int64) ([]*entities.CancellationRequestResponse, error)
 	GetCancellationRequest(ctx context.Context, requestID int64) (*entities.InitiativeCancellationRequest, error)
+	WithdrawCancellation(ctx context.Context, requestID int64, req *entities.WithdrawCancellationRequest, userID int64) error
+	ListMyCancellations(ctx context.Context, userID int64) ([]*entities.CancellationRequestResponse, error)
 }
@@ -9,5 +9,10 @@ type CommentUseCase interface {
 	CreateComment(ctx context.Context, initiativeID int64, req *entities.CreateCommentRequest, userID int64) (*entities.Comment, error)
 	UpdateComment(ctx context.Context, commentID int64, req *entities.UpdateCommentRequest, userID int64) (*entities.Comment, error)
 	DeleteComment(ctx context.Context, commentID int64, userID int64) error
-	ListComments(ctx context.Context, initiativeID int64) ([]*entities.CommentListResponse, error)
+	ListComments(ctx context.Context, initiativeID int64, userID int64) ([]*entities.CommentListResponse, error)
+	AddReaction(ctx context.Context, commentID int64, emoji string, userID int64) error
+	RemoveReaction(ctx context.Context, commentID int64, emoji string, userID int64) error
+	ListRevisions(ctx context.Context, commentID int64, userID int64) ([]*entities.CommentRevisionResponse, error)
+	RestoreComment(ctx context.Context, commentID int64, userID int64) (*entities.Comment, error)
+	PurgeComment(ctx context.Context, commentID int64, userID int64) error
 }
@@ -0,0 +1,14 @@
+package usecases
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type ConsolidationUseCase interface {
+	GenerateRanking(ctx context.Context, req *entities.GenerateConsolidatedRankingRequest, userID int64) (*entities.ConsolidatedRanking, error)
+	GetRanking(ctx context.Context, year int, userID int64) (*entities.ConsolidatedRanking, error)
+	SubmitRanking(ctx context.Context, rankingID int64, userID int64) (*entities.ConsolidatedRanking, error)
+	ReviewRanking(ctx context.Context, rankingID int64, req *entities.ReviewConsolidatedRankingRequest, userID int64) (*entities.ConsolidatedRanking, error)
+	ReopenRanking(ctx context.Context, rankingID int64, req *entities.ReopenConsolidatedRankingRequest, userID int64) (*entities.ConsolidatedRanking, error)
+}
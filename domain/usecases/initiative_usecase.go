@@ -15,4 +15,10 @@ type InitiativeUseCase interface {
 	ReviewInitiative(ctx context.Context, initiativeID int64, req *entities.ReviewInitiativeRequest, userID int64) error
 	ChangeStatus(ctx context.Context, initiativeID int64, req *entities.ChangeInitiativeStatusRequest, userID int64) error
 	GetMyInitiatives(ctx context.Context, userID int64) ([]*entities.Initiative, error)
+	GetApprovalChain(ctx context.Context, initiativeID int64, userID int64) (*entities.ApprovalChain, error)
+	ResubmitInitiative(ctx context.Context, initiativeID int64, req *entities.ResubmitInitiativeRequest, userID int64) (*entities.InitiativeReviewRound, error)
+	ListReviewRounds(ctx context.Context, initiativeID int64) ([]*entities.InitiativeReviewRound, error)
+	ListVersions(ctx context.Context, initiativeID int64) ([]*entities.InitiativeVersion, error)
+	DiffVersions(ctx context.Context, initiativeID int64, fromVersion, toVersion int) (*entities.InitiativeVersionDiff, error)
+	RestoreVersion(ctx context.Context, initiativeID int64, version int, userID int64) (*entities.Initiative, error)
 }
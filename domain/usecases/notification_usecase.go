@@ -0,0 +1,12 @@
+package usecases
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type NotificationUseCase interface {
+	ListNotifications(ctx context.Context, userID int64, filter *entities.NotificationFilter) ([]*entities.NotificationListResponse, int, error)
+	MarkAsRead(ctx context.Context, notificationID, userID int64) error
+	MarkAllAsRead(ctx context.Context, userID int64) error
+}
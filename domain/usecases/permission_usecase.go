@@ -12,4 +12,5 @@ type PermissionUseCase interface {
 	AssignUserType(ctx context.Context, userID, userTypeID int64) error
 	RemoveUserType(ctx context.Context, userID, userTypeID int64) error
 	GetAllUserTypes(ctx context.Context) ([]*entities.UserType, error) // NOVO
+	SetTwoFactorRequired(ctx context.Context, userTypeID int64, required bool) error
 }
@@ -0,0 +1,17 @@
+package usecases
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type PlanningCycleUseCase interface {
+	CreateCycle(ctx context.Context, req *entities.CreatePlanningCycleRequest, userID int64) (*entities.PlanningCycle, error)
+	UpdateCycle(ctx context.Context, cycleID int64, req *entities.UpdatePlanningCycleRequest, userID int64) (*entities.PlanningCycle, error)
+	ListCycles(ctx context.Context, year int) ([]*entities.PlanningCycle, error)
+	GetCycleOverview(ctx context.Context, cycleID int64, userID int64) (*entities.PlanningCycleOverview, error)
+	CloseCycle(ctx context.Context, cycleID int64, userID int64) (*entities.PlanningCycleOverview, error)
+
+	// Abre e encerra os ciclos cuja janela começou ou terminou
+	RunOnce(ctx context.Context) (*entities.PlanningCycleRunResult, error)
+}
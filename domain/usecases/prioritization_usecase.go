@@ -8,6 +8,9 @@ import (
 type PrioritizationUseCase interface {
 	// Priorização
 	SavePrioritization(ctx context.Context, req *entities.SavePrioritizationRequest, userID int64) (*entities.PrioritizationWithInitiatives, error)
+	SaveDraft(ctx context.Context, req *entities.SavePrioritizationRequest, userID int64) (*entities.PrioritizationWithInitiatives, error)
+	SubmitPrioritization(ctx context.Context, req *entities.SavePrioritizationRequest, userID int64) (*entities.PrioritizationWithInitiatives, error)
+	ReopenPrioritization(ctx context.Context, prioritizationID int64, req *entities.ReopenPrioritizationRequest, userID int64) (*entities.PrioritizationWithInitiatives, error)
 	GetPrioritization(ctx context.Context, year int, userID int64) (*entities.PrioritizationWithInitiatives, error)
 	GetAllSectorsPrioritization(ctx context.Context, year int, userID int64) (*entities.AllSectorsPrioritization, error)
 
@@ -15,4 +18,13 @@ type PrioritizationUseCase interface {
 	RequestPrioritizationChange(ctx context.Context, req *entities.RequestPrioritizationChangeRequest, userID int64, year int) (*entities.PrioritizationChangeRequest, error)
 	ReviewPrioritizationChange(ctx context.Context, requestID int64, req *entities.ReviewPrioritizationChangeRequest, userID int64) error
 	ListPendingChangeRequests(ctx context.Context, userID int64) ([]*entities.PrioritizationChangeRequest, error)
+
+	// Versões
+	ListVersions(ctx context.Context, prioritizationID int64, userID int64) ([]*entities.PrioritizationVersion, error)
+	DiffVersions(ctx context.Context, prioritizationID int64, fromVersion, toVersion int, userID int64) (*entities.PrioritizationVersionDiff, error)
+	RollbackVersion(ctx context.Context, prioritizationID int64, version int, req *entities.RollbackPrioritizationRequest, userID int64) (*entities.PrioritizationWithInitiatives, error)
+
+	// Capacidade dos setores
+	SetSectorCapacity(ctx context.Context, req *entities.SetSectorCapacityRequest, userID int64) (*entities.SectorCapacity, error)
+	ListSectorCapacities(ctx context.Context, year int, userID int64) ([]*entities.SectorCapacity, error)
 }
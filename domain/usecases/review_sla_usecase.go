@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type ReviewSLAUseCase interface {
+	RunOnce(ctx context.Context) (*entities.ReviewSLARunResult, error)
+}
@@ -0,0 +1,17 @@
+package usecases
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type ScoringUseCase interface {
+	// Critérios
+	CreateCriterion(ctx context.Context, req *entities.CreateScoringCriterionRequest) (*entities.ScoringCriterion, error)
+	UpdateCriterion(ctx context.Context, criterionID int64, req *entities.UpdateScoringCriterionRequest) (*entities.ScoringCriterion, error)
+	ListCriteria(ctx context.Context, activeOnly bool) ([]*entities.ScoringCriterion, error)
+
+	// Notas
+	ScoreInitiative(ctx context.Context, initiativeID int64, req *entities.ScoreInitiativeRequest, userID int64) (*entities.InitiativeScoreBreakdown, error)
+	GetInitiativeScore(ctx context.Context, initiativeID int64) (*entities.InitiativeScoreBreakdown, error)
+}
@@ -0,0 +1,11 @@
+package usecases
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type SSOUseCase interface {
+	BeginLogin(ctx context.Context) (*entities.OIDCAuthRequest, error)
+	CompleteLogin(ctx context.Context, code string, flow *entities.OIDCFlowState, ipAddress string) (*entities.User, error)
+}
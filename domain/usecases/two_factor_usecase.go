@@ -0,0 +1,14 @@
+package usecases
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type TwoFactorUseCase interface {
+	GetStatus(ctx context.Context, userID int64) (*entities.TwoFactorStatus, error)
+	Setup(ctx context.Context, userID int64) (*entities.TwoFactorSetupResponse, error)
+	Confirm(ctx context.Context, userID int64, code string) ([]string, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) ([]string, error)
+	Disable(ctx context.Context, userID int64, code string) error
+}
@@ -2,22 +2,47 @@ package usecase_impl
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"hackathon-backend/domain/entities"
 	"hackathon-backend/infrastructure/repositories"
+	"regexp"
+	"sort"
+	"strings"
 )
 
+// Tamanho máximo somado dos valores das variáveis (mesmo limite do texto livre)
+const aiTemplateMaxInput = 10000
+
+// Variáveis no formato {{nome}}
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+
 type AIUseCaseImpl struct {
-	aiRepo repositories.AIRepository
+	aiRepo       repositories.AIRepository
+	templateRepo repositories.AIPromptTemplateRepository
+	permRepo     repositories.PermissionRepository
 }
 
-func NewAIUseCaseImpl(aiRepo repositories.AIRepository) *AIUseCaseImpl {
+func NewAIUseCaseImpl(
+	aiRepo repositories.AIRepository,
+	templateRepo repositories.AIPromptTemplateRepository,
+	permRepo repositories.PermissionRepository,
+) *AIUseCaseImpl {
 	return &AIUseCaseImpl{
-		aiRepo: aiRepo,
+		aiRepo:       aiRepo,
+		templateRepo: templateRepo,
+		permRepo:     permRepo,
 	}
 }
 
+// ProcessText envia um prompt livre ao modelo (apenas admin; os demais usam templates)
 func (uc *AIUseCaseImpl) ProcessText(ctx context.Context, req *entities.AIRequest, userID int64) (*entities.AIResponse, error) {
+	isAdmin, err := uc.isAdmin(ctx, userID)
+	if err != nil || !isAdmin {
+		return nil, errors.New("apenas administradores podem usar prompts livres. Use um template em /ai/templates/run")
+	}
+
 	// Validações
 	if req.Text == "" {
 		return nil, errors.New("texto não pode estar vazio")
@@ -38,3 +63,199 @@ func (uc *AIUseCaseImpl) ProcessText(ctx context.Context, req *entities.AIReques
 	// Chamar IA
 	return uc.aiRepo.ProcessText(ctx, req)
 }
+
+// RunPromptTemplate preenche o template ativo com as variáveis enviadas e chama o modelo
+func (uc *AIUseCaseImpl) RunPromptTemplate(ctx context.Context, req *entities.RunAIPromptTemplateRequest, userID int64) (*entities.AIResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("nome do template é obrigatório")
+	}
+
+	template, err := uc.templateRepo.GetByName(ctx, name)
+	if err != nil || !template.Active {
+		return nil, fmt.Errorf("template %q não encontrado", name)
+	}
+
+	prompt, err := renderPromptTemplate(template, req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := uc.aiRepo.Complete(ctx, prompt, &template.AIModelParams)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Prompt = template.Name
+	resp.Metadata.TemplateName = template.Name
+	resp.Metadata.TemplateVersion = template.Version
+	return resp, nil
+}
+
+// ListPromptTemplates lista os templates; apenas admin vê os inativos
+func (uc *AIUseCaseImpl) ListPromptTemplates(ctx context.Context, userID int64) ([]*entities.AIPromptTemplate, error) {
+	isAdmin, err := uc.isAdmin(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.templateRepo.List(ctx, !isAdmin)
+}
+
+func (uc *AIUseCaseImpl) CreatePromptTemplate(ctx context.Context, req *entities.CreateAIPromptTemplateRequest, userID int64) (*entities.AIPromptTemplate, error) {
+	name := strings.TrimSpace(req.Name)
+	if len(name) < 3 || len(name) > 100 {
+		return nil, errors.New("nome do template deve ter entre 3 e 100 caracteres")
+	}
+
+	if _, err := uc.templateRepo.GetByName(ctx, name); err == nil {
+		return nil, errors.New("já existe um template com esse nome")
+	} else if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("erro ao buscar template: %w", err)
+	}
+
+	template := &entities.AIPromptTemplate{
+		Name:            name,
+		Description:     strings.TrimSpace(req.Description),
+		Template:        strings.TrimSpace(req.Template),
+		CreatedByUserID: &userID,
+		AIModelParams:   req.AIModelParams,
+	}
+	template.Model = strings.TrimSpace(template.Model)
+	if err := validatePromptTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := uc.templateRepo.Create(ctx, template); err != nil {
+		return nil, fmt.Errorf("erro ao criar template: %w", err)
+	}
+
+	return template, nil
+}
+
+// UpdatePromptTemplate altera o template; mudanças no texto ou nos parâmetros geram nova versão
+func (uc *AIUseCaseImpl) UpdatePromptTemplate(ctx context.Context, templateID int64, req *entities.UpdateAIPromptTemplateRequest, userID int64) (*entities.AIPromptTemplate, error) {
+	template, err := uc.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, errors.New("template não encontrado")
+	}
+
+	newVersion := false
+	if req.Description != nil {
+		template.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Active != nil {
+		template.Active = *req.Active
+	}
+	if req.Template != nil && strings.TrimSpace(*req.Template) != template.Template {
+		template.Template = strings.TrimSpace(*req.Template)
+		newVersion = true
+	}
+	if req.Temperature != nil && (template.Temperature == nil || *template.Temperature != *req.Temperature) {
+		template.Temperature = req.Temperature
+		newVersion = true
+	}
+	if req.MaxTokens != nil && (template.MaxTokens == nil || *template.MaxTokens != *req.MaxTokens) {
+		template.MaxTokens = req.MaxTokens
+		newVersion = true
+	}
+	if req.Model != nil && strings.TrimSpace(*req.Model) != template.Model {
+		template.Model = strings.TrimSpace(*req.Model)
+		newVersion = true
+	}
+
+	if err := validatePromptTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := uc.templateRepo.Update(ctx, template, newVersion, userID); err != nil {
+		return nil, fmt.Errorf("erro ao atualizar template: %w", err)
+	}
+
+	return template, nil
+}
+
+func (uc *AIUseCaseImpl) ListPromptTemplateVersions(ctx context.Context, templateID int64) ([]*entities.AIPromptTemplateVersion, error) {
+	if _, err := uc.templateRepo.GetByID(ctx, templateID); err != nil {
+		return nil, errors.New("template não encontrado")
+	}
+
+	return uc.templateRepo.ListVersions(ctx, templateID)
+}
+
+// validatePromptTemplate confere texto e parâmetros e atualiza a lista de variáveis
+func validatePromptTemplate(template *entities.AIPromptTemplate) error {
+	if len(template.Template) < 10 {
+		return errors.New("template deve ter no mínimo 10 caracteres")
+	}
+	if template.Temperature != nil && (*template.Temperature < 0 || *template.Temperature > 2) {
+		return errors.New("temperatura deve estar entre 0 e 2")
+	}
+	if template.MaxTokens != nil && *template.MaxTokens < 1 {
+		return errors.New("max_tokens deve ser positivo")
+	}
+
+	template.Variables = templateVariables(template.Template)
+	return nil
+}
+
+// templateVariables retorna as variáveis do template, sem repetição, na ordem em que aparecem
+func templateVariables(text string) []string {
+	variables := []string{}
+	seen := make(map[string]bool)
+	for _, match := range templateVariablePattern.FindAllStringSubmatch(text, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			variables = append(variables, match[1])
+		}
+	}
+	return variables
+}
+
+// renderPromptTemplate substitui as variáveis em uma única passada (valores não são reinterpretados como template)
+func renderPromptTemplate(template *entities.AIPromptTemplate, values map[string]string) (string, error) {
+	declared := make(map[string]bool)
+	varErr := &entities.AIPromptVariablesError{}
+	size := 0
+	for _, variable := range template.Variables {
+		declared[variable] = true
+		value := strings.TrimSpace(values[variable])
+		if value == "" {
+			varErr.Missing = append(varErr.Missing, variable)
+		}
+		size += len(value)
+	}
+	for variable := range values {
+		if !declared[variable] {
+			varErr.Unknown = append(varErr.Unknown, variable)
+		}
+	}
+	sort.Strings(varErr.Unknown)
+
+	if len(varErr.Missing) > 0 || len(varErr.Unknown) > 0 {
+		return "", varErr
+	}
+	if size > aiTemplateMaxInput {
+		return "", fmt.Errorf("texto muito longo (máximo %d caracteres)", aiTemplateMaxInput)
+	}
+
+	return templateVariablePattern.ReplaceAllStringFunc(template.Template, func(match string) string {
+		name := templateVariablePattern.FindStringSubmatch(match)[1]
+		return strings.TrimSpace(values[name])
+	}), nil
+}
+
+func (uc *AIUseCaseImpl) isAdmin(ctx context.Context, userID int64) (bool, error) {
+	userTypes, err := uc.permRepo.GetUserTypes(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, userType := range userTypes {
+		if userType.Name == "admin" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,204 @@
+package usecase_impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/domain/usecases"
+	"hackathon-backend/infrastructure/repositories"
+	"hackathon-backend/settings_loader"
+)
+
+type ApprovalUseCaseImpl struct {
+	slaRepo               repositories.ReviewSLARepository
+	initiativeRepo        repositories.InitiativeRepository
+	permRepo              repositories.PermissionRepository
+	workflow              *approvalWorkflow
+	initiativeUseCase     usecases.InitiativeUseCase
+	cancellationUseCase   usecases.CancellationUseCase
+	prioritizationUseCase usecases.PrioritizationUseCase
+	settings              *settings_loader.SettingsLoader
+}
+
+func NewApprovalUseCaseImpl(
+	slaRepo repositories.ReviewSLARepository,
+	initiativeRepo repositories.InitiativeRepository,
+	decisionRepo repositories.ApprovalDecisionRepository,
+	permRepo repositories.PermissionRepository,
+	authRepo repositories.AuthRepository,
+	initiativeUseCase usecases.InitiativeUseCase,
+	cancellationUseCase usecases.CancellationUseCase,
+	prioritizationUseCase usecases.PrioritizationUseCase,
+	settings *settings_loader.SettingsLoader,
+) *ApprovalUseCaseImpl {
+	return &ApprovalUseCaseImpl{
+		slaRepo:               slaRepo,
+		initiativeRepo:        initiativeRepo,
+		permRepo:              permRepo,
+		workflow:              newApprovalWorkflow(decisionRepo, permRepo, authRepo, settings),
+		initiativeUseCase:     initiativeUseCase,
+		cancellationUseCase:   cancellationUseCase,
+		prioritizationUseCase: prioritizationUseCase,
+		settings:              settings,
+	}
+}
+
+// ListApprovals lista todas as decisões pendentes do revisor (mais antigas primeiro).
+// Os contadores consideram todos os tipos, mesmo com filtro
+func (uc *ApprovalUseCaseImpl) ListApprovals(ctx context.Context, userID int64, itemType string) (*entities.ApprovalInbox, error) {
+	if itemType != "" && !entities.IsValidReviewItemType(itemType) {
+		return nil, errors.New("tipo inválido. Use: initiative, cancellation ou prioritization_change")
+	}
+
+	items, err := uc.pendingItems(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	inbox := &entities.ApprovalInbox{Items: []*entities.ApprovalItem{}}
+	for _, item := range items {
+		approval := toApprovalItem(uc.settings, item)
+		countApproval(&inbox.Counts, approval)
+
+		if itemType == "" || item.Type == itemType {
+			inbox.Items = append(inbox.Items, approval)
+		}
+	}
+
+	return inbox, nil
+}
+
+func (uc *ApprovalUseCaseImpl) CountApprovals(ctx context.Context, userID int64) (*entities.ApprovalCounts, error) {
+	items, err := uc.pendingItems(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := &entities.ApprovalCounts{}
+	for _, item := range items {
+		countApproval(counts, toApprovalItem(uc.settings, item))
+	}
+
+	return counts, nil
+}
+
+// Decide encaminha a decisão para a revisão do tipo correspondente (as regras de cada uma continuam valendo)
+func (uc *ApprovalUseCaseImpl) Decide(ctx context.Context, itemType string, itemID int64, req *entities.ApprovalDecisionRequest, userID int64) error {
+	if itemType != entities.ReviewItemInitiative {
+		switch req.Decision {
+		case "":
+		case entities.ReviewDecisionApprove, entities.ReviewDecisionReject:
+			req.Approved = req.Decision == entities.ReviewDecisionApprove
+		default:
+			return errors.New("decisão inválida. Solicitações aceitam apenas approve ou reject")
+		}
+	}
+
+	switch itemType {
+	case entities.ReviewItemInitiative:
+		return uc.initiativeUseCase.ReviewInitiative(ctx, itemID, &entities.ReviewInitiativeRequest{
+			Approved:       req.Approved,
+			Decision:       req.Decision,
+			Reason:         req.Reason,
+			ChangeRequests: req.ChangeRequests,
+		}, userID)
+	case entities.ReviewItemCancellation:
+		return uc.cancellationUseCase.ReviewCancellation(ctx, itemID, &entities.ReviewCancellationRequest{
+			Approved: req.Approved,
+			Reason:   req.Reason,
+		}, userID)
+	case entities.ReviewItemPrioritizationChange:
+		return uc.prioritizationUseCase.ReviewPrioritizationChange(ctx, itemID, &entities.ReviewPrioritizationChangeRequest{
+			Approved: req.Approved,
+			Reason:   req.Reason,
+			Force:    req.Force,
+		}, userID)
+	default:
+		return errors.New("tipo inválido. Use: initiative, cancellation ou prioritization_change")
+	}
+}
+
+// pendingItems retorna os itens que o usuário pode decidir: revisões únicas exigem admin ou gerente;
+// iniciativas com fluxo de aprovação aparecem para quem decide uma etapa pendente
+func (uc *ApprovalUseCaseImpl) pendingItems(ctx context.Context, userID int64) ([]*entities.PendingReviewItem, error) {
+	isAdminOrManager, err := uc.isAdminOrManager(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao verificar permissões: %w", err)
+	}
+
+	items, err := uc.slaRepo.ListPendingItems(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar aprovações pendentes: %w", err)
+	}
+
+	var allowed []*entities.PendingReviewItem
+	for _, item := range items {
+		if item.Type == entities.ReviewItemInitiative && uc.settings.ApprovalWorkflowFor(item.InitiativeType) != nil {
+			initiative, err := uc.initiativeRepo.GetByID(ctx, item.ID)
+			if err != nil {
+				continue
+			}
+			if _, decidable, err := uc.workflow.chain(ctx, initiative, userID); err == nil && len(decidable) > 0 {
+				allowed = append(allowed, item)
+			}
+			continue
+		}
+
+		if isAdminOrManager {
+			allowed = append(allowed, item)
+		}
+	}
+
+	return allowed, nil
+}
+
+func (uc *ApprovalUseCaseImpl) isAdminOrManager(ctx context.Context, userID int64) (bool, error) {
+	userTypes, err := uc.permRepo.GetUserTypes(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, userType := range userTypes {
+		if userType.Name == "admin" || userType.Name == "manager" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func toApprovalItem(settings *settings_loader.SettingsLoader, item *entities.PendingReviewItem) *entities.ApprovalItem {
+	ageHours, overdue := pendingAge(settings, item.Type, item.PendingSince)
+
+	return &entities.ApprovalItem{
+		Type:              item.Type,
+		TypeLabel:         reviewItemLabel(item.Type),
+		ID:                item.ID,
+		Subject:           item.Subject,
+		InitiativeID:      item.InitiativeID,
+		RequestedByUserID: item.RequestedByUserID,
+		RequestedByName:   item.RequestedByName,
+		Reason:            item.Reason,
+		PendingSince:      item.PendingSince.Format("2006-01-02 15:04:05"),
+		TimeAgo:           timeAgo(item.PendingSince),
+		AgeHours:          ageHours,
+		Overdue:           overdue,
+		EscalationLevel:   item.EscalationLevel,
+	}
+}
+
+func countApproval(counts *entities.ApprovalCounts, item *entities.ApprovalItem) {
+	switch item.Type {
+	case entities.ReviewItemInitiative:
+		counts.Initiative++
+	case entities.ReviewItemCancellation:
+		counts.Cancellation++
+	case entities.ReviewItemPrioritizationChange:
+		counts.PrioritizationChange++
+	}
+	if item.Overdue {
+		counts.Overdue++
+	}
+	counts.Total++
+}
@@ -0,0 +1,268 @@
+package usecase_impl
+
+import (
+	"context"
+	"fmt"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/infrastructure/repositories"
+	"hackathon-backend/settings_loader"
+	"strconv"
+	"strings"
+)
+
+// approvalWorkflow calcula o estado dos fluxos de aprovação em etapas (settings approval.workflows).
+// Usado pela revisão de iniciativas e pela caixa de aprovações
+type approvalWorkflow struct {
+	decisionRepo repositories.ApprovalDecisionRepository
+	permRepo     repositories.PermissionRepository
+	authRepo     repositories.AuthRepository
+	settings     *settings_loader.SettingsLoader
+}
+
+func newApprovalWorkflow(
+	decisionRepo repositories.ApprovalDecisionRepository,
+	permRepo repositories.PermissionRepository,
+	authRepo repositories.AuthRepository,
+	settings *settings_loader.SettingsLoader,
+) *approvalWorkflow {
+	return &approvalWorkflow{
+		decisionRepo: decisionRepo,
+		permRepo:     permRepo,
+		authRepo:     authRepo,
+		settings:     settings,
+	}
+}
+
+// chain monta o estado do fluxo da iniciativa e as etapas que o usuário pode decidir agora.
+// Retorna nil se o tipo da iniciativa não tem fluxo configurado
+func (w *approvalWorkflow) chain(ctx context.Context, initiative *entities.Initiative, userID int64) (*entities.ApprovalChain, []int, error) {
+	workflow := w.settings.ApprovalWorkflowFor(initiative.Type)
+	if workflow == nil {
+		return nil, nil, nil
+	}
+
+	decisions, err := w.decisionRepo.ListByInitiative(ctx, initiative.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao buscar decisões: %w", err)
+	}
+
+	var ownerSectorID *int64
+	if owner, err := w.authRepo.GetUserByID(ctx, initiative.OwnerID); err == nil {
+		ownerSectorID = owner.SectorID
+	}
+
+	chain := &entities.ApprovalChain{
+		InitiativeID:   initiative.ID,
+		InitiativeType: initiative.Type,
+		Mode:           workflow.Mode,
+		Status:         entities.ApprovalChainApproved,
+	}
+
+	var decidable []int
+	previousApproved := true
+
+	for i, step := range workflow.Steps {
+		eligible, fallback, err := w.stepApprovers(ctx, step, initiative.OwnerID, ownerSectorID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		chainStep := &entities.ApprovalChainStep{
+			Index:         i,
+			Name:          step.Name,
+			AssignedTo:    stepAssignee(step),
+			Quorum:        step.Quorum,
+			Required:      quorumRequired(step.Quorum, len(eligible)),
+			Decisions:     []*entities.ApprovalDecision{},
+			AdminFallback: fallback,
+		}
+		if fallback {
+			chainStep.AssignedTo = "admin (nenhum aprovador elegível para " + stepAssignee(step) + ")"
+		}
+
+		rejected := false
+		decided := false
+		for _, decision := range decisions {
+			// Decisões do próprio solicitante não contam (registros anteriores à exclusão do dono)
+			if decision.StepIndex != i || decision.UserID == initiative.OwnerID {
+				continue
+			}
+			chainStep.Decisions = append(chainStep.Decisions, decision)
+			if decision.Approved {
+				chainStep.Approvals++
+			} else {
+				rejected = true
+			}
+			if decision.UserID == userID {
+				decided = true
+			}
+		}
+
+		switch {
+		case rejected:
+			chainStep.Status = entities.ApprovalStepRejected
+		case chainStep.Approvals >= chainStep.Required:
+			chainStep.Status = entities.ApprovalStepApproved
+		case workflow.Mode == settings_loader.ApprovalModeSequential && !previousApproved:
+			chainStep.Status = entities.ApprovalStepWaiting
+		default:
+			chainStep.Status = entities.ApprovalStepPending
+			if eligible[userID] && !decided {
+				decidable = append(decidable, i)
+			}
+		}
+
+		if chainStep.Status != entities.ApprovalStepApproved {
+			previousApproved = false
+		}
+		if rejected {
+			chain.Status = entities.ApprovalChainRejected
+		} else if chainStep.Status != entities.ApprovalStepApproved && chain.Status != entities.ApprovalChainRejected {
+			chain.Status = entities.ApprovalChainInProgress
+		}
+
+		chain.Steps = append(chain.Steps, chainStep)
+	}
+
+	if chain.Status == entities.ApprovalChainRejected {
+		decidable = nil
+	}
+	chain.CanDecide = len(decidable) > 0
+
+	return chain, decidable, nil
+}
+
+// pendingApprovers retorna os usuários que ainda podem decidir alguma etapa pendente do fluxo
+func (w *approvalWorkflow) pendingApprovers(ctx context.Context, initiative *entities.Initiative, chain *entities.ApprovalChain) ([]int64, error) {
+	workflow := w.settings.ApprovalWorkflowFor(initiative.Type)
+	if workflow == nil || chain.Status != entities.ApprovalChainInProgress {
+		return nil, nil
+	}
+
+	var ownerSectorID *int64
+	if owner, err := w.authRepo.GetUserByID(ctx, initiative.OwnerID); err == nil {
+		ownerSectorID = owner.SectorID
+	}
+
+	seen := make(map[int64]bool)
+	var userIDs []int64
+	for _, chainStep := range chain.Steps {
+		if chainStep.Status != entities.ApprovalStepPending {
+			continue
+		}
+
+		decided := make(map[int64]bool)
+		for _, decision := range chainStep.Decisions {
+			decided[decision.UserID] = true
+		}
+
+		eligible, _, err := w.stepApprovers(ctx, workflow.Steps[chainStep.Index], initiative.OwnerID, ownerSectorID)
+		if err != nil {
+			return nil, err
+		}
+		for userID := range eligible {
+			if !decided[userID] && !seen[userID] {
+				seen[userID] = true
+				userIDs = append(userIDs, userID)
+			}
+		}
+	}
+
+	return userIDs, nil
+}
+
+// stepApprovers resolve os aprovadores da etapa; sem nenhum elegível, a etapa passa para os
+// administradores (fallback = true) em vez de exigir aprovações que ninguém pode dar.
+// O dono da iniciativa nunca aprova a própria solicitação
+func (w *approvalWorkflow) stepApprovers(ctx context.Context, step settings_loader.ApprovalStep, ownerID int64, ownerSectorID *int64) (map[int64]bool, bool, error) {
+	eligible, err := w.eligibleUsers(ctx, step, ownerID, ownerSectorID)
+	if err != nil || len(eligible) > 0 {
+		return eligible, false, err
+	}
+
+	adminIDs, err := w.permRepo.ListUserIDsByType(ctx, "admin")
+	if err != nil {
+		return nil, false, fmt.Errorf("erro ao buscar administradores para a etapa %s: %w", step.Name, err)
+	}
+
+	for _, adminID := range adminIDs {
+		if adminID != ownerID {
+			eligible[adminID] = true
+		}
+	}
+	if len(eligible) == 0 {
+		return nil, false, fmt.Errorf("etapa %s não tem aprovadores elegíveis além do solicitante", step.Name)
+	}
+	return eligible, true, nil
+}
+
+// eligibleUsers resolve os aprovadores de uma etapa, sem o dono da iniciativa
+func (w *approvalWorkflow) eligibleUsers(ctx context.Context, step settings_loader.ApprovalStep, ownerID int64, ownerSectorID *int64) (map[int64]bool, error) {
+	eligible := make(map[int64]bool)
+
+	if step.UserType == "" {
+		for _, email := range step.UserEmails {
+			user, err := w.authRepo.GetUserByEmail(ctx, email)
+			if err != nil || user.ID == ownerID {
+				continue
+			}
+			eligible[user.ID] = true
+		}
+		return eligible, nil
+	}
+
+	userIDs, err := w.permRepo.ListUserIDsByType(ctx, step.UserType)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar aprovadores da etapa %s: %w", step.Name, err)
+	}
+
+	for _, userID := range userIDs {
+		if userID == ownerID {
+			continue
+		}
+		if step.SameSector {
+			user, err := w.authRepo.GetUserByID(ctx, userID)
+			if err != nil || user.SectorID == nil || ownerSectorID == nil || *user.SectorID != *ownerSectorID {
+				continue
+			}
+		}
+		eligible[userID] = true
+	}
+
+	return eligible, nil
+}
+
+// quorumRequired converte a regra de quórum no número de aprovações necessárias
+// (mínimo 1, limitado ao número de aprovadores elegíveis)
+func quorumRequired(quorum string, eligible int) int {
+	required := 1
+	switch quorum {
+	case settings_loader.ApprovalQuorumAny:
+	case settings_loader.ApprovalQuorumAll:
+		required = eligible
+	case settings_loader.ApprovalQuorumMajority:
+		required = eligible/2 + 1
+	default:
+		if n, err := strconv.Atoi(quorum); err == nil {
+			required = n
+		}
+	}
+
+	if eligible > 0 && required > eligible {
+		required = eligible
+	}
+	if required < 1 {
+		required = 1
+	}
+	return required
+}
+
+func stepAssignee(step settings_loader.ApprovalStep) string {
+	if step.UserType == "" {
+		return strings.Join(step.UserEmails, ", ")
+	}
+	if step.SameSector {
+		return step.UserType + " (setor do solicitante)"
+	}
+	return step.UserType
+}
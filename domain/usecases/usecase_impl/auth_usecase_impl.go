@@ -2,40 +2,153 @@ package usecase_impl
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"hackathon-backend/domain/entities"
 	"hackathon-backend/infrastructure/repositories"
 	"hackathon-backend/settings_loader"
+	"log"
+	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthUseCaseImpl struct {
-	authRepo repositories.AuthRepository
-	settings *settings_loader.SettingsLoader
+	authRepo         repositories.AuthRepository
+	loginAttemptRepo repositories.LoginAttemptRepository
+	twoFactorRepo    repositories.TwoFactorRepository
+	settings         *settings_loader.SettingsLoader
+	dummyHash        []byte // Usado para emails inexistentes (tempo constante)
 }
 
-func NewAuthUseCaseImpl(authRepo repositories.AuthRepository, settings *settings_loader.SettingsLoader) *AuthUseCaseImpl {
+func NewAuthUseCaseImpl(
+	authRepo repositories.AuthRepository,
+	loginAttemptRepo repositories.LoginAttemptRepository,
+	twoFactorRepo repositories.TwoFactorRepository,
+	settings *settings_loader.SettingsLoader,
+) *AuthUseCaseImpl {
+	dummyHash, err := bcrypt.GenerateFromPassword([]byte("dummy-password-for-timing"), settings.Password.BcryptCost)
+	if err != nil {
+		log.Fatalf("Erro ao gerar hash de referência: %v", err)
+	}
+
 	return &AuthUseCaseImpl{
-		authRepo: authRepo,
-		settings: settings,
+		authRepo:         authRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		twoFactorRepo:    twoFactorRepo,
+		settings:         settings,
+		dummyHash:        dummyHash,
 	}
 }
 
-func (uc *AuthUseCaseImpl) Login(ctx context.Context, email, password string) (*entities.User, string, error) {
+func (uc *AuthUseCaseImpl) Login(ctx context.Context, email, password, ipAddress string) (*entities.User, string, error) {
+	// Identificador normalizado da conta para contadores e auditoria
+	account := normalizeEmail(email)
+
+	// Verificar bloqueio por conta e por IP antes de validar a senha
+	if err := uc.checkLockout(ctx, account, ipAddress); err != nil {
+		uc.recordAttempt(ctx, account, ipAddress, false, entities.LoginFailureThrottled)
+		return nil, "", err
+	}
+
+	// Com SSO obrigatório, apenas as contas de emergência (break-glass) usam senha
+	if !uc.localLoginAllowed(account) {
+		uc.recordAttempt(ctx, account, ipAddress, false, entities.LoginFailureLocalLoginDisabled)
+		return nil, "", errors.New("login com senha desabilitado; utilize o login corporativo (SSO)")
+	}
+
 	// Validar credenciais
 	user, err := uc.ValidateCredentials(ctx, email, password)
 	if err != nil {
+		uc.registerFailure(ctx, account, ipAddress)
+		uc.recordAttempt(ctx, account, ipAddress, false, entities.LoginFailureInvalidCredentials)
 		return nil, "", err
 	}
 
+	// Sucesso: zerar o contador da conta (o do IP expira pela janela).
+	// Com 2FA ativo o login só é concluído após o código, em VerifyTwoFactor.
+	if !user.TwoFactorEnabled {
+		uc.loginSucceeded(ctx, account, ipAddress)
+	}
+
+	// Custo do bcrypt aumentou: regerar o hash de forma transparente
+	if needsRehash(uc.settings.Password, user.Password) {
+		if hashed, err := hashPassword(uc.settings.Password, password); err == nil {
+			if err := uc.authRepo.RehashPassword(ctx, user.ID, hashed); err != nil {
+				log.Printf("Erro ao regerar hash de senha: %v", err)
+			}
+		}
+	}
+
+	// Senha expirada: forçar a troca antes de liberar o restante da API
+	if uc.isPasswordExpired(user) && !user.MustChangePassword {
+		if err := uc.authRepo.SetMustChangePassword(ctx, user.ID, true); err != nil {
+			log.Printf("Erro ao marcar troca de senha obrigatória: %v", err)
+		}
+		user.MustChangePassword = true
+	}
+
 	// Gerar token (neste caso, retornamos o ID como string)
 	token := fmt.Sprintf("%d", user.ID)
 
 	return user, token, nil
 }
 
+// VerifyTwoFactor conclui o login de quem tem 2FA ativo, com código TOTP ou de recuperação
+func (uc *AuthUseCaseImpl) VerifyTwoFactor(ctx context.Context, userID int64, code, recoveryCode, ipAddress string) (*entities.User, error) {
+	user, err := uc.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("credenciais inválidas")
+	}
+
+	account := normalizeEmail(user.Email)
+
+	// Os códigos contam como tentativas de login: mesmo bloqueio e backoff da senha
+	if err := uc.checkLockout(ctx, account, ipAddress); err != nil {
+		uc.recordAttempt(ctx, account, ipAddress, false, entities.LoginFailureThrottled)
+		return nil, err
+	}
+
+	if err := uc.verifySecondFactor(ctx, userID, code, recoveryCode); err != nil {
+		uc.registerFailure(ctx, account, ipAddress)
+		uc.recordAttempt(ctx, account, ipAddress, false, entities.LoginFailureInvalidTwoFactor)
+		return nil, err
+	}
+
+	uc.loginSucceeded(ctx, account, ipAddress)
+
+	return user, nil
+}
+
+func (uc *AuthUseCaseImpl) verifySecondFactor(ctx context.Context, userID int64, code, recoveryCode string) error {
+	tf, err := uc.twoFactorRepo.Get(ctx, userID)
+	if err != nil || !tf.Enabled {
+		return errors.New("autenticação em dois fatores não está ativada")
+	}
+
+	if strings.TrimSpace(recoveryCode) != "" {
+		used, err := uc.twoFactorRepo.UseRecoveryCode(ctx, userID, hashRecoveryCode(recoveryCode))
+		if err != nil {
+			return fmt.Errorf("erro ao validar código de recuperação: %w", err)
+		}
+		if !used {
+			return errors.New("código de recuperação inválido")
+		}
+		return nil
+	}
+
+	return verifyTOTPCode(ctx, uc.twoFactorRepo, tf, code)
+}
+
+func (uc *AuthUseCaseImpl) loginSucceeded(ctx context.Context, account, ipAddress string) {
+	if err := uc.loginAttemptRepo.ClearLockout(ctx, entities.LoginLockoutScopeAccount, account); err != nil {
+		log.Printf("Erro ao limpar bloqueio de login: %v", err)
+	}
+	uc.recordAttempt(ctx, account, ipAddress, true, "")
+}
+
 func (uc *AuthUseCaseImpl) GetUserByID(ctx context.Context, userID int64) (*entities.User, error) {
 	return uc.authRepo.GetUserByID(ctx, userID)
 }
@@ -43,6 +156,8 @@ func (uc *AuthUseCaseImpl) GetUserByID(ctx context.Context, userID int64) (*enti
 func (uc *AuthUseCaseImpl) ValidateCredentials(ctx context.Context, email, password string) (*entities.User, error) {
 	user, err := uc.authRepo.GetUserByEmail(ctx, email)
 	if err != nil {
+		// Executar o bcrypt mesmo assim para não revelar pelo tempo de resposta se o email existe
+		bcrypt.CompareHashAndPassword(uc.dummyHash, []byte(password))
 		return nil, errors.New("credenciais inválidas")
 	}
 
@@ -53,3 +168,138 @@ func (uc *AuthUseCaseImpl) ValidateCredentials(ctx context.Context, email, passw
 
 	return user, nil
 }
+
+// checkLockout retorna LoginThrottledError se a conta ou o IP estiverem bloqueados
+func (uc *AuthUseCaseImpl) checkLockout(ctx context.Context, email, ipAddress string) error {
+	return checkLoginLockout(ctx, uc.loginAttemptRepo, email, ipAddress)
+}
+
+// checkLoginLockout também é usada pelo login corporativo (SSO)
+func checkLoginLockout(ctx context.Context, loginAttemptRepo repositories.LoginAttemptRepository, email, ipAddress string) error {
+	var retryAfter time.Duration
+
+	for _, key := range lockoutKeys(email, ipAddress) {
+		lockout, err := loginAttemptRepo.GetLockout(ctx, key[0], key[1])
+		if err != nil {
+			if err != sql.ErrNoRows {
+				log.Printf("Erro ao verificar bloqueio de login: %v", err)
+			}
+			continue
+		}
+
+		if lockout.LockedUntil != nil {
+			if remaining := time.Until(*lockout.LockedUntil); remaining > retryAfter {
+				retryAfter = remaining
+			}
+		}
+	}
+
+	if retryAfter > 0 {
+		return &entities.LoginThrottledError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// registerFailure incrementa os contadores e aplica backoff exponencial / bloqueio
+func (uc *AuthUseCaseImpl) registerFailure(ctx context.Context, email, ipAddress string) {
+	security := uc.settings.Security
+	window := time.Duration(security.LoginAttemptWindowSeconds) * time.Second
+
+	for _, key := range lockoutKeys(email, ipAddress) {
+		failedCount, err := uc.loginAttemptRepo.IncrementFailures(ctx, key[0], key[1], window)
+		if err != nil {
+			log.Printf("Erro ao registrar falha de login: %v", err)
+			continue
+		}
+
+		maxAttempts := security.LoginMaxAttempts
+		if key[0] == entities.LoginLockoutScopeIP {
+			maxAttempts = security.LoginIPMaxAttempts
+		}
+
+		delay := loginDelay(failedCount, maxAttempts, security)
+		if err := uc.loginAttemptRepo.SetLockedUntil(ctx, key[0], key[1], time.Now().Add(delay)); err != nil {
+			log.Printf("Erro ao bloquear login: %v", err)
+		}
+	}
+}
+
+func (uc *AuthUseCaseImpl) isPasswordExpired(user *entities.User) bool {
+	expiryDays := uc.settings.Password.ExpiryDays
+	if expiryDays <= 0 {
+		return false
+	}
+	return time.Since(user.PasswordChangedAt) > time.Duration(expiryDays)*24*time.Hour
+}
+
+func (uc *AuthUseCaseImpl) localLoginAllowed(account string) bool {
+	oidc := uc.settings.OIDC
+	if !oidc.Enabled || !oidc.DisableLocalLogin {
+		return true
+	}
+
+	return isBreakGlassAccount(uc.settings, account)
+}
+
+// isBreakGlassAccount indica se a conta é de emergência (oidc.break_glass_emails)
+func isBreakGlassAccount(settings *settings_loader.SettingsLoader, account string) bool {
+	for _, email := range settings.OIDC.BreakGlassEmails {
+		if normalizeEmail(email) == account {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func lockoutKeys(email, ipAddress string) [][2]string {
+	keys := [][2]string{{entities.LoginLockoutScopeAccount, email}}
+	if ipAddress != "" {
+		keys = append(keys, [2]string{entities.LoginLockoutScopeIP, ipAddress})
+	}
+	return keys
+}
+
+func (uc *AuthUseCaseImpl) recordAttempt(ctx context.Context, email, ipAddress string, success bool, failureReason string) {
+	attempt := &entities.LoginAttempt{
+		Email:         email,
+		IPAddress:     ipAddress,
+		Success:       success,
+		FailureReason: failureReason,
+	}
+
+	if err := uc.loginAttemptRepo.RecordAttempt(ctx, attempt); err != nil {
+		// Log do erro mas não falha a operação
+		log.Printf("Erro ao registrar tentativa de login: %v", err)
+	}
+}
+
+// loginDelay calcula a espera após N falhas:
+// antes do limite, backoff exponencial (base, 2x base, 4x base...);
+// a partir do limite, bloqueio que dobra a cada nova falha até o máximo.
+func loginDelay(failedCount, maxAttempts int, security settings_loader.SecurityConfig) time.Duration {
+	base := time.Duration(security.LoginBackoffBaseSeconds) * time.Second
+	exponent := failedCount - 1
+
+	if failedCount >= maxAttempts {
+		base = time.Duration(security.LoginLockoutSeconds) * time.Second
+		exponent = failedCount - maxAttempts
+	}
+
+	maxDelay := time.Duration(security.LoginMaxLockoutSeconds) * time.Second
+	delay := base
+	for i := 0; i < exponent && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	return delay
+}
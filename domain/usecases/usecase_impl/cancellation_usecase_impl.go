@@ -2,10 +2,13 @@ package usecase_impl
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"hackathon-backend/domain/entities"
 	"hackathon-backend/infrastructure/repositories"
+	"hackathon-backend/settings_loader"
+	"time"
 )
 
 type CancellationUseCaseImpl struct {
@@ -13,6 +16,8 @@ type CancellationUseCaseImpl struct {
 	initiativeRepo   repositories.InitiativeRepository
 	historyRepo      repositories.InitiativeHistoryRepository // NOVO
 	permRepo         repositories.PermissionRepository
+	authRepo         repositories.AuthRepository
+	settings         *settings_loader.SettingsLoader
 }
 
 func NewCancellationUseCaseImpl(
@@ -20,12 +25,16 @@ func NewCancellationUseCaseImpl(
 	initiativeRepo repositories.InitiativeRepository,
 	historyRepo repositories.InitiativeHistoryRepository, // NOVO
 	permRepo repositories.PermissionRepository,
+	authRepo repositories.AuthRepository,
+	settings *settings_loader.SettingsLoader,
 ) *CancellationUseCaseImpl {
 	return &CancellationUseCaseImpl{
 		cancellationRepo: cancellationRepo,
 		initiativeRepo:   initiativeRepo,
 		historyRepo:      historyRepo, // NOVO
 		permRepo:         permRepo,
+		authRepo:         authRepo,
+		settings:         settings,
 	}
 }
 
@@ -46,12 +55,27 @@ func (uc *CancellationUseCaseImpl) RequestCancellation(ctx context.Context, init
 		return nil, errors.New("iniciativa já está cancelada")
 	}
 
+	// Verificar quem pode solicitar (cancellation.allowed_requesters)
+	allowed, err := uc.canRequest(ctx, initiative, userID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao verificar permissões: %w", err)
+	}
+	if !allowed {
+		return nil, errors.New("você não tem permissão para solicitar o cancelamento desta iniciativa")
+	}
+
 	// Verificar se já tem solicitação pendente
 	hasPending, err := uc.cancellationRepo.HasPendingRequest(ctx, initiativeID)
 	if err == nil && hasPending {
 		return nil, errors.New("já existe uma solicitação de cancelamento pendente para esta iniciativa")
 	}
 
+	// Espera após uma reprovação
+	if allowedAt := uc.newRequestAllowedAt(ctx, initiativeID); allowedAt != nil && time.Now().Before(*allowedAt) {
+		return nil, fmt.Errorf("a última solicitação de cancelamento foi reprovada; uma nova só pode ser feita a partir de %s",
+			allowedAt.Format("2006-01-02 15:04"))
+	}
+
 	// Criar solicitação
 	cancellationReq := &entities.InitiativeCancellationRequest{
 		InitiativeID:      initiativeID,
@@ -168,6 +192,8 @@ func (uc *CancellationUseCaseImpl) ListPendingCancellations(ctx context.Context,
 			continue
 		}
 
+		ageHours, overdue := pendingAge(uc.settings, entities.ReviewItemCancellation, req.CreatedAt)
+
 		response = append(response, &entities.CancellationRequestResponse{
 			ID:                req.ID,
 			InitiativeID:      req.InitiativeID,
@@ -178,16 +204,200 @@ func (uc *CancellationUseCaseImpl) ListPendingCancellations(ctx context.Context,
 			Status:            req.Status,
 			CreatedAt:         req.CreatedAt.Format("2006-01-02 15:04:05"),
 			TimeAgo:           timeAgo(req.CreatedAt),
+			AgeHours:          ageHours,
+			Overdue:           overdue,
 		})
 	}
 
 	return response, nil
 }
 
+// WithdrawCancellation retira uma solicitação pendente (apenas o próprio solicitante)
+func (uc *CancellationUseCaseImpl) WithdrawCancellation(ctx context.Context, requestID int64, req *entities.WithdrawCancellationRequest, userID int64) error {
+	cancellationReq, err := uc.cancellationRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return errors.New("solicitação de cancelamento não encontrada")
+	}
+
+	if cancellationReq.RequestedByUserID != userID {
+		return errors.New("apenas quem fez a solicitação pode retirá-la")
+	}
+
+	if cancellationReq.Status != entities.CancellationStatusPending {
+		return errors.New("apenas solicitações pendentes podem ser retiradas")
+	}
+
+	if err := uc.cancellationRepo.Withdraw(ctx, requestID, req.Reason); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("esta solicitação já foi revisada")
+		}
+		return fmt.Errorf("erro ao retirar solicitação: %w", err)
+	}
+
+	initiative, err := uc.initiativeRepo.GetByID(ctx, cancellationReq.InitiativeID)
+	if err != nil {
+		return nil
+	}
+
+	reason := "↩️ Solicitação de cancelamento retirada pelo solicitante"
+	if req.Reason != "" {
+		reason += ": " + req.Reason
+	}
+
+	history := &entities.InitiativeHistory{
+		InitiativeID: cancellationReq.InitiativeID,
+		UserID:       userID,
+		OldStatus:    initiative.Status,
+		NewStatus:    initiative.Status,
+		Reason:       reason,
+	}
+
+	if err := uc.historyRepo.Create(ctx, history); err != nil {
+		// Log do erro mas não falha a operação
+		fmt.Printf("Erro ao registrar histórico de retirada: %v\n", err)
+	}
+
+	return nil
+}
+
+// ListMyCancellations lista as solicitações feitas pelo usuário, com a linha do tempo de cada uma
+func (uc *CancellationUseCaseImpl) ListMyCancellations(ctx context.Context, userID int64) ([]*entities.CancellationRequestResponse, error) {
+	requests, err := uc.cancellationRepo.ListByRequester(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar solicitações: %w", err)
+	}
+
+	response := make([]*entities.CancellationRequestResponse, 0, len(requests))
+	for _, req := range requests {
+		item := &entities.CancellationRequestResponse{
+			ID:                req.ID,
+			InitiativeID:      req.InitiativeID,
+			InitiativeTitle:   req.InitiativeTitle,
+			RequestedByUserID: req.RequestedByUserID,
+			RequestedByName:   req.RequestedByName,
+			Reason:            req.Reason,
+			Status:            req.Status,
+			ReviewedByName:    req.ReviewedByName,
+			ReviewReason:      req.ReviewReason,
+			CreatedAt:         req.CreatedAt.Format("2006-01-02 15:04:05"),
+			TimeAgo:           timeAgo(req.CreatedAt),
+			History:           cancellationTimeline(req),
+		}
+
+		if req.ReviewedAt != nil {
+			item.ReviewedAt = req.ReviewedAt.Format("2006-01-02 15:04:05")
+
+			if req.Status == entities.CancellationStatusRejected {
+				allowedAt := req.ReviewedAt.Add(uc.cooldown())
+				if time.Now().Before(allowedAt) {
+					item.NewRequestAllowedAt = allowedAt.Format("2006-01-02 15:04:05")
+				}
+			}
+		}
+
+		response = append(response, item)
+	}
+
+	return response, nil
+}
+
 func (uc *CancellationUseCaseImpl) GetCancellationRequest(ctx context.Context, requestID int64) (*entities.InitiativeCancellationRequest, error) {
 	return uc.cancellationRepo.GetByID(ctx, requestID)
 }
 
+// canRequest aplica a regra configurada de quem pode solicitar cancelamento
+func (uc *CancellationUseCaseImpl) canRequest(ctx context.Context, initiative *entities.Initiative, userID int64) (bool, error) {
+	allowed := make(map[string]bool)
+	for _, requester := range uc.settings.Cancellation.AllowedRequesters {
+		allowed[requester] = true
+	}
+
+	if allowed[settings_loader.CancellationRequesterOwner] && initiative.OwnerID == userID {
+		return true, nil
+	}
+
+	if allowed[settings_loader.CancellationRequesterAdmin] || allowed[settings_loader.CancellationRequesterManager] {
+		userTypes, err := uc.permRepo.GetUserTypes(ctx, userID)
+		if err != nil {
+			return false, err
+		}
+		for _, userType := range userTypes {
+			if allowed[userType.Name] && (userType.Name == "admin" || userType.Name == "manager") {
+				return true, nil
+			}
+		}
+	}
+
+	if allowed[settings_loader.CancellationRequesterSector] {
+		user, err := uc.authRepo.GetUserByID(ctx, userID)
+		if err != nil {
+			return false, err
+		}
+		owner, err := uc.authRepo.GetUserByID(ctx, initiative.OwnerID)
+		if err != nil {
+			return false, err
+		}
+		if user.SectorID != nil && owner.SectorID != nil && *user.SectorID == *owner.SectorID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// newRequestAllowedAt retorna quando termina a espera após a última reprovação (nil se não houver)
+func (uc *CancellationUseCaseImpl) newRequestAllowedAt(ctx context.Context, initiativeID int64) *time.Time {
+	rejected, err := uc.cancellationRepo.GetLatestRejected(ctx, initiativeID)
+	if err != nil || rejected.ReviewedAt == nil {
+		return nil
+	}
+
+	allowedAt := rejected.ReviewedAt.Add(uc.cooldown())
+	return &allowedAt
+}
+
+func (uc *CancellationUseCaseImpl) cooldown() time.Duration {
+	return time.Duration(uc.settings.Cancellation.RejectionCooldownHours) * time.Hour
+}
+
+// cancellationTimeline monta os eventos da solicitação em ordem cronológica
+func cancellationTimeline(req *entities.InitiativeCancellationRequest) []*entities.CancellationRequestEvent {
+	events := []*entities.CancellationRequestEvent{
+		{
+			Status:   entities.CancellationStatusPending,
+			UserID:   req.RequestedByUserID,
+			UserName: req.RequestedByName,
+			Reason:   req.Reason,
+			Date:     req.CreatedAt.Format("2006-01-02 15:04:05"),
+			TimeAgo:  timeAgo(req.CreatedAt),
+		},
+	}
+
+	if req.WithdrawnAt != nil {
+		events = append(events, &entities.CancellationRequestEvent{
+			Status:   entities.CancellationStatusWithdrawn,
+			UserID:   req.RequestedByUserID,
+			UserName: req.RequestedByName,
+			Reason:   req.WithdrawReason,
+			Date:     req.WithdrawnAt.Format("2006-01-02 15:04:05"),
+			TimeAgo:  timeAgo(*req.WithdrawnAt),
+		})
+	}
+
+	if req.ReviewedAt != nil && req.ReviewedByUserID != nil {
+		events = append(events, &entities.CancellationRequestEvent{
+			Status:   req.Status,
+			UserID:   *req.ReviewedByUserID,
+			UserName: req.ReviewedByName,
+			Reason:   req.ReviewReason,
+			Date:     req.ReviewedAt.Format("2006-01-02 15:04:05"),
+			TimeAgo:  timeAgo(*req.ReviewedAt),
+		})
+	}
+
+	return events
+}
+
 func (uc *CancellationUseCaseImpl) isAdminOrManager(ctx context.Context, userID int64) (bool, error) {
 	userTypes, err := uc.permRepo.GetUserTypes(ctx, userID)
 	if err != nil {
@@ -0,0 +1,51 @@
+package usecase_impl
+
+import "hackathon-backend/domain/entities"
+
+// computeCapacity percorre a ordem acumulando esforço e custo; a primeira iniciativa que estoura a
+// capacidade (ou o orçamento, se definido) define a linha de corte e todas as seguintes ficam abaixo dela
+func computeCapacity(capacity *entities.SectorCapacity, initiatives []*entities.InitiativeListResponse) *entities.PrioritizationCapacity {
+	result := &entities.PrioritizationCapacity{
+		CapacityDays:   capacity.CapacityDays,
+		Budget:         capacity.Budget,
+		UnestimatedIDs: []int64{},
+	}
+
+	cut := false
+	for _, initiative := range initiatives {
+		if initiative.EffortDays == nil {
+			result.UnestimatedIDs = append(result.UnestimatedIDs, initiative.ID)
+		}
+
+		if !cut {
+			days := result.UsedDays + valueOrZero(initiative.EffortDays)
+			cost := result.UsedCost + valueOrZero(initiative.EstimatedCost)
+			if days > capacity.CapacityDays || (capacity.Budget != nil && cost > *capacity.Budget) {
+				cut = true
+			} else {
+				result.UsedDays = days
+				result.UsedCost = cost
+				result.CutLine++
+			}
+		}
+
+		fits := !cut
+		initiative.FitsCapacity = &fits
+	}
+
+	result.UnestimatedCount = len(result.UnestimatedIDs)
+	result.RemainingDays = capacity.CapacityDays - result.UsedDays
+	if capacity.Budget != nil {
+		remaining := *capacity.Budget - result.UsedCost
+		result.RemainingBudget = &remaining
+	}
+
+	return result
+}
+
+func valueOrZero(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -6,30 +6,46 @@ import (
 	"fmt"
 	"hackathon-backend/domain/entities"
 	"hackathon-backend/infrastructure/repositories"
+	"hackathon-backend/utils/markdown"
+	"regexp"
+	"strings"
 	"time"
 )
 
 type CommentUseCaseImpl struct {
-	commentRepo    repositories.CommentRepository
-	initiativeRepo repositories.InitiativeRepository
-	permRepo       repositories.PermissionRepository
+	commentRepo      repositories.CommentRepository
+	initiativeRepo   repositories.InitiativeRepository
+	permRepo         repositories.PermissionRepository
+	authRepo         repositories.AuthRepository
+	notificationRepo repositories.NotificationRepository
 }
 
 func NewCommentUseCaseImpl(
 	commentRepo repositories.CommentRepository,
 	initiativeRepo repositories.InitiativeRepository,
 	permRepo repositories.PermissionRepository,
+	authRepo repositories.AuthRepository,
+	notificationRepo repositories.NotificationRepository,
 ) *CommentUseCaseImpl {
 	return &CommentUseCaseImpl{
-		commentRepo:    commentRepo,
-		initiativeRepo: initiativeRepo,
-		permRepo:       permRepo,
+		commentRepo:      commentRepo,
+		initiativeRepo:   initiativeRepo,
+		permRepo:         permRepo,
+		authRepo:         authRepo,
+		notificationRepo: notificationRepo,
 	}
 }
 
+// Permissão para publicar notas internas. Não é uma rota: é concedida por tipo de
+// usuário em user_type_permissions, como as demais.
+const internalNotePermission = "/api/private/comments/internal"
+
+// Menções: @usuario (parte do email antes do @) ou @email@dominio completo
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9._%+-]+(?:@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)+)?)`)
+
 func (uc *CommentUseCaseImpl) CreateComment(ctx context.Context, initiativeID int64, req *entities.CreateCommentRequest, userID int64) (*entities.Comment, error) {
-	// Validar conteúdo
-	if len(req.Content) < 3 {
+	// Validar conteúdo (o mínimo vale para o texto, sem a marcação Markdown)
+	if len(markdown.PlainText(req.Content)) < 3 {
 		return nil, errors.New("comentário deve ter no mínimo 3 caracteres")
 	}
 
@@ -38,15 +54,49 @@ func (uc *CommentUseCaseImpl) CreateComment(ctx context.Context, initiativeID in
 	}
 
 	// Verificar se a iniciativa existe
-	_, err := uc.initiativeRepo.GetByID(ctx, initiativeID)
+	initiative, err := uc.initiativeRepo.GetByID(ctx, initiativeID)
 	if err != nil {
 		return nil, errors.New("iniciativa não encontrada")
 	}
 
+	visibility := req.Visibility
+	if visibility == "" {
+		visibility = entities.CommentVisibilityPublic
+	}
+	if visibility != entities.CommentVisibilityPublic && visibility != entities.CommentVisibilityInternal {
+		return nil, errors.New("visibilidade inválida; use public ou internal")
+	}
+
+	access := uc.internalNoteAccess(ctx, initiative, userID)
+
+	// Resposta: o comentário pai precisa ser da mesma iniciativa
+	var parent *entities.Comment
+	if req.ParentID != nil {
+		parent, err = uc.commentRepo.GetByID(ctx, *req.ParentID)
+		if err != nil || parent.InitiativeID != initiativeID ||
+			(parent.Visibility == entities.CommentVisibilityInternal && !access.canView) {
+			return nil, errors.New("comentário respondido não encontrado nesta iniciativa")
+		}
+		if parent.DeletedAt != nil {
+			return nil, errors.New("não é possível responder um comentário removido")
+		}
+
+		// Respostas a uma nota interna também são internas
+		if parent.Visibility == entities.CommentVisibilityInternal {
+			visibility = entities.CommentVisibilityInternal
+		}
+	}
+
+	if visibility == entities.CommentVisibilityInternal && !access.canPost {
+		return nil, errors.New("você não tem permissão para publicar notas internas nesta iniciativa")
+	}
+
 	comment := &entities.Comment{
 		InitiativeID: initiativeID,
+		ParentID:     req.ParentID,
 		UserID:       userID,
 		Content:      req.Content,
+		Visibility:   visibility,
 	}
 
 	if err := uc.commentRepo.Create(ctx, comment); err != nil {
@@ -54,12 +104,35 @@ func (uc *CommentUseCaseImpl) CreateComment(ctx context.Context, initiativeID in
 	}
 
 	// Buscar o comentário completo com nome do usuário
-	return uc.commentRepo.GetByID(ctx, comment.ID)
+	created, err := uc.commentRepo.GetByID(ctx, comment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	notified, err := uc.updateMentions(ctx, created, initiative, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Avisar o autor do comentário respondido (se já não foi mencionado e puder ver a resposta)
+	if parent != nil && parent.UserID != userID && !notified[parent.UserID] && uc.canSee(ctx, created, initiative, parent.UserID) {
+		notify(ctx, uc.notificationRepo, &entities.Notification{
+			UserID:       parent.UserID,
+			Type:         entities.NotificationTypeCommentReply,
+			Title:        "Nova resposta ao seu comentário",
+			Message:      fmt.Sprintf("%s respondeu seu comentário na iniciativa \"%s\"", created.UserName, initiative.Title),
+			InitiativeID: &initiative.ID,
+			CommentID:    &created.ID,
+		})
+	}
+
+	created.ContentHTML = markdown.Render(created.Content)
+	return created, nil
 }
 
 func (uc *CommentUseCaseImpl) UpdateComment(ctx context.Context, commentID int64, req *entities.UpdateCommentRequest, userID int64) (*entities.Comment, error) {
-	// Validar conteúdo
-	if len(req.Content) < 3 {
+	// Validar conteúdo (o mínimo vale para o texto, sem a marcação Markdown)
+	if len(markdown.PlainText(req.Content)) < 3 {
 		return nil, errors.New("comentário deve ter no mínimo 3 caracteres")
 	}
 
@@ -68,9 +141,13 @@ func (uc *CommentUseCaseImpl) UpdateComment(ctx context.Context, commentID int64
 	}
 
 	// Buscar comentário
-	comment, err := uc.commentRepo.GetByID(ctx, commentID)
+	comment, err := uc.getVisibleComment(ctx, commentID, userID)
 	if err != nil {
-		return nil, errors.New("comentário não encontrado")
+		return nil, err
+	}
+
+	if comment.DeletedAt != nil {
+		return nil, errors.New("não é possível editar um comentário removido")
 	}
 
 	// Verificar se é o dono ou admin
@@ -79,20 +156,52 @@ func (uc *CommentUseCaseImpl) UpdateComment(ctx context.Context, commentID int64
 		return nil, errors.New("você não tem permissão para editar este comentário")
 	}
 
+	if comment.Content == req.Content {
+		comment.ContentHTML = markdown.Render(comment.Content)
+		return comment, nil
+	}
+
+	previousMentions, err := uc.commentRepo.ListMentions(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar menções: %w", err)
+	}
+
 	comment.Content = req.Content
 
-	if err := uc.commentRepo.Update(ctx, comment); err != nil {
+	// O conteúdo anterior é guardado como revisão
+	if err := uc.commentRepo.Update(ctx, comment, userID); err != nil {
 		return nil, fmt.Errorf("erro ao atualizar comentário: %w", err)
 	}
 
-	return uc.commentRepo.GetByID(ctx, commentID)
+	initiative, err := uc.initiativeRepo.GetByID(ctx, comment.InitiativeID)
+	if err != nil {
+		return nil, errors.New("iniciativa não encontrada")
+	}
+
+	// Notificar apenas quem passou a ser mencionado nesta edição
+	alreadyMentioned := make(map[int64]bool)
+	for _, mention := range previousMentions {
+		alreadyMentioned[mention.UserID] = true
+	}
+
+	if _, err := uc.updateMentions(ctx, comment, initiative, alreadyMentioned); err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated.ContentHTML = markdown.Render(updated.Content)
+	return updated, nil
 }
 
 func (uc *CommentUseCaseImpl) DeleteComment(ctx context.Context, commentID int64, userID int64) error {
 	// Buscar comentário
-	comment, err := uc.commentRepo.GetByID(ctx, commentID)
+	comment, err := uc.getVisibleComment(ctx, commentID, userID)
 	if err != nil {
-		return errors.New("comentário não encontrado")
+		return err
 	}
 
 	// Verificar se é o dono ou admin
@@ -101,31 +210,385 @@ func (uc *CommentUseCaseImpl) DeleteComment(ctx context.Context, commentID int64
 		return errors.New("você não tem permissão para deletar este comentário")
 	}
 
-	return uc.commentRepo.Delete(ctx, commentID)
+	if comment.DeletedAt != nil {
+		return errors.New("comentário já foi removido")
+	}
+
+	// Soft delete: o comentário continua na árvore como "comentário removido"
+	if err := uc.commentRepo.SoftDelete(ctx, commentID, userID); err != nil {
+		return fmt.Errorf("erro ao remover comentário: %w", err)
+	}
+
+	return nil
+}
+
+// ListRevisions lista as versões anteriores do comentário (mais recente primeiro).
+// As revisões de um comentário removido ficam visíveis apenas para admin.
+func (uc *CommentUseCaseImpl) ListRevisions(ctx context.Context, commentID int64, userID int64) ([]*entities.CommentRevisionResponse, error) {
+	comment, err := uc.getVisibleComment(ctx, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.DeletedAt != nil {
+		isAdmin, _ := uc.isAdmin(ctx, userID)
+		if !isAdmin {
+			return nil, errors.New("comentário removido")
+		}
+	}
+
+	revisions, err := uc.commentRepo.ListRevisions(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar revisões: %w", err)
+	}
+
+	response := make([]*entities.CommentRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		response = append(response, &entities.CommentRevisionResponse{
+			ID:           revision.ID,
+			CommentID:    revision.CommentID,
+			Content:      revision.Content,
+			ContentHTML:  markdown.Render(revision.Content),
+			EditedBy:     revision.EditedBy,
+			EditedByName: revision.EditedByName,
+			CreatedAt:    formatCommentDate(revision.CreatedAt),
+			TimeAgo:      timeAgo(revision.CreatedAt),
+		})
+	}
+
+	return response, nil
 }
 
-func (uc *CommentUseCaseImpl) ListComments(ctx context.Context, initiativeID int64) ([]*entities.CommentListResponse, error) {
-	comments, err := uc.commentRepo.ListByInitiative(ctx, initiativeID)
+// RestoreComment desfaz o soft delete (somente admin)
+func (uc *CommentUseCaseImpl) RestoreComment(ctx context.Context, commentID int64, userID int64) (*entities.Comment, error) {
+	isAdmin, _ := uc.isAdmin(ctx, userID)
+	if !isAdmin {
+		return nil, errors.New("apenas administradores podem restaurar comentários")
+	}
+
+	comment, err := uc.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, errors.New("comentário não encontrado")
+	}
+
+	if comment.DeletedAt == nil {
+		return nil, errors.New("comentário não está removido")
+	}
+
+	if err := uc.commentRepo.Restore(ctx, commentID); err != nil {
+		return nil, fmt.Errorf("erro ao restaurar comentário: %w", err)
+	}
+
+	restored, err := uc.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	restored.ContentHTML = markdown.Render(restored.Content)
+	return restored, nil
+}
+
+// PurgeComment remove definitivamente o comentário e suas revisões (somente admin).
+// As respostas são mantidas e passam para o comentário pai.
+func (uc *CommentUseCaseImpl) PurgeComment(ctx context.Context, commentID int64, userID int64) error {
+	isAdmin, _ := uc.isAdmin(ctx, userID)
+	if !isAdmin {
+		return errors.New("apenas administradores podem excluir comentários definitivamente")
+	}
+
+	if _, err := uc.commentRepo.GetByID(ctx, commentID); err != nil {
+		return errors.New("comentário não encontrado")
+	}
+
+	if err := uc.commentRepo.Delete(ctx, commentID); err != nil {
+		return fmt.Errorf("erro ao excluir comentário: %w", err)
+	}
+
+	return nil
+}
+
+// ListComments retorna os comentários em árvore (respostas aninhadas no comentário pai)
+// Notas internas só aparecem para quem tem acesso (revisores, nunca o dono da iniciativa).
+func (uc *CommentUseCaseImpl) ListComments(ctx context.Context, initiativeID int64, userID int64) ([]*entities.CommentListResponse, error) {
+	initiative, err := uc.initiativeRepo.GetByID(ctx, initiativeID)
+	if err != nil {
+		return nil, errors.New("iniciativa não encontrada")
+	}
+
+	all, err := uc.commentRepo.ListByInitiative(ctx, initiativeID)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao listar comentários: %w", err)
 	}
 
+	access := uc.internalNoteAccess(ctx, initiative, userID)
+
+	var comments []*entities.Comment
+	for _, comment := range all {
+		if comment.Visibility == entities.CommentVisibilityInternal && !access.canView {
+			continue
+		}
+		comments = append(comments, comment)
+	}
+
+	mentions, err := uc.commentRepo.ListMentionsByInitiative(ctx, initiativeID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar menções: %w", err)
+	}
+
+	reactions, err := uc.commentRepo.ListReactionsByInitiative(ctx, initiativeID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar reações: %w", err)
+	}
+
+	mentionsByComment := make(map[int64][]*entities.CommentMention)
+	for _, mention := range mentions {
+		mentionsByComment[mention.CommentID] = append(mentionsByComment[mention.CommentID], mention)
+	}
+
+	reactionsByComment := make(map[int64][]*entities.CommentReaction)
+	for _, reaction := range reactions {
+		reactionsByComment[reaction.CommentID] = append(reactionsByComment[reaction.CommentID], reaction)
+	}
+
+	nodes := make(map[int64]*entities.CommentListResponse, len(comments))
+	for _, comment := range comments {
+		commentMentions := mentionsByComment[comment.ID]
+		if commentMentions == nil {
+			commentMentions = []*entities.CommentMention{}
+		}
+
+		node := &entities.CommentListResponse{
+			ID:            comment.ID,
+			InitiativeID:  comment.InitiativeID,
+			ParentID:      comment.ParentID,
+			UserID:        comment.UserID,
+			UserName:      comment.UserName,
+			Content:       comment.Content,
+			ContentHTML:   markdown.Render(comment.Content),
+			Visibility:    comment.Visibility,
+			CreatedAt:     formatCommentDate(comment.CreatedAt),
+			UpdatedAt:     formatCommentDate(comment.UpdatedAt),
+			RevisionCount: comment.RevisionCount,
+			Mentions:      commentMentions,
+			Reactions:     summarizeReactions(reactionsByComment[comment.ID], userID),
+			Replies:       []*entities.CommentListResponse{},
+		}
+
+		if comment.RevisionCount > 0 {
+			node.Edited = true
+			node.EditedAt = formatCommentDate(comment.UpdatedAt)
+		}
+
+		// Tombstone: mantém a posição na árvore sem expor o conteúdo
+		if comment.DeletedAt != nil {
+			node.Deleted = true
+			node.Content = entities.CommentRemovedPlaceholder
+			node.ContentHTML = "<p>" + entities.CommentRemovedPlaceholder + "</p>"
+			node.Mentions = []*entities.CommentMention{}
+			node.Reactions = []*entities.CommentReactionSummary{}
+		}
+
+		nodes[comment.ID] = node
+	}
+
+	// Montar a árvore mantendo a ordem cronológica
 	var response []*entities.CommentListResponse
 	for _, comment := range comments {
-		response = append(response, &entities.CommentListResponse{
-			ID:           comment.ID,
-			InitiativeID: comment.InitiativeID,
-			UserID:       comment.UserID,
-			UserName:     comment.UserName,
-			Content:      comment.Content,
-			CreatedAt:    formatCommentDate(comment.CreatedAt),
-			UpdatedAt:    formatCommentDate(comment.UpdatedAt),
-		})
+		node := nodes[comment.ID]
+		if comment.ParentID != nil {
+			if parent, ok := nodes[*comment.ParentID]; ok {
+				parent.Replies = append(parent.Replies, node)
+				parent.ReplyCount++
+				continue
+			}
+		}
+		response = append(response, node)
 	}
 
 	return response, nil
 }
 
+// AddReaction adiciona uma reação do usuário ao comentário
+func (uc *CommentUseCaseImpl) AddReaction(ctx context.Context, commentID int64, emoji string, userID int64) error {
+	if !isAllowedReaction(emoji) {
+		return fmt.Errorf("reação inválida; use uma de: %s", strings.Join(entities.AllowedCommentReactions, " "))
+	}
+
+	comment, err := uc.getVisibleComment(ctx, commentID, userID)
+	if err != nil {
+		return err
+	}
+
+	if comment.DeletedAt != nil {
+		return errors.New("não é possível reagir a um comentário removido")
+	}
+
+	reaction := &entities.CommentReaction{
+		CommentID: commentID,
+		UserID:    userID,
+		Emoji:     emoji,
+	}
+
+	if err := uc.commentRepo.AddReaction(ctx, reaction); err != nil {
+		return fmt.Errorf("erro ao adicionar reação: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveReaction remove a reação do próprio usuário
+func (uc *CommentUseCaseImpl) RemoveReaction(ctx context.Context, commentID int64, emoji string, userID int64) error {
+	if err := uc.commentRepo.RemoveReaction(ctx, commentID, userID, emoji); err != nil {
+		return fmt.Errorf("erro ao remover reação: %w", err)
+	}
+	return nil
+}
+
+// updateMentions resolve as menções do conteúdo, grava e notifica os mencionados
+// (exceto o autor e quem está em skip). Retorna os usuários notificados.
+func (uc *CommentUseCaseImpl) updateMentions(ctx context.Context, comment *entities.Comment, initiative *entities.Initiative, skip map[int64]bool) (map[int64]bool, error) {
+	users, err := uc.resolveMentions(ctx, comment.Content)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao resolver menções: %w", err)
+	}
+
+	userIDs := make([]int64, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+
+	if err := uc.commentRepo.SetMentions(ctx, comment.ID, userIDs); err != nil {
+		return nil, fmt.Errorf("erro ao salvar menções: %w", err)
+	}
+
+	notified := make(map[int64]bool)
+	for _, user := range users {
+		if user.ID == comment.UserID || skip[user.ID] || !uc.canSee(ctx, comment, initiative, user.ID) {
+			continue
+		}
+
+		notify(ctx, uc.notificationRepo, &entities.Notification{
+			UserID:       user.ID,
+			Type:         entities.NotificationTypeCommentMention,
+			Title:        "Você foi mencionado em um comentário",
+			Message:      fmt.Sprintf("%s mencionou você na iniciativa \"%s\"", comment.UserName, initiative.Title),
+			InitiativeID: &initiative.ID,
+			CommentID:    &comment.ID,
+		})
+		notified[user.ID] = true
+	}
+
+	return notified, nil
+}
+
+// resolveMentions extrai os @handles do conteúdo e busca os usuários.
+// Um handle curto que corresponda a mais de um usuário é ignorado (ambíguo).
+func (uc *CommentUseCaseImpl) resolveMentions(ctx context.Context, content string) ([]*entities.User, error) {
+	var handles []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		handle := strings.ToLower(strings.TrimRight(match[1], "."))
+		if handle != "" && !seen[handle] {
+			seen[handle] = true
+			handles = append(handles, handle)
+		}
+	}
+
+	if len(handles) == 0 {
+		return nil, nil
+	}
+
+	candidates, err := uc.authRepo.FindUsersByHandles(ctx, handles)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*entities.User
+	added := make(map[int64]bool)
+	for _, handle := range handles {
+		var matches []*entities.User
+		for _, user := range candidates {
+			email := strings.ToLower(user.Email)
+			localPart, _, _ := strings.Cut(email, "@")
+			if email == handle || (!strings.Contains(handle, "@") && localPart == handle) {
+				matches = append(matches, user)
+			}
+		}
+
+		if len(matches) == 1 && !added[matches[0].ID] {
+			users = append(users, matches[0])
+			added[matches[0].ID] = true
+		}
+	}
+
+	return users, nil
+}
+
+type internalNoteAccess struct {
+	canView bool
+	canPost bool
+}
+
+// internalNoteAccess define se o usuário vê e/ou publica notas internas na iniciativa.
+// Revisores (admin/manager) e quem tem a permissão de publicar podem ver; o dono da
+// iniciativa nunca vê, a menos que seja admin.
+func (uc *CommentUseCaseImpl) internalNoteAccess(ctx context.Context, initiative *entities.Initiative, userID int64) internalNoteAccess {
+	var access internalNoteAccess
+
+	userTypes, err := uc.permRepo.GetUserTypes(ctx, userID)
+	if err != nil {
+		return access
+	}
+
+	isAdmin, isReviewer := false, false
+	for _, userType := range userTypes {
+		switch userType.Name {
+		case "admin":
+			isAdmin, isReviewer = true, true
+		case "manager":
+			isReviewer = true
+		}
+	}
+
+	if initiative.OwnerID == userID && !isAdmin {
+		return access
+	}
+
+	canPost, err := uc.permRepo.HasPermission(ctx, userID, internalNotePermission, "POST")
+	if err != nil {
+		canPost = false
+	}
+
+	access.canPost = canPost
+	access.canView = isReviewer || canPost
+	return access
+}
+
+// canSee indica se o usuário pode ver o comentário (usado antes de notificar)
+func (uc *CommentUseCaseImpl) canSee(ctx context.Context, comment *entities.Comment, initiative *entities.Initiative, userID int64) bool {
+	if comment.Visibility != entities.CommentVisibilityInternal {
+		return true
+	}
+	return uc.internalNoteAccess(ctx, initiative, userID).canView
+}
+
+// getVisibleComment busca o comentário; uma nota interna sem acesso é tratada como inexistente
+func (uc *CommentUseCaseImpl) getVisibleComment(ctx context.Context, commentID int64, userID int64) (*entities.Comment, error) {
+	comment, err := uc.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, errors.New("comentário não encontrado")
+	}
+
+	if comment.Visibility == entities.CommentVisibilityInternal {
+		initiative, err := uc.initiativeRepo.GetByID(ctx, comment.InitiativeID)
+		if err != nil || !uc.internalNoteAccess(ctx, initiative, userID).canView {
+			return nil, errors.New("comentário não encontrado")
+		}
+	}
+
+	return comment, nil
+}
+
 func (uc *CommentUseCaseImpl) isAdmin(ctx context.Context, userID int64) (bool, error) {
 	userTypes, err := uc.permRepo.GetUserTypes(ctx, userID)
 	if err != nil {
@@ -141,6 +604,41 @@ func (uc *CommentUseCaseImpl) isAdmin(ctx context.Context, userID int64) (bool,
 	return false, nil
 }
 
+// summarizeReactions agrupa as reações por emoji, na ordem da lista de reações permitidas
+func summarizeReactions(reactions []*entities.CommentReaction, userID int64) []*entities.CommentReactionSummary {
+	summary := []*entities.CommentReactionSummary{}
+	byEmoji := make(map[string]*entities.CommentReactionSummary)
+
+	for _, reaction := range reactions {
+		item, ok := byEmoji[reaction.Emoji]
+		if !ok {
+			item = &entities.CommentReactionSummary{Emoji: reaction.Emoji}
+			byEmoji[reaction.Emoji] = item
+		}
+		item.Count++
+		if reaction.UserID == userID {
+			item.ReactedByMe = true
+		}
+	}
+
+	for _, emoji := range entities.AllowedCommentReactions {
+		if item, ok := byEmoji[emoji]; ok {
+			summary = append(summary, item)
+		}
+	}
+
+	return summary
+}
+
+func isAllowedReaction(emoji string) bool {
+	for _, allowed := range entities.AllowedCommentReactions {
+		if emoji == allowed {
+			return true
+		}
+	}
+	return false
+}
+
 func formatCommentDate(t time.Time) string {
 	return t.Format("2006-01-02 15:04:05")
 }
@@ -0,0 +1,210 @@
+package usecase_impl
+
+import (
+	"errors"
+	"fmt"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/settings_loader"
+	"math"
+	"sort"
+)
+
+// Priorização de um setor usada como entrada do ranking consolidado
+type sectorRanking struct {
+	SectorID   int64
+	SectorName string
+	Weight     float64
+	Order      []int64
+}
+
+// consolidateRankings mescla as priorizações dos setores em um único ranking, conforme a estratégia
+func consolidateRankings(strategy string, sectors []*sectorRanking) []*entities.ConsolidatedRankingItem {
+	var items []*entities.ConsolidatedRankingItem
+
+	switch strategy {
+	case settings_loader.ConsolidationRoundRobin:
+		items = roundRobinRanking(sectors)
+	default:
+		items = scoredRanking(strategy, sectors)
+	}
+
+	// Uma iniciativa só entra uma vez (vale a melhor colocação)
+	seen := make(map[int64]bool)
+	var result []*entities.ConsolidatedRankingItem
+	for _, item := range items {
+		if seen[item.InitiativeID] {
+			continue
+		}
+		seen[item.InitiativeID] = true
+		item.Position = len(result) + 1
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// roundRobinRanking: a cada rodada, cada setor (maior peso primeiro) indica tantas iniciativas quanto o seu peso
+func roundRobinRanking(sectors []*sectorRanking) []*entities.ConsolidatedRankingItem {
+	ordered := append([]*sectorRanking(nil), sectors...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Weight != ordered[j].Weight {
+			return ordered[i].Weight > ordered[j].Weight
+		}
+		return ordered[i].SectorName < ordered[j].SectorName
+	})
+
+	next := make([]int, len(ordered))
+	var items []*entities.ConsolidatedRankingItem
+	for round := 1; ; round++ {
+		picked := false
+		for i, sector := range ordered {
+			quota := max(1, int(math.Round(sector.Weight)))
+			for n := 0; n < quota && next[i] < len(sector.Order); n++ {
+				position := next[i] + 1
+				items = append(items, &entities.ConsolidatedRankingItem{
+					InitiativeID:   sector.Order[next[i]],
+					SectorID:       sector.SectorID,
+					SectorName:     sector.SectorName,
+					SectorPosition: position,
+					SectorWeight:   sector.Weight,
+					Explanation: fmt.Sprintf("Rodada %d: %dª do setor %s (peso %g, %d indicação(ões) por rodada)",
+						round, position, sector.SectorName, sector.Weight, quota),
+				})
+				next[i]++
+				picked = true
+			}
+		}
+		if !picked {
+			return items
+		}
+	}
+}
+
+// scoredRanking pontua cada iniciativa pela posição no setor e ordena pela pontuação
+func scoredRanking(strategy string, sectors []*sectorRanking) []*entities.ConsolidatedRankingItem {
+	longest := 0
+	for _, sector := range sectors {
+		longest = max(longest, len(sector.Order))
+	}
+
+	var items []*entities.ConsolidatedRankingItem
+	for _, sector := range sectors {
+		size := len(sector.Order)
+		for i, initiativeID := range sector.Order {
+			position := i + 1
+			item := &entities.ConsolidatedRankingItem{
+				InitiativeID:   initiativeID,
+				SectorID:       sector.SectorID,
+				SectorName:     sector.SectorName,
+				SectorPosition: position,
+				SectorWeight:   sector.Weight,
+			}
+
+			if strategy == settings_loader.ConsolidationBorda {
+				// Contagem de Borda: a 1ª de cada setor vale o mesmo, independente do tamanho da lista
+				points := longest - position + 1
+				item.Score = float64(points)
+				item.Explanation = fmt.Sprintf("Borda: %d ponto(s) por ser a %dª do setor %s (máximo %d)",
+					points, position, sector.SectorName, longest)
+			} else {
+				// Pontuação ponderada: posição normalizada (1 = topo do setor) multiplicada pelo peso do setor
+				item.Score = math.Round(sector.Weight*float64(size-position+1)/float64(size)*1000) / 1000
+				item.Explanation = fmt.Sprintf("Pontuação %.3f = peso %g × (%d - %d + 1) / %d, pela %dª posição no setor %s",
+					item.Score, sector.Weight, size, position, size, position, sector.SectorName)
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	// Empates: maior peso do setor, melhor posição no setor, nome do setor e ID
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch {
+		case a.Score != b.Score:
+			return a.Score > b.Score
+		case a.SectorWeight != b.SectorWeight:
+			return a.SectorWeight > b.SectorWeight
+		case a.SectorPosition != b.SectorPosition:
+			return a.SectorPosition < b.SectorPosition
+		case a.SectorName != b.SectorName:
+			return a.SectorName < b.SectorName
+		}
+		return a.InitiativeID < b.InitiativeID
+	})
+
+	return items
+}
+
+// dropStaleRankingPins separa as fixações cujas iniciativas não estão mais nos itens ou cuja posição
+// passou do tamanho do ranking
+func dropStaleRankingPins(items []*entities.ConsolidatedRankingItem, pins []*entities.RankingPin) ([]*entities.RankingPin, []*entities.RankingPin) {
+	present := make(map[int64]bool, len(items))
+	for _, item := range items {
+		present[item.InitiativeID] = true
+	}
+
+	kept := []*entities.RankingPin{}
+	var dropped []*entities.RankingPin
+	for _, pin := range pins {
+		if !present[pin.InitiativeID] || pin.Position > len(items) {
+			dropped = append(dropped, pin)
+			continue
+		}
+		kept = append(kept, pin)
+	}
+	return kept, dropped
+}
+
+// applyRankingPins coloca as iniciativas fixadas nas posições pedidas; as demais seguem a ordem calculada
+func applyRankingPins(items []*entities.ConsolidatedRankingItem, pins []*entities.RankingPin) ([]*entities.ConsolidatedRankingItem, error) {
+	if len(pins) == 0 {
+		return items, nil
+	}
+
+	byID := make(map[int64]*entities.ConsolidatedRankingItem, len(items))
+	for _, item := range items {
+		byID[item.InitiativeID] = item
+	}
+
+	slots := make([]*entities.ConsolidatedRankingItem, len(items))
+	pinned := make(map[int64]bool)
+	for _, pin := range pins {
+		item, ok := byID[pin.InitiativeID]
+		if !ok {
+			return nil, fmt.Errorf("iniciativa %d não está em nenhuma priorização de setor", pin.InitiativeID)
+		}
+		if pin.Position < 1 || pin.Position > len(items) {
+			return nil, fmt.Errorf("posição %d inválida para a iniciativa %d (use 1 a %d)", pin.Position, pin.InitiativeID, len(items))
+		}
+		if pinned[pin.InitiativeID] {
+			return nil, fmt.Errorf("iniciativa %d fixada mais de uma vez", pin.InitiativeID)
+		}
+		if slots[pin.Position-1] != nil {
+			return nil, errors.New("duas iniciativas fixadas na mesma posição")
+		}
+
+		pinned[pin.InitiativeID] = true
+		item.Pinned = true
+		item.Explanation = fmt.Sprintf("Posição %d fixada por administrador (pela estratégia seria a %dª). %s",
+			pin.Position, item.Position, item.Explanation)
+		slots[pin.Position-1] = item
+	}
+
+	next := 0
+	for _, item := range items {
+		if pinned[item.InitiativeID] {
+			continue
+		}
+		for slots[next] != nil {
+			next++
+		}
+		slots[next] = item
+	}
+
+	for i, item := range slots {
+		item.Position = i + 1
+	}
+
+	return slots, nil
+}
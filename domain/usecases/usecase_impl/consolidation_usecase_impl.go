@@ -0,0 +1,297 @@
+package usecase_impl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/infrastructure/repositories"
+	"hackathon-backend/settings_loader"
+	"slices"
+)
+
+type ConsolidationUseCaseImpl struct {
+	rankingRepo        repositories.ConsolidatedRankingRepository
+	prioritizationRepo repositories.PrioritizationRepository
+	initiativeRepo     repositories.InitiativeRepository
+	permRepo           repositories.PermissionRepository
+	notificationRepo   repositories.NotificationRepository
+	settings           *settings_loader.SettingsLoader
+}
+
+func NewConsolidationUseCaseImpl(
+	rankingRepo repositories.ConsolidatedRankingRepository,
+	prioritizationRepo repositories.PrioritizationRepository,
+	initiativeRepo repositories.InitiativeRepository,
+	permRepo repositories.PermissionRepository,
+	notificationRepo repositories.NotificationRepository,
+	settings *settings_loader.SettingsLoader,
+) *ConsolidationUseCaseImpl {
+	return &ConsolidationUseCaseImpl{
+		rankingRepo:        rankingRepo,
+		prioritizationRepo: prioritizationRepo,
+		initiativeRepo:     initiativeRepo,
+		permRepo:           permRepo,
+		notificationRepo:   notificationRepo,
+		settings:           settings,
+	}
+}
+
+// GenerateRanking mescla as priorizações dos setores do ano e grava o resultado como rascunho (apenas admin)
+func (uc *ConsolidationUseCaseImpl) GenerateRanking(ctx context.Context, req *entities.GenerateConsolidatedRankingRequest, userID int64) (*entities.ConsolidatedRanking, error) {
+	if ok, err := uc.hasUserType(ctx, userID, "admin"); err != nil || !ok {
+		return nil, errors.New("apenas administradores podem gerar o ranking consolidado")
+	}
+
+	if req.Year < 2020 || req.Year > 2100 {
+		return nil, errors.New("ano inválido")
+	}
+
+	strategy := req.Strategy
+	if strategy == "" {
+		strategy = uc.settings.Prioritization.ConsolidationStrategy
+	}
+	if !settings_loader.IsValidConsolidationStrategy(strategy) {
+		return nil, errors.New("estratégia inválida. Use: round_robin, borda ou weighted_score")
+	}
+
+	existing, err := uc.rankingRepo.GetByYear(ctx, req.Year)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("erro ao buscar ranking consolidado: %w", err)
+	}
+	if existing != nil && (existing.IsLocked || existing.Status == entities.ConsolidatedRankingPending) {
+		return nil, errors.New("o ranking consolidado deste ano está aguardando aprovação ou bloqueado")
+	}
+
+	// Sem pins na requisição: mantém as fixações do ranking atual
+	pins := req.Pins
+	inherited := false
+	if pins == nil {
+		pins = []*entities.RankingPin{}
+		if existing != nil && existing.Pins != nil {
+			pins = existing.Pins
+			inherited = true
+		}
+	}
+
+	all, err := uc.prioritizationRepo.GetAllByYear(ctx, req.Year)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar priorizações: %w", err)
+	}
+
+	// Rascunhos ainda não foram enviados pelos setores
+	var prioritizations []*entities.InitiativePrioritization
+	for _, p := range all {
+		if p.Status == entities.PrioritizationStatusSubmitted {
+			prioritizations = append(prioritizations, p)
+		}
+	}
+	if len(prioritizations) == 0 {
+		return nil, errors.New("nenhum setor enviou a priorização deste ano")
+	}
+
+	titles := make(map[int64]string)
+	var sectors []*sectorRanking
+	for _, p := range prioritizations {
+		sector := &sectorRanking{
+			SectorID:   p.SectorID,
+			SectorName: p.SectorName,
+			Weight:     uc.settings.SectorWeight(p.SectorName),
+		}
+
+		// Iniciativas que deixaram de ser elegíveis desde a priorização não entram
+		for _, initiativeID := range p.PriorityOrder {
+			initiative, err := uc.initiativeRepo.GetByID(ctx, initiativeID)
+			if err != nil || !entities.IsPrioritizationEligible(initiative.Status) {
+				continue
+			}
+			titles[initiativeID] = initiative.Title
+			sector.Order = append(sector.Order, initiativeID)
+		}
+
+		if len(sector.Order) > 0 {
+			sectors = append(sectors, sector)
+		}
+	}
+
+	items := consolidateRankings(strategy, sectors)
+
+	// Fixações herdadas de iniciativas que saíram do ranking (canceladas, não ranqueadas ou fora da
+	// faixa de posições) são descartadas e reportadas; as enviadas na requisição continuam gerando erro
+	var dropped []*entities.RankingPin
+	if inherited {
+		pins, dropped = dropStaleRankingPins(items, pins)
+	}
+
+	items, err = applyRankingPins(items, pins)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		item.InitiativeTitle = titles[item.InitiativeID]
+	}
+
+	ranking := &entities.ConsolidatedRanking{
+		Year:              req.Year,
+		Strategy:          strategy,
+		Items:             items,
+		Pins:              pins,
+		GeneratedByUserID: userID,
+	}
+
+	if err := uc.rankingRepo.Save(ctx, ranking); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("o ranking consolidado deste ano está aguardando aprovação ou bloqueado")
+		}
+		return nil, fmt.Errorf("erro ao salvar ranking consolidado: %w", err)
+	}
+
+	saved, err := uc.rankingRepo.GetByID(ctx, ranking.ID)
+	if err != nil {
+		return nil, err
+	}
+	saved.DroppedPins = dropped
+
+	return saved, nil
+}
+
+// GetRanking busca o ranking consolidado do ano (admin, gerentes e aprovadores)
+func (uc *ConsolidationUseCaseImpl) GetRanking(ctx context.Context, year int, userID int64) (*entities.ConsolidatedRanking, error) {
+	allowed := append([]string{"admin", "manager"}, uc.settings.Prioritization.ConsolidationApprovers...)
+	if ok, err := uc.hasUserType(ctx, userID, allowed...); err != nil || !ok {
+		return nil, errors.New("sem permissão para visualizar o ranking consolidado")
+	}
+
+	ranking, err := uc.rankingRepo.GetByYear(ctx, year)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("ranking consolidado ainda não foi gerado para este ano")
+		}
+		return nil, fmt.Errorf("erro ao buscar ranking consolidado: %w", err)
+	}
+
+	return ranking, nil
+}
+
+// SubmitRanking envia o rascunho para aprovação do comitê (apenas admin)
+func (uc *ConsolidationUseCaseImpl) SubmitRanking(ctx context.Context, rankingID int64, userID int64) (*entities.ConsolidatedRanking, error) {
+	if ok, err := uc.hasUserType(ctx, userID, "admin"); err != nil || !ok {
+		return nil, errors.New("apenas administradores podem enviar o ranking consolidado")
+	}
+
+	ranking, err := uc.rankingRepo.GetByID(ctx, rankingID)
+	if err != nil {
+		return nil, errors.New("ranking consolidado não encontrado")
+	}
+
+	if err := uc.rankingRepo.Submit(ctx, rankingID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("ranking com status %s não pode ser enviado para aprovação", ranking.Status)
+		}
+		return nil, fmt.Errorf("erro ao enviar ranking consolidado: %w", err)
+	}
+
+	uc.notifyApprovers(ctx, ranking.Year, userID)
+
+	return uc.rankingRepo.GetByID(ctx, rankingID)
+}
+
+// ReviewRanking aprova (bloqueando) ou reprova o ranking pendente
+func (uc *ConsolidationUseCaseImpl) ReviewRanking(ctx context.Context, rankingID int64, req *entities.ReviewConsolidatedRankingRequest, userID int64) (*entities.ConsolidatedRanking, error) {
+	if ok, err := uc.hasUserType(ctx, userID, uc.settings.Prioritization.ConsolidationApprovers...); err != nil || !ok {
+		return nil, errors.New("você não está entre os aprovadores do ranking consolidado")
+	}
+
+	if len(req.Reason) < 5 {
+		return nil, errors.New("justificativa deve ter no mínimo 5 caracteres")
+	}
+
+	ranking, err := uc.rankingRepo.GetByID(ctx, rankingID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("ranking não encontrado ou não está aguardando aprovação")
+		}
+		return nil, fmt.Errorf("erro ao buscar ranking consolidado: %w", err)
+	}
+
+	// Quem gerou ou enviou o ranking não pode aprová-lo
+	if ranking.GeneratedByUserID == userID || (ranking.SubmittedByUserID != nil && *ranking.SubmittedByUserID == userID) {
+		return nil, errors.New("quem gerou ou enviou o ranking não pode revisá-lo")
+	}
+
+	status := entities.ConsolidatedRankingRejected
+	if req.Approved {
+		status = entities.ConsolidatedRankingApproved
+	}
+
+	if err := uc.rankingRepo.Review(ctx, rankingID, status, userID, req.Reason); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("ranking não encontrado ou não está aguardando aprovação")
+		}
+		return nil, fmt.Errorf("erro ao revisar ranking consolidado: %w", err)
+	}
+
+	return uc.rankingRepo.GetByID(ctx, rankingID)
+}
+
+// ReopenRanking desbloqueia um ranking aprovado para nova geração (apenas admin)
+func (uc *ConsolidationUseCaseImpl) ReopenRanking(ctx context.Context, rankingID int64, req *entities.ReopenConsolidatedRankingRequest, userID int64) (*entities.ConsolidatedRanking, error) {
+	if ok, err := uc.hasUserType(ctx, userID, "admin"); err != nil || !ok {
+		return nil, errors.New("apenas administradores podem reabrir o ranking consolidado")
+	}
+
+	if len(req.Reason) < 5 {
+		return nil, errors.New("justificativa deve ter no mínimo 5 caracteres")
+	}
+
+	if err := uc.rankingRepo.Reopen(ctx, rankingID, userID, req.Reason); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("ranking não encontrado ou não está aprovado")
+		}
+		return nil, fmt.Errorf("erro ao reabrir ranking consolidado: %w", err)
+	}
+
+	return uc.rankingRepo.GetByID(ctx, rankingID)
+}
+
+func (uc *ConsolidationUseCaseImpl) notifyApprovers(ctx context.Context, year int, submittedBy int64) {
+	notified := make(map[int64]bool)
+	for _, userType := range uc.settings.Prioritization.ConsolidationApprovers {
+		userIDs, err := uc.permRepo.ListUserIDsByType(ctx, userType)
+		if err != nil {
+			fmt.Printf("Erro ao buscar aprovadores do ranking: %v\n", err)
+			continue
+		}
+
+		for _, id := range userIDs {
+			if id == submittedBy || notified[id] {
+				continue
+			}
+			notified[id] = true
+
+			notify(ctx, uc.notificationRepo, &entities.Notification{
+				UserID:  id,
+				Type:    entities.NotificationTypeRankingReview,
+				Title:   "Ranking consolidado aguardando aprovação",
+				Message: fmt.Sprintf("O ranking consolidado de %d foi enviado para aprovação do comitê", year),
+			})
+		}
+	}
+}
+
+func (uc *ConsolidationUseCaseImpl) hasUserType(ctx context.Context, userID int64, names ...string) (bool, error) {
+	userTypes, err := uc.permRepo.GetUserTypes(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, userType := range userTypes {
+		if slices.Contains(names, userType.Name) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -2,9 +2,17 @@ package usecase_impl
 
 import (
 	"fmt"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/settings_loader"
+	"hackathon-backend/utils/markdown"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
+// Tamanho máximo da descrição exibida nas listagens
+const listDescriptionMaxLen = 200
+
 // formatDate formata uma data no padrão brasileiro
 func formatDate(t time.Time) string {
 	months := map[time.Month]string{
@@ -30,5 +38,52 @@ func truncateDescription(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
-	return s[:maxLen] + "..."
+	// Não cortar no meio de um caractere acentuado
+	for maxLen > 0 && !utf8.RuneStart(s[maxLen]) {
+		maxLen--
+	}
+	return strings.TrimSpace(s[:maxLen]) + "..."
+}
+
+// listDescription retorna a descrição em texto puro, em uma linha e truncada, para listagens
+func listDescription(description string) string {
+	return truncateDescription(strings.Join(strings.Fields(markdown.PlainText(description)), " "), listDescriptionMaxLen)
+}
+
+// renderInitiativeText preenche as versões HTML (sanitizada) e texto puro dos campos Markdown
+func renderInitiativeText(initiative *entities.Initiative) {
+	initiative.DescriptionHTML = markdown.Render(initiative.Description)
+	initiative.BenefitsHTML = markdown.Render(initiative.Benefits)
+	initiative.DescriptionText = markdown.PlainText(initiative.Description)
+	initiative.BenefitsText = markdown.PlainText(initiative.Benefits)
+}
+
+// reviewSLA retorna o prazo configurado para a decisão de cada tipo de item pendente
+func reviewSLA(settings *settings_loader.SettingsLoader, itemType string) time.Duration {
+	hours := settings.SLA.InitiativeReviewHours
+	switch itemType {
+	case entities.ReviewItemCancellation:
+		hours = settings.SLA.CancellationHours
+	case entities.ReviewItemPrioritizationChange:
+		hours = settings.SLA.PrioritizationChangeHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// pendingAge retorna há quantas horas o item aguarda decisão e se já passou do SLA
+func pendingAge(settings *settings_loader.SettingsLoader, itemType string, since time.Time) (int, bool) {
+	age := time.Since(since)
+	return int(age.Hours()), age >= reviewSLA(settings, itemType)
+}
+
+// reviewItemLabel retorna o nome exibido de cada tipo de item pendente
+func reviewItemLabel(itemType string) string {
+	switch itemType {
+	case entities.ReviewItemCancellation:
+		return "Cancelamento de iniciativa"
+	case entities.ReviewItemPrioritizationChange:
+		return "Mudança de priorização"
+	default:
+		return "Iniciativa"
+	}
 }
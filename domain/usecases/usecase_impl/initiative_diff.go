@@ -0,0 +1,109 @@
+package usecase_impl
+
+import (
+	"hackathon-backend/domain/entities"
+	"strconv"
+)
+
+// Nomes exibidos dos campos da iniciativa, na ordem usada nos diffs
+var initiativeFieldLabels = []struct {
+	field string
+	label string
+}{
+	{entities.InitiativeFieldTitle, "Título"},
+	{entities.InitiativeFieldDescription, "Descrição"},
+	{entities.InitiativeFieldBenefits, "Benefícios"},
+	{entities.InitiativeFieldType, "Tipo"},
+	{entities.InitiativeFieldPriority, "Prioridade"},
+	{entities.InitiativeFieldSector, "Setor"},
+	{entities.InitiativeFieldDeadline, "Prazo"},
+	{entities.InitiativeFieldEffort, "Esforço (pessoa-dia)"},
+	{entities.InitiativeFieldCost, "Custo estimado"},
+	{entities.InitiativeFieldSkill, "Competência necessária"},
+}
+
+// initiativeSnapshot copia o conteúdo editável da iniciativa
+func initiativeSnapshot(initiative *entities.Initiative) entities.InitiativeSnapshot {
+	snapshot := entities.InitiativeSnapshot{
+		Title:       initiative.Title,
+		Description: initiative.Description,
+		Benefits:    initiative.Benefits,
+		Type:        initiative.Type,
+		Priority:    initiative.Priority,
+		Sector:      initiative.Sector,
+
+		EffortDays:    initiative.EffortDays,
+		EstimatedCost: initiative.EstimatedCost,
+		RequiredSkill: initiative.RequiredSkill,
+	}
+	if initiative.Deadline != nil {
+		deadline := initiative.Deadline.Format("2006-01-02")
+		snapshot.Deadline = &deadline
+	}
+	return snapshot
+}
+
+func snapshotField(snapshot entities.InitiativeSnapshot, field string) string {
+	switch field {
+	case entities.InitiativeFieldTitle:
+		return snapshot.Title
+	case entities.InitiativeFieldDescription:
+		return snapshot.Description
+	case entities.InitiativeFieldBenefits:
+		return snapshot.Benefits
+	case entities.InitiativeFieldType:
+		return snapshot.Type
+	case entities.InitiativeFieldPriority:
+		return snapshot.Priority
+	case entities.InitiativeFieldSector:
+		return snapshot.Sector
+	case entities.InitiativeFieldDeadline:
+		if snapshot.Deadline != nil {
+			return *snapshot.Deadline
+		}
+	case entities.InitiativeFieldEffort:
+		return formatEstimate(snapshot.EffortDays)
+	case entities.InitiativeFieldCost:
+		return formatEstimate(snapshot.EstimatedCost)
+	case entities.InitiativeFieldSkill:
+		return snapshot.RequiredSkill
+	}
+	return ""
+}
+
+func formatEstimate(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+// diffSnapshots lista os campos que mudaram entre duas versões
+func diffSnapshots(before, after entities.InitiativeSnapshot) []*entities.FieldChange {
+	changes := []*entities.FieldChange{}
+	for _, f := range initiativeFieldLabels {
+		oldValue := snapshotField(before, f.field)
+		newValue := snapshotField(after, f.field)
+		if oldValue != newValue {
+			changes = append(changes, &entities.FieldChange{
+				Field:    f.field,
+				Label:    f.label,
+				OldValue: oldValue,
+				NewValue: newValue,
+			})
+		}
+	}
+	return changes
+}
+
+func isValidChangeRequestField(field string) bool {
+	if field == entities.InitiativeFieldGeneral {
+		return true
+	}
+	for _, f := range initiativeFieldLabels {
+		if f.field == field {
+			return true
+		}
+	}
+	return false
+}
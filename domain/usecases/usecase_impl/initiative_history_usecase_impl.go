@@ -5,30 +5,46 @@ import (
 	"fmt"
 	"hackathon-backend/domain/entities"
 	"hackathon-backend/infrastructure/repositories"
+	"sort"
+	"strings"
 	"time"
 )
 
 type InitiativeHistoryUseCaseImpl struct {
 	historyRepo repositories.InitiativeHistoryRepository
+	versionRepo repositories.InitiativeVersionRepository
 }
 
-func NewInitiativeHistoryUseCaseImpl(historyRepo repositories.InitiativeHistoryRepository) *InitiativeHistoryUseCaseImpl {
+func NewInitiativeHistoryUseCaseImpl(historyRepo repositories.InitiativeHistoryRepository, versionRepo repositories.InitiativeVersionRepository) *InitiativeHistoryUseCaseImpl {
 	return &InitiativeHistoryUseCaseImpl{
 		historyRepo: historyRepo,
+		versionRepo: versionRepo,
 	}
 }
 
+// GetHistory retorna a linha do tempo da iniciativa: mudanças de status e edições de conteúdo (mais recentes primeiro)
 func (uc *InitiativeHistoryUseCaseImpl) GetHistory(ctx context.Context, initiativeID int64) ([]*entities.InitiativeHistoryResponse, error) {
 	histories, err := uc.historyRepo.ListByInitiative(ctx, initiativeID)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar histórico: %w", err)
 	}
 
-	var response []*entities.InitiativeHistoryResponse
+	versions, err := uc.versionRepo.ListByInitiative(ctx, initiativeID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar versões: %w", err)
+	}
+
+	type timelineEntry struct {
+		at   time.Time
+		item *entities.InitiativeHistoryResponse
+	}
+	var timeline []timelineEntry
+
 	for _, history := range histories {
-		response = append(response, &entities.InitiativeHistoryResponse{
+		timeline = append(timeline, timelineEntry{history.CreatedAt, &entities.InitiativeHistoryResponse{
 			ID:           history.ID,
 			InitiativeID: history.InitiativeID,
+			Kind:         entities.HistoryKindStatus,
 			UserID:       history.UserID,
 			UserName:     history.UserName,
 			OldStatus:    history.OldStatus,
@@ -36,7 +52,47 @@ func (uc *InitiativeHistoryUseCaseImpl) GetHistory(ctx context.Context, initiati
 			Reason:       history.Reason,
 			CreatedAt:    history.CreatedAt.Format("2006-01-02 15:04:05"),
 			TimeAgo:      timeAgo(history.CreatedAt),
-		})
+		}})
+	}
+
+	// A versão 1 é a criação; as seguintes são edições
+	for i := 1; i < len(versions); i++ {
+		version := versions[i]
+		changes := diffSnapshots(versions[i-1].Snapshot, version.Snapshot)
+
+		reason := "✏️ Conteúdo editado"
+		if version.RestoredFromVersion != nil {
+			reason = fmt.Sprintf("⏪ Versão %d restaurada", *version.RestoredFromVersion)
+		}
+		labels := make([]string, 0, len(changes))
+		for _, change := range changes {
+			labels = append(labels, change.Label)
+		}
+		if len(labels) > 0 {
+			reason += ": " + strings.Join(labels, ", ")
+		}
+
+		timeline = append(timeline, timelineEntry{version.CreatedAt, &entities.InitiativeHistoryResponse{
+			ID:           version.ID,
+			InitiativeID: version.InitiativeID,
+			Kind:         entities.HistoryKindEdit,
+			UserID:       version.EditedByUserID,
+			UserName:     version.EditedByName,
+			Reason:       reason,
+			Version:      version.Version,
+			Changes:      changes,
+			CreatedAt:    version.CreatedAt.Format("2006-01-02 15:04:05"),
+			TimeAgo:      timeAgo(version.CreatedAt),
+		}})
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].at.After(timeline[j].at)
+	})
+
+	response := make([]*entities.InitiativeHistoryResponse, 0, len(timeline))
+	for _, entry := range timeline {
+		response = append(response, entry.item)
 	}
 
 	return response, nil
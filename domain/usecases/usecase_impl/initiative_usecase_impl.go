@@ -2,10 +2,14 @@ package usecase_impl
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"hackathon-backend/domain/entities"
 	"hackathon-backend/infrastructure/repositories"
+	"hackathon-backend/settings_loader"
+	"hackathon-backend/utils/markdown"
+	"strings"
 	"time"
 )
 
@@ -14,6 +18,11 @@ type InitiativeUseCaseImpl struct {
 	historyRepo    repositories.InitiativeHistoryRepository
 	permRepo       repositories.PermissionRepository
 	authRepo       repositories.AuthRepository // NOVO: para buscar setor do usuário
+	notifRepo      repositories.NotificationRepository
+	roundRepo      repositories.ReviewRoundRepository
+	versionRepo    repositories.InitiativeVersionRepository
+	workflow       *approvalWorkflow
+	settings       *settings_loader.SettingsLoader
 }
 
 func NewInitiativeUseCaseImpl(
@@ -21,12 +30,22 @@ func NewInitiativeUseCaseImpl(
 	historyRepo repositories.InitiativeHistoryRepository,
 	permRepo repositories.PermissionRepository,
 	authRepo repositories.AuthRepository, // NOVO
+	notifRepo repositories.NotificationRepository,
+	decisionRepo repositories.ApprovalDecisionRepository,
+	roundRepo repositories.ReviewRoundRepository,
+	versionRepo repositories.InitiativeVersionRepository,
+	settings *settings_loader.SettingsLoader,
 ) *InitiativeUseCaseImpl {
 	return &InitiativeUseCaseImpl{
 		initiativeRepo: initiativeRepo,
 		historyRepo:    historyRepo,
 		permRepo:       permRepo,
 		authRepo:       authRepo, // NOVO
+		notifRepo:      notifRepo,
+		roundRepo:      roundRepo,
+		versionRepo:    versionRepo,
+		workflow:       newApprovalWorkflow(decisionRepo, permRepo, authRepo, settings),
+		settings:       settings,
 	}
 }
 
@@ -36,11 +55,12 @@ func (uc *InitiativeUseCaseImpl) CreateInitiative(ctx context.Context, req *enti
 		return nil, errors.New("título deve ter no mínimo 5 caracteres")
 	}
 
-	if len(req.Description) < 20 {
+	// Os mínimos valem para o texto, sem contar a marcação Markdown
+	if len(markdown.PlainText(req.Description)) < 20 {
 		return nil, errors.New("descrição deve ter no mínimo 20 caracteres")
 	}
 
-	if len(req.Benefits) < 10 {
+	if len(markdown.PlainText(req.Benefits)) < 10 {
 		return nil, errors.New("benefícios devem ter no mínimo 10 caracteres")
 	}
 
@@ -64,22 +84,54 @@ func (uc *InitiativeUseCaseImpl) CreateInitiative(ctx context.Context, req *enti
 		deadline = &t
 	}
 
-	initiative := &entities.Initiative{
-		Title:       req.Title,
-		Description: req.Description,
-		Benefits:    req.Benefits,
-		Status:      entities.StatusSubmitted, // Status inicial:  Submetida
-		Type:        req.Type,
-		Priority:    req.Priority,
-		Sector:      req.Sector,
-		OwnerID:     ownerID,
-		Deadline:    deadline,
+	if err := validateEstimates(req.EffortDays, req.EstimatedCost); err != nil {
+		return nil, err
 	}
 
+	initiative := &entities.Initiative{
+		Title:         req.Title,
+		Description:   req.Description,
+		Benefits:      req.Benefits,
+		Status:        entities.StatusSubmitted, // Status inicial:  Submetida
+		Type:          req.Type,
+		Priority:      req.Priority,
+		Sector:        req.Sector,
+		OwnerID:       ownerID,
+		Deadline:      deadline,
+		EffortDays:    positiveOrNil(req.EffortDays),
+		EstimatedCost: positiveOrNil(req.EstimatedCost),
+		RequiredSkill: strings.TrimSpace(req.RequiredSkill),
+	}
+	renderInitiativeText(initiative)
+
 	if err := uc.initiativeRepo.Create(ctx, initiative); err != nil {
 		return nil, fmt.Errorf("erro ao criar iniciativa: %w", err)
 	}
 
+	// A criação já é a primeira submissão (rodada 1) e a versão 1
+	round := &entities.InitiativeReviewRound{
+		InitiativeID:      initiative.ID,
+		Snapshot:          initiativeSnapshot(initiative),
+		SubmittedByUserID: ownerID,
+	}
+	if err := uc.roundRepo.Create(ctx, round); err != nil {
+		fmt.Printf("Erro ao registrar rodada de revisão: %v\n", err)
+	}
+
+	version := &entities.InitiativeVersion{
+		InitiativeID:   initiative.ID,
+		Snapshot:       round.Snapshot,
+		EditedByUserID: ownerID,
+	}
+	if err := uc.versionRepo.Create(ctx, version); err != nil {
+		fmt.Printf("Erro ao registrar versão: %v\n", err)
+	}
+
+	// Tipos com fluxo de aprovação: avisar os aprovadores das etapas abertas
+	if chain, _, err := uc.workflow.chain(ctx, initiative, ownerID); err == nil && chain != nil {
+		uc.notifyApprovers(ctx, initiative, chain)
+	}
+
 	return initiative, nil
 }
 
@@ -105,17 +157,28 @@ func (uc *InitiativeUseCaseImpl) ListSubmittedInitiatives(ctx context.Context, u
 	var response []*entities.InitiativeListResponse
 	for _, initiative := range initiatives {
 		listItem := &entities.InitiativeListResponse{
-			ID:          initiative.ID,
-			Title:       initiative.Title,
-			Description: initiative.Description,
-			Status:      initiative.Status,
-			Type:        initiative.Type,
-			Priority:    initiative.Priority,
-			Sector:      initiative.Sector,
-			OwnerName:   initiative.OwnerName,
-			Date:        formatDate(initiative.CreatedAt),
+			ID:            initiative.ID,
+			Title:         initiative.Title,
+			Description:   listDescription(initiative.Description),
+			Status:        initiative.Status,
+			Type:          initiative.Type,
+			Priority:      initiative.Priority,
+			Score:         initiative.Score,
+			EffortDays:    initiative.EffortDays,
+			EstimatedCost: initiative.EstimatedCost,
+			RequiredSkill: initiative.RequiredSkill,
+			Sector:        initiative.Sector,
+			OwnerName:     initiative.OwnerName,
+			Date:          formatDate(initiative.CreatedAt),
 		}
 
+		// Mesma referência do job de SLA: envio da rodada atual (ou criação, sem rodadas)
+		pendingSince := initiative.CreatedAt
+		if round, err := uc.roundRepo.GetLatest(ctx, initiative.ID); err == nil {
+			pendingSince = round.SubmittedAt
+		}
+		listItem.AgeHours, listItem.Overdue = pendingAge(uc.settings, entities.ReviewItemInitiative, pendingSince)
+
 		if initiative.CancellationRequest != nil {
 			listItem.CancellationRequest = initiative.CancellationRequest
 		}
@@ -128,17 +191,21 @@ func (uc *InitiativeUseCaseImpl) ListSubmittedInitiatives(ctx context.Context, u
 
 // CORRIGIDO: Aprovar ou reprovar iniciativa
 func (uc *InitiativeUseCaseImpl) ReviewInitiative(ctx context.Context, initiativeID int64, req *entities.ReviewInitiativeRequest, userID int64) error {
-	// Verificar se é admin ou manager
-	isAdminOrManager, err := uc.isAdminOrManager(ctx, userID)
-	if err != nil || !isAdminOrManager {
-		return errors.New("apenas administradores e gerentes podem revisar iniciativas")
-	}
-
 	// Validar justificativa
 	if len(req.Reason) < 10 {
 		return errors.New("justificativa deve ter no mínimo 10 caracteres")
 	}
 
+	// "decision" tem precedência sobre "approved"
+	switch req.Decision {
+	case "":
+	case entities.ReviewDecisionApprove, entities.ReviewDecisionReject:
+		req.Approved = req.Decision == entities.ReviewDecisionApprove
+	case entities.ReviewDecisionReturn:
+	default:
+		return errors.New("decisão inválida. Use: approve, reject ou return")
+	}
+
 	// Buscar iniciativa
 	initiative, err := uc.initiativeRepo.GetByID(ctx, initiativeID)
 	if err != nil {
@@ -150,6 +217,21 @@ func (uc *InitiativeUseCaseImpl) ReviewInitiative(ctx context.Context, initiativ
 		return errors.New("apenas iniciativas submetidas podem ser revisadas")
 	}
 
+	if req.Decision == entities.ReviewDecisionReturn {
+		return uc.returnInitiative(ctx, initiative, req, userID)
+	}
+
+	// Tipos com fluxo de aprovação em etapas
+	if uc.settings.ApprovalWorkflowFor(initiative.Type) != nil {
+		return uc.reviewApprovalStep(ctx, initiative, req, userID)
+	}
+
+	// Verificar se é admin ou manager
+	isAdminOrManager, err := uc.isAdminOrManager(ctx, userID)
+	if err != nil || !isAdminOrManager {
+		return errors.New("apenas administradores e gerentes podem revisar iniciativas")
+	}
+
 	var newStatus string
 	var historyReason string
 
@@ -184,6 +266,8 @@ func (uc *InitiativeUseCaseImpl) UpdateInitiative(ctx context.Context, initiativ
 		return nil, errors.New("você não tem permissão para editar esta iniciativa")
 	}
 
+	before := initiativeSnapshot(initiative)
+
 	// Atualizar campos
 	if req.Title != nil {
 		if len(*req.Title) < 5 {
@@ -193,7 +277,7 @@ func (uc *InitiativeUseCaseImpl) UpdateInitiative(ctx context.Context, initiativ
 	}
 
 	if req.Description != nil {
-		if len(*req.Description) < 20 {
+		if len(markdown.PlainText(*req.Description)) < 20 {
 			return nil, errors.New("descrição deve ter no mínimo 20 caracteres")
 		}
 		initiative.Description = *req.Description
@@ -233,10 +317,28 @@ func (uc *InitiativeUseCaseImpl) UpdateInitiative(ctx context.Context, initiativ
 		}
 	}
 
+	// Estimativas: 0 remove o valor
+	if err := validateEstimates(req.EffortDays, req.EstimatedCost); err != nil {
+		return nil, err
+	}
+	if req.EffortDays != nil {
+		initiative.EffortDays = positiveOrNil(req.EffortDays)
+	}
+	if req.EstimatedCost != nil {
+		initiative.EstimatedCost = positiveOrNil(req.EstimatedCost)
+	}
+	if req.RequiredSkill != nil {
+		initiative.RequiredSkill = strings.TrimSpace(*req.RequiredSkill)
+	}
+
+	renderInitiativeText(initiative)
+
 	if err := uc.initiativeRepo.Update(ctx, initiative); err != nil {
 		return nil, fmt.Errorf("erro ao atualizar iniciativa:  %w", err)
 	}
 
+	uc.recordVersion(ctx, initiative, before, userID, nil)
+
 	return initiative, nil
 }
 
@@ -257,7 +359,13 @@ func (uc *InitiativeUseCaseImpl) DeleteInitiative(ctx context.Context, initiativ
 }
 
 func (uc *InitiativeUseCaseImpl) GetInitiativeByID(ctx context.Context, initiativeID int64) (*entities.Initiative, error) {
-	return uc.initiativeRepo.GetByIDWithCancellation(ctx, initiativeID)
+	initiative, err := uc.initiativeRepo.GetByIDWithCancellation(ctx, initiativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	renderInitiativeText(initiative)
+	return initiative, nil
 }
 
 func (uc *InitiativeUseCaseImpl) ListInitiatives(ctx context.Context, filter *entities.InitiativeFilter, userID int64) ([]*entities.InitiativeListResponse, error) {
@@ -299,15 +407,19 @@ func (uc *InitiativeUseCaseImpl) ListInitiatives(ctx context.Context, filter *en
 	var response []*entities.InitiativeListResponse
 	for _, initiative := range initiatives {
 		listItem := &entities.InitiativeListResponse{
-			ID:          initiative.ID,
-			Title:       initiative.Title,
-			Description: initiative.Description,
-			Status:      initiative.Status,
-			Type:        initiative.Type,
-			Priority:    initiative.Priority,
-			Sector:      initiative.Sector,
-			OwnerName:   initiative.OwnerName,
-			Date:        formatDate(initiative.CreatedAt),
+			ID:            initiative.ID,
+			Title:         initiative.Title,
+			Description:   listDescription(initiative.Description),
+			Status:        initiative.Status,
+			Type:          initiative.Type,
+			Priority:      initiative.Priority,
+			Score:         initiative.Score,
+			EffortDays:    initiative.EffortDays,
+			EstimatedCost: initiative.EstimatedCost,
+			RequiredSkill: initiative.RequiredSkill,
+			Sector:        initiative.Sector,
+			OwnerName:     initiative.OwnerName,
+			Date:          formatDate(initiative.CreatedAt),
 		}
 
 		if initiative.CancellationRequest != nil {
@@ -407,6 +519,25 @@ func isValidPriority(p string) bool {
 	return false
 }
 
+// Estimativas são opcionais; valores negativos não são aceitos
+func validateEstimates(effortDays, estimatedCost *float64) error {
+	if effortDays != nil && *effortDays < 0 {
+		return errors.New("esforço estimado não pode ser negativo")
+	}
+	if estimatedCost != nil && *estimatedCost < 0 {
+		return errors.New("custo estimado não pode ser negativo")
+	}
+	return nil
+}
+
+func positiveOrNil(value *float64) *float64 {
+	if value == nil || *value <= 0 {
+		return nil
+	}
+	v := *value
+	return &v
+}
+
 func isValidStatus(s string) bool {
 	validStatuses := []string{
 		entities.StatusSubmitted,
@@ -427,3 +558,384 @@ func isValidStatus(s string) bool {
 	}
 	return false
 }
+
+// GetApprovalChain retorna as etapas do fluxo de aprovação da iniciativa com as decisões registradas
+func (uc *InitiativeUseCaseImpl) GetApprovalChain(ctx context.Context, initiativeID int64, userID int64) (*entities.ApprovalChain, error) {
+	initiative, err := uc.initiativeRepo.GetByID(ctx, initiativeID)
+	if err != nil {
+		return nil, errors.New("iniciativa não encontrada")
+	}
+
+	chain, _, err := uc.workflow.chain(ctx, initiative, userID)
+	if err != nil {
+		return nil, err
+	}
+	if chain == nil {
+		return nil, errors.New("este tipo de iniciativa usa revisão única, sem fluxo de aprovação")
+	}
+
+	// Só é possível decidir enquanto a iniciativa está submetida
+	if initiative.Status != entities.StatusSubmitted {
+		chain.CanDecide = false
+	}
+
+	return chain, nil
+}
+
+// reviewApprovalStep registra a decisão do usuário na etapa pendente que lhe cabe.
+// Qualquer reprovação encerra o fluxo; a iniciativa só é aprovada quando todas as etapas concluírem
+func (uc *InitiativeUseCaseImpl) reviewApprovalStep(ctx context.Context, initiative *entities.Initiative, req *entities.ReviewInitiativeRequest, userID int64) error {
+	chain, decidable, err := uc.workflow.chain(ctx, initiative, userID)
+	if err != nil {
+		return err
+	}
+	if len(decidable) == 0 {
+		return errors.New("você não é aprovador de nenhuma etapa pendente desta iniciativa")
+	}
+
+	step := chain.Steps[decidable[0]]
+	decision := &entities.ApprovalDecision{
+		InitiativeID: initiative.ID,
+		StepIndex:    step.Index,
+		StepName:     step.Name,
+		UserID:       userID,
+		Approved:     req.Approved,
+		Reason:       req.Reason,
+	}
+
+	if err := uc.workflow.decisionRepo.Create(ctx, decision); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("você já registrou sua decisão nesta etapa")
+		}
+		return fmt.Errorf("erro ao registrar decisão: %w", err)
+	}
+
+	if !req.Approved {
+		historyReason := fmt.Sprintf("❌ Iniciativa reprovada na etapa \"%s\": %s", step.Name, req.Reason)
+		if err := uc.initiativeRepo.ChangeStatusWithUser(ctx, initiative.ID, entities.StatusRejected, historyReason, userID); err != nil {
+			return fmt.Errorf("erro ao revisar iniciativa: %w", err)
+		}
+		return nil
+	}
+
+	chain, _, err = uc.workflow.chain(ctx, initiative, userID)
+	if err != nil {
+		return err
+	}
+
+	if chain.Status == entities.ApprovalChainApproved {
+		historyReason := fmt.Sprintf("✅ Iniciativa aprovada (fluxo concluído na etapa \"%s\"): %s", step.Name, req.Reason)
+		if err := uc.initiativeRepo.ChangeStatusWithUser(ctx, initiative.ID, entities.StatusApproved, historyReason, userID); err != nil {
+			return fmt.Errorf("erro ao revisar iniciativa: %w", err)
+		}
+		return nil
+	}
+
+	// Fluxo em andamento: registrar a aprovação parcial no histórico sem mudar o status
+	updated := chain.Steps[step.Index]
+	history := &entities.InitiativeHistory{
+		InitiativeID: initiative.ID,
+		UserID:       userID,
+		OldStatus:    initiative.Status,
+		NewStatus:    initiative.Status,
+		Reason:       fmt.Sprintf("👍 Aprovação na etapa \"%s\" (%d/%d): %s", step.Name, updated.Approvals, updated.Required, req.Reason),
+	}
+	if err := uc.historyRepo.Create(ctx, history); err != nil {
+		// Log do erro mas não falha a operação
+		fmt.Printf("Erro ao registrar histórico de aprovação: %v\n", err)
+	}
+
+	// Fluxo sequencial com etapa concluída: avisar quem decide a próxima
+	if updated.Status == entities.ApprovalStepApproved && chain.Mode == settings_loader.ApprovalModeSequential {
+		uc.notifyApprovers(ctx, initiative, chain)
+	}
+
+	return nil
+}
+
+// notifyApprovers avisa os aprovadores das etapas pendentes que ainda não decidiram
+func (uc *InitiativeUseCaseImpl) notifyApprovers(ctx context.Context, initiative *entities.Initiative, chain *entities.ApprovalChain) {
+	userIDs, err := uc.workflow.pendingApprovers(ctx, initiative, chain)
+	if err != nil {
+		fmt.Printf("Erro ao buscar aprovadores: %v\n", err)
+		return
+	}
+
+	initiativeID := initiative.ID
+	for _, userID := range userIDs {
+		if userID == initiative.OwnerID {
+			continue
+		}
+
+		notify(ctx, uc.notifRepo, &entities.Notification{
+			UserID:       userID,
+			Type:         entities.NotificationTypeApprovalStep,
+			Title:        "Aprovação pendente",
+			Message:      fmt.Sprintf("A iniciativa \"%s\" aguarda sua decisão no fluxo de aprovação", initiative.Title),
+			InitiativeID: &initiativeID,
+		})
+	}
+}
+
+// returnInitiative devolve a iniciativa ao dono com os pedidos de ajuste, encerrando a rodada atual
+func (uc *InitiativeUseCaseImpl) returnInitiative(ctx context.Context, initiative *entities.Initiative, req *entities.ReviewInitiativeRequest, userID int64) error {
+	if len(req.ChangeRequests) == 0 {
+		return errors.New("informe ao menos um pedido de ajuste para devolver a iniciativa")
+	}
+	for _, changeRequest := range req.ChangeRequests {
+		if !isValidChangeRequestField(changeRequest.Field) {
+			return fmt.Errorf("campo inválido no pedido de ajuste: %s", changeRequest.Field)
+		}
+		if strings.TrimSpace(changeRequest.Comment) == "" {
+			return errors.New("todo pedido de ajuste precisa de um comentário")
+		}
+	}
+
+	// Quem pode decidir também pode devolver
+	if uc.settings.ApprovalWorkflowFor(initiative.Type) != nil {
+		_, decidable, err := uc.workflow.chain(ctx, initiative, userID)
+		if err != nil {
+			return err
+		}
+		if len(decidable) == 0 {
+			return errors.New("você não é aprovador de nenhuma etapa pendente desta iniciativa")
+		}
+	} else {
+		isAdminOrManager, err := uc.isAdminOrManager(ctx, userID)
+		if err != nil || !isAdminOrManager {
+			return errors.New("apenas administradores e gerentes podem revisar iniciativas")
+		}
+	}
+
+	round, err := uc.currentRound(ctx, initiative)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.roundRepo.MarkReturned(ctx, round.ID, userID, req.Reason, req.ChangeRequests); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("esta rodada de revisão já foi encerrada")
+		}
+		return fmt.Errorf("erro ao registrar devolução: %w", err)
+	}
+
+	historyReason := fmt.Sprintf("↩️ Iniciativa devolvida para ajustes (rodada %d, %d pedido(s)): %s", round.Round, len(req.ChangeRequests), req.Reason)
+	if err := uc.initiativeRepo.ChangeStatusWithUser(ctx, initiative.ID, entities.StatusReturned, historyReason, userID); err != nil {
+		return fmt.Errorf("erro ao devolver iniciativa: %w", err)
+	}
+
+	initiativeID := initiative.ID
+	notify(ctx, uc.notifRepo, &entities.Notification{
+		UserID:       initiative.OwnerID,
+		Type:         entities.NotificationTypeReturned,
+		Title:        "Iniciativa devolvida para ajustes",
+		Message:      fmt.Sprintf("A iniciativa \"%s\" foi devolvida com %d pedido(s) de ajuste. Edite e reenvie.", initiative.Title, len(req.ChangeRequests)),
+		InitiativeID: &initiativeID,
+	})
+
+	return nil
+}
+
+// ResubmitInitiative reenvia uma iniciativa devolvida, abrindo nova rodada com o diff em relação à anterior
+func (uc *InitiativeUseCaseImpl) ResubmitInitiative(ctx context.Context, initiativeID int64, req *entities.ResubmitInitiativeRequest, userID int64) (*entities.InitiativeReviewRound, error) {
+	initiative, err := uc.initiativeRepo.GetByID(ctx, initiativeID)
+	if err != nil {
+		return nil, errors.New("iniciativa não encontrada")
+	}
+
+	if initiative.OwnerID != userID {
+		return nil, errors.New("apenas o dono pode reenviar a iniciativa")
+	}
+
+	if initiative.Status != entities.StatusReturned {
+		return nil, errors.New("apenas iniciativas devolvidas podem ser reenviadas")
+	}
+
+	previous, err := uc.currentRound(ctx, initiative)
+	if err != nil {
+		return nil, err
+	}
+
+	round := &entities.InitiativeReviewRound{
+		InitiativeID:      initiative.ID,
+		Snapshot:          initiativeSnapshot(initiative),
+		SubmittedByUserID: userID,
+		SubmitComment:     strings.TrimSpace(req.Comment),
+	}
+	round.Changes = diffSnapshots(previous.Snapshot, round.Snapshot)
+
+	if err := uc.roundRepo.Create(ctx, round); err != nil {
+		return nil, fmt.Errorf("erro ao registrar rodada de revisão: %w", err)
+	}
+
+	historyReason := fmt.Sprintf("🔁 Iniciativa reenviada (rodada %d, %d campo(s) alterado(s))", round.Round, len(round.Changes))
+	if round.SubmitComment != "" {
+		historyReason += ": " + round.SubmitComment
+	}
+	if err := uc.initiativeRepo.ChangeStatusWithUser(ctx, initiative.ID, entities.StatusSubmitted, historyReason, userID); err != nil {
+		return nil, fmt.Errorf("erro ao reenviar iniciativa: %w", err)
+	}
+
+	if previous.ReturnedByUserID != nil && *previous.ReturnedByUserID != userID {
+		notify(ctx, uc.notifRepo, &entities.Notification{
+			UserID:       *previous.ReturnedByUserID,
+			Type:         entities.NotificationTypeResubmitted,
+			Title:        "Iniciativa reenviada",
+			Message:      fmt.Sprintf("A iniciativa \"%s\" que você devolveu foi ajustada e reenviada", initiative.Title),
+			InitiativeID: &initiativeID,
+		})
+	}
+
+	// Fluxo de aprovação recomeça na nova rodada
+	initiative.Status = entities.StatusSubmitted
+	if chain, _, err := uc.workflow.chain(ctx, initiative, userID); err == nil && chain != nil {
+		uc.notifyApprovers(ctx, initiative, chain)
+	}
+
+	return round, nil
+}
+
+// ListReviewRounds lista as rodadas de revisão, cada uma com o diff em relação à anterior
+func (uc *InitiativeUseCaseImpl) ListReviewRounds(ctx context.Context, initiativeID int64) ([]*entities.InitiativeReviewRound, error) {
+	if _, err := uc.initiativeRepo.GetByID(ctx, initiativeID); err != nil {
+		return nil, errors.New("iniciativa não encontrada")
+	}
+
+	rounds, err := uc.roundRepo.ListByInitiative(ctx, initiativeID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar rodadas de revisão: %w", err)
+	}
+
+	for i := 1; i < len(rounds); i++ {
+		rounds[i].Changes = diffSnapshots(rounds[i-1].Snapshot, rounds[i].Snapshot)
+	}
+
+	return rounds, nil
+}
+
+// currentRound retorna a rodada atual; iniciativas anteriores às rodadas ganham a rodada 1 com o conteúdo atual
+func (uc *InitiativeUseCaseImpl) currentRound(ctx context.Context, initiative *entities.Initiative) (*entities.InitiativeReviewRound, error) {
+	round, err := uc.roundRepo.GetLatest(ctx, initiative.ID)
+	if err == nil {
+		return round, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("erro ao buscar rodada de revisão: %w", err)
+	}
+
+	round = &entities.InitiativeReviewRound{
+		InitiativeID:      initiative.ID,
+		Snapshot:          initiativeSnapshot(initiative),
+		SubmittedByUserID: initiative.OwnerID,
+	}
+	if err := uc.roundRepo.Create(ctx, round); err != nil {
+		return nil, fmt.Errorf("erro ao registrar rodada de revisão: %w", err)
+	}
+
+	return round, nil
+}
+
+// ListVersions lista as versões da iniciativa, cada uma com o diff em relação à anterior
+func (uc *InitiativeUseCaseImpl) ListVersions(ctx context.Context, initiativeID int64) ([]*entities.InitiativeVersion, error) {
+	if _, err := uc.initiativeRepo.GetByID(ctx, initiativeID); err != nil {
+		return nil, errors.New("iniciativa não encontrada")
+	}
+
+	versions, err := uc.versionRepo.ListByInitiative(ctx, initiativeID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar versões: %w", err)
+	}
+
+	for i := 1; i < len(versions); i++ {
+		versions[i].Changes = diffSnapshots(versions[i-1].Snapshot, versions[i].Snapshot)
+	}
+
+	return versions, nil
+}
+
+// DiffVersions compara duas versões quaisquer da iniciativa
+func (uc *InitiativeUseCaseImpl) DiffVersions(ctx context.Context, initiativeID int64, fromVersion, toVersion int) (*entities.InitiativeVersionDiff, error) {
+	from, err := uc.versionRepo.GetByVersion(ctx, initiativeID, fromVersion)
+	if err != nil {
+		return nil, fmt.Errorf("versão %d não encontrada", fromVersion)
+	}
+
+	to, err := uc.versionRepo.GetByVersion(ctx, initiativeID, toVersion)
+	if err != nil {
+		return nil, fmt.Errorf("versão %d não encontrada", toVersion)
+	}
+
+	return &entities.InitiativeVersionDiff{
+		InitiativeID: initiativeID,
+		FromVersion:  fromVersion,
+		ToVersion:    toVersion,
+		Changes:      diffSnapshots(from.Snapshot, to.Snapshot),
+	}, nil
+}
+
+// RestoreVersion volta o conteúdo da iniciativa para uma versão anterior (apenas admin), gerando uma nova versão
+func (uc *InitiativeUseCaseImpl) RestoreVersion(ctx context.Context, initiativeID int64, version int, userID int64) (*entities.Initiative, error) {
+	isAdmin, err := uc.isAdmin(ctx, userID)
+	if err != nil || !isAdmin {
+		return nil, errors.New("apenas administradores podem restaurar versões")
+	}
+
+	initiative, err := uc.initiativeRepo.GetByID(ctx, initiativeID)
+	if err != nil {
+		return nil, errors.New("iniciativa não encontrada")
+	}
+
+	target, err := uc.versionRepo.GetByVersion(ctx, initiativeID, version)
+	if err != nil {
+		return nil, fmt.Errorf("versão %d não encontrada", version)
+	}
+
+	before := initiativeSnapshot(initiative)
+	if len(diffSnapshots(before, target.Snapshot)) == 0 {
+		return nil, errors.New("o conteúdo atual já é igual ao desta versão")
+	}
+
+	initiative.Title = target.Snapshot.Title
+	initiative.Description = target.Snapshot.Description
+	initiative.Benefits = target.Snapshot.Benefits
+	initiative.Type = target.Snapshot.Type
+	initiative.Priority = target.Snapshot.Priority
+	initiative.Sector = target.Snapshot.Sector
+	initiative.EffortDays = target.Snapshot.EffortDays
+	initiative.EstimatedCost = target.Snapshot.EstimatedCost
+	initiative.RequiredSkill = target.Snapshot.RequiredSkill
+	initiative.Deadline = nil
+	if target.Snapshot.Deadline != nil {
+		if t, err := time.Parse("2006-01-02", *target.Snapshot.Deadline); err == nil {
+			initiative.Deadline = &t
+		}
+	}
+	renderInitiativeText(initiative)
+
+	if err := uc.initiativeRepo.Update(ctx, initiative); err != nil {
+		return nil, fmt.Errorf("erro ao restaurar versão: %w", err)
+	}
+
+	uc.recordVersion(ctx, initiative, before, userID, &version)
+
+	return initiative, nil
+}
+
+// recordVersion grava uma nova versão se o conteúdo mudou
+func (uc *InitiativeUseCaseImpl) recordVersion(ctx context.Context, initiative *entities.Initiative, before entities.InitiativeSnapshot, userID int64, restoredFrom *int) {
+	after := initiativeSnapshot(initiative)
+	if len(diffSnapshots(before, after)) == 0 {
+		return
+	}
+
+	version := &entities.InitiativeVersion{
+		InitiativeID:        initiative.ID,
+		Snapshot:            after,
+		EditedByUserID:      userID,
+		RestoredFromVersion: restoredFrom,
+	}
+	if err := uc.versionRepo.Create(ctx, version); err != nil {
+		// Log do erro mas não falha a operação
+		fmt.Printf("Erro ao registrar versão: %v\n", err)
+	}
+}
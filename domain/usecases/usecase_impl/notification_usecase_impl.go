@@ -0,0 +1,75 @@
+package usecase_impl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/infrastructure/repositories"
+	"log"
+)
+
+type NotificationUseCaseImpl struct {
+	notificationRepo repositories.NotificationRepository
+}
+
+func NewNotificationUseCaseImpl(notificationRepo repositories.NotificationRepository) *NotificationUseCaseImpl {
+	return &NotificationUseCaseImpl{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// ListNotifications retorna as notificações do usuário e o total de não lidas
+func (uc *NotificationUseCaseImpl) ListNotifications(ctx context.Context, userID int64, filter *entities.NotificationFilter) ([]*entities.NotificationListResponse, int, error) {
+	notifications, err := uc.notificationRepo.ListByUser(ctx, userID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar notificações: %w", err)
+	}
+
+	unread, err := uc.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar notificações: %w", err)
+	}
+
+	response := make([]*entities.NotificationListResponse, 0, len(notifications))
+	for _, notification := range notifications {
+		response = append(response, &entities.NotificationListResponse{
+			ID:           notification.ID,
+			Type:         notification.Type,
+			Title:        notification.Title,
+			Message:      notification.Message,
+			InitiativeID: notification.InitiativeID,
+			CommentID:    notification.CommentID,
+			Read:         notification.ReadAt != nil,
+			CreatedAt:    notification.CreatedAt.Format("2006-01-02 15:04:05"),
+			TimeAgo:      timeAgo(notification.CreatedAt),
+		})
+	}
+
+	return response, unread, nil
+}
+
+func (uc *NotificationUseCaseImpl) MarkAsRead(ctx context.Context, notificationID, userID int64) error {
+	if err := uc.notificationRepo.MarkAsRead(ctx, notificationID, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("notificação não encontrada")
+		}
+		return fmt.Errorf("erro ao marcar notificação como lida: %w", err)
+	}
+	return nil
+}
+
+func (uc *NotificationUseCaseImpl) MarkAllAsRead(ctx context.Context, userID int64) error {
+	if err := uc.notificationRepo.MarkAllAsRead(ctx, userID); err != nil {
+		return fmt.Errorf("erro ao marcar notificações como lidas: %w", err)
+	}
+	return nil
+}
+
+// notify cria a notificação sem interromper a operação principal em caso de erro
+func notify(ctx context.Context, repo repositories.NotificationRepository, notification *entities.Notification) {
+	if err := repo.Create(ctx, notification); err != nil {
+		log.Printf("Erro ao criar notificação: %v", err)
+	}
+}
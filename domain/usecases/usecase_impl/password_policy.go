@@ -0,0 +1,126 @@
+package usecase_impl
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"hackathon-backend/settings_loader"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+// Lista de senhas comuns (carregada do arquivo embutido)
+var commonPasswords = parseCommonPasswords(commonPasswordsFile)
+
+func parseCommonPasswords(content string) map[string]struct{} {
+	passwords := make(map[string]struct{})
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.ToLower(strings.TrimSpace(line)); line != "" {
+			passwords[line] = struct{}{}
+		}
+	}
+	return passwords
+}
+
+// validatePassword aplica a política de senhas configurada e retorna todas as violações
+func validatePassword(policy settings_loader.PasswordConfig, password, email, name string) error {
+	var violations []string
+
+	if len([]rune(password)) < policy.MinLength {
+		violations = append(violations, fmt.Sprintf("deve ter no mínimo %d caracteres", policy.MinLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	if policy.RequireUppercase && !hasUpper {
+		violations = append(violations, "deve conter ao menos uma letra maiúscula")
+	}
+	if policy.RequireLowercase && !hasLower {
+		violations = append(violations, "deve conter ao menos uma letra minúscula")
+	}
+	if policy.RequireDigit && !hasDigit {
+		violations = append(violations, "deve conter ao menos um número")
+	}
+	if policy.RequireSymbol && !hasSymbol {
+		violations = append(violations, "deve conter ao menos um caractere especial")
+	}
+
+	lower := strings.ToLower(password)
+
+	if policy.RejectPersonalInfo && containsPersonalInfo(lower, email, name) {
+		violations = append(violations, "não pode conter seu email ou nome")
+	}
+
+	if policy.RejectCommon {
+		if _, found := commonPasswords[lower]; found {
+			violations = append(violations, "é muito comum")
+		}
+	}
+
+	if len(violations) > 0 {
+		return errors.New("senha inválida: " + strings.Join(violations, "; "))
+	}
+
+	return nil
+}
+
+// containsPersonalInfo verifica se a senha contém a parte local do email ou partes do nome
+func containsPersonalInfo(lowerPassword, email, name string) bool {
+	localPart, _, _ := strings.Cut(strings.ToLower(email), "@")
+	if len(localPart) >= 3 && strings.Contains(lowerPassword, localPart) {
+		return true
+	}
+
+	for _, part := range strings.Fields(strings.ToLower(name)) {
+		if len([]rune(part)) >= 3 && strings.Contains(lowerPassword, part) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hashPassword gera o hash bcrypt com o custo configurado
+func hashPassword(policy settings_loader.PasswordConfig, password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), policy.BcryptCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// needsRehash indica se o hash foi gerado com custo menor que o configurado
+func needsRehash(policy settings_loader.PasswordConfig, hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return false
+	}
+	return cost < policy.BcryptCost
+}
+
+// isPasswordReused verifica se a senha bate com algum dos hashes informados
+func isPasswordReused(password string, hashes []string) bool {
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,8 @@ package usecase_impl
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"hackathon-backend/domain/entities"
 	"hackathon-backend/infrastructure/repositories"
@@ -58,3 +60,14 @@ func (uc *PermissionUseCaseImpl) RemoveUserType(ctx context.Context, userID, use
 func (uc *PermissionUseCaseImpl) GetAllUserTypes(ctx context.Context) ([]*entities.UserType, error) {
 	return uc.permRepo.GetAllUserTypes(ctx)
 }
+
+// SetTwoFactorRequired exige (ou não) 2FA dos usuários de um tipo
+func (uc *PermissionUseCaseImpl) SetTwoFactorRequired(ctx context.Context, userTypeID int64, required bool) error {
+	if err := uc.permRepo.SetTwoFactorRequired(ctx, userTypeID, required); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("tipo de usuário não encontrado")
+		}
+		return fmt.Errorf("erro ao atualizar política de 2FA: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,499 @@
+package usecase_impl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/infrastructure/repositories"
+	"slices"
+	"time"
+)
+
+type PlanningCycleUseCaseImpl struct {
+	cycleRepo          repositories.PlanningCycleRepository
+	prioritizationRepo repositories.PrioritizationRepository
+	initiativeRepo     repositories.InitiativeRepository
+	sectorRepo         repositories.SectorRepository
+	permRepo           repositories.PermissionRepository
+}
+
+func NewPlanningCycleUseCaseImpl(
+	cycleRepo repositories.PlanningCycleRepository,
+	prioritizationRepo repositories.PrioritizationRepository,
+	initiativeRepo repositories.InitiativeRepository,
+	sectorRepo repositories.SectorRepository,
+	permRepo repositories.PermissionRepository,
+) *PlanningCycleUseCaseImpl {
+	return &PlanningCycleUseCaseImpl{
+		cycleRepo:          cycleRepo,
+		prioritizationRepo: prioritizationRepo,
+		initiativeRepo:     initiativeRepo,
+		sectorRepo:         sectorRepo,
+		permRepo:           permRepo,
+	}
+}
+
+// CreateCycle cadastra um ciclo de planejamento (apenas admin)
+func (uc *PlanningCycleUseCaseImpl) CreateCycle(ctx context.Context, req *entities.CreatePlanningCycleRequest, userID int64) (*entities.PlanningCycle, error) {
+	if ok, err := uc.hasUserType(ctx, userID, "admin"); err != nil || !ok {
+		return nil, errors.New("apenas administradores podem cadastrar ciclos de planejamento")
+	}
+
+	if req.Year < 2020 || req.Year > 2100 {
+		return nil, errors.New("ano inválido")
+	}
+
+	if req.Quarter != nil && (*req.Quarter < 1 || *req.Quarter > 4) {
+		return nil, errors.New("trimestre deve estar entre 1 e 4")
+	}
+
+	cycle := &entities.PlanningCycle{
+		Year:            req.Year,
+		Quarter:         req.Quarter,
+		Name:            req.Name,
+		OpensAt:         req.OpensAt,
+		ClosesAt:        req.ClosesAt,
+		SectorIDs:       req.SectorIDs,
+		CreatedByUserID: userID,
+	}
+
+	if cycle.Name == "" {
+		cycle.Name = fmt.Sprintf("Planejamento %d", cycle.Year)
+		if cycle.Quarter != nil {
+			cycle.Name = fmt.Sprintf("Planejamento %d T%d", cycle.Year, *cycle.Quarter)
+		}
+	}
+
+	if err := uc.validateCycle(ctx, cycle); err != nil {
+		return nil, err
+	}
+
+	if err := uc.cycleRepo.Create(ctx, cycle); err != nil {
+		return nil, fmt.Errorf("erro ao criar ciclo de planejamento: %w", err)
+	}
+
+	return uc.getCycle(ctx, cycle.ID)
+}
+
+// UpdateCycle altera nome, janela ou setores de um ciclo ainda não encerrado (apenas admin)
+func (uc *PlanningCycleUseCaseImpl) UpdateCycle(ctx context.Context, cycleID int64, req *entities.UpdatePlanningCycleRequest, userID int64) (*entities.PlanningCycle, error) {
+	if ok, err := uc.hasUserType(ctx, userID, "admin"); err != nil || !ok {
+		return nil, errors.New("apenas administradores podem alterar ciclos de planejamento")
+	}
+
+	cycle, err := uc.getCycle(ctx, cycleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cycle.Status == entities.PlanningCycleClosed {
+		return nil, errors.New("ciclo de planejamento já encerrado")
+	}
+
+	if req.Name != nil && *req.Name != "" {
+		cycle.Name = *req.Name
+	}
+	if req.OpensAt != nil {
+		cycle.OpensAt = *req.OpensAt
+	}
+	if req.ClosesAt != nil {
+		cycle.ClosesAt = *req.ClosesAt
+	}
+	if req.SectorIDs != nil {
+		cycle.SectorIDs = req.SectorIDs
+	}
+
+	if err := uc.validateCycle(ctx, cycle); err != nil {
+		return nil, err
+	}
+
+	if err := uc.cycleRepo.Update(ctx, cycle); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("ciclo de planejamento já encerrado")
+		}
+		return nil, fmt.Errorf("erro ao atualizar ciclo de planejamento: %w", err)
+	}
+
+	return uc.getCycle(ctx, cycleID)
+}
+
+// ListCycles lista os ciclos do ano (0 = todos) com a situação atual
+func (uc *PlanningCycleUseCaseImpl) ListCycles(ctx context.Context, year int) ([]*entities.PlanningCycle, error) {
+	cycles, err := uc.cycleRepo.List(ctx, year)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar ciclos de planejamento: %w", err)
+	}
+
+	now := time.Now()
+	for _, cycle := range cycles {
+		cycle.Status = cycle.StatusAt(now)
+	}
+
+	return cycles, nil
+}
+
+// GetCycleOverview mostra a situação de cada setor participante (admin/manager)
+func (uc *PlanningCycleUseCaseImpl) GetCycleOverview(ctx context.Context, cycleID int64, userID int64) (*entities.PlanningCycleOverview, error) {
+	if ok, err := uc.hasUserType(ctx, userID, "admin", "manager"); err != nil || !ok {
+		return nil, errors.New("apenas administradores e gerentes podem acompanhar os ciclos de planejamento")
+	}
+
+	cycle, err := uc.getCycle(ctx, cycleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.buildOverview(ctx, cycle)
+}
+
+// CloseCycle encerra o ciclo antes do prazo, bloqueando as priorizações dos participantes (apenas admin)
+func (uc *PlanningCycleUseCaseImpl) CloseCycle(ctx context.Context, cycleID int64, userID int64) (*entities.PlanningCycleOverview, error) {
+	if ok, err := uc.hasUserType(ctx, userID, "admin"); err != nil || !ok {
+		return nil, errors.New("apenas administradores podem encerrar ciclos de planejamento")
+	}
+
+	cycle, err := uc.getCycle(ctx, cycleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cycle.ClosedAt != nil {
+		return nil, errors.New("ciclo de planejamento já encerrado")
+	}
+
+	_, invalid, err := uc.closeCycle(ctx, cycle)
+	if err != nil {
+		return nil, err
+	}
+
+	cycle, err = uc.getCycle(ctx, cycleID)
+	if err != nil {
+		return nil, err
+	}
+
+	overview, err := uc.buildOverview(ctx, cycle)
+	if err != nil {
+		return nil, err
+	}
+	overview.InvalidDrafts = invalid
+
+	return overview, nil
+}
+
+// RunOnce processa as aberturas (desbloqueio e carry-forward) e os encerramentos (bloqueio) pendentes
+func (uc *PlanningCycleUseCaseImpl) RunOnce(ctx context.Context) (*entities.PlanningCycleRunResult, error) {
+	result := &entities.PlanningCycleRunResult{}
+
+	toOpen, err := uc.cycleRepo.ListDueToOpen(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar ciclos para abrir: %w", err)
+	}
+
+	for _, cycle := range toOpen {
+		carried, err := uc.openCycle(ctx, cycle)
+		if err != nil {
+			fmt.Printf("Erro ao abrir ciclo de planejamento %d: %v\n", cycle.ID, err)
+			continue
+		}
+		result.Opened++
+		result.CarriedOver += carried
+	}
+
+	toClose, err := uc.cycleRepo.ListDueToClose(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar ciclos para encerrar: %w", err)
+	}
+
+	for _, cycle := range toClose {
+		locked, invalid, err := uc.closeCycle(ctx, cycle)
+		if err != nil {
+			fmt.Printf("Erro ao encerrar ciclo de planejamento %d: %v\n", cycle.ID, err)
+			continue
+		}
+		result.Closed++
+		result.Locked += locked
+		result.Invalid += len(invalid)
+	}
+
+	return result, nil
+}
+
+// openCycle volta para rascunho as priorizações enviadas do ano dos participantes e cria rascunhos
+// com as iniciativas não concluídas para quem ainda não tem priorização no ano
+func (uc *PlanningCycleUseCaseImpl) openCycle(ctx context.Context, cycle *entities.PlanningCycle) (int, error) {
+	sectors, err := uc.participants(ctx, cycle)
+	if err != nil {
+		return 0, err
+	}
+
+	carried := 0
+	for _, sector := range sectors {
+		existing, err := uc.prioritizationRepo.GetBySectorAndYear(ctx, sector.ID, cycle.Year)
+		if err == nil {
+			if existing.Status == entities.PrioritizationStatusSubmitted {
+				if err := uc.reopenForCycle(ctx, cycle, existing); err != nil {
+					return carried, fmt.Errorf("erro ao reabrir priorização do setor %s: %w", sector.Name, err)
+				}
+			}
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return carried, err
+		}
+
+		ok, err := uc.carryForward(ctx, cycle, sector)
+		if err != nil {
+			return carried, fmt.Errorf("erro ao levar iniciativas do setor %s para o novo ciclo: %w", sector.Name, err)
+		}
+		if ok {
+			carried++
+		}
+	}
+
+	if err := uc.cycleRepo.MarkOpened(ctx, cycle.ID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return carried, err
+	}
+
+	return carried, nil
+}
+
+// reopenForCycle volta a priorização para rascunho na abertura do ciclo, registrando no histórico
+func (uc *PlanningCycleUseCaseImpl) reopenForCycle(ctx context.Context, cycle *entities.PlanningCycle, p *entities.InitiativePrioritization) error {
+	reason := fmt.Sprintf("Abertura do ciclo %s", cycle.Name)
+	if err := uc.prioritizationRepo.Reopen(ctx, p.ID, nil, reason); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	uc.recordVersion(ctx, p, entities.PrioritizationVersionSourceReopen, reason, cycle.CreatedByUserID)
+	return nil
+}
+
+// carryForward cria o rascunho do ano com as iniciativas da última priorização do setor que seguem elegíveis
+func (uc *PlanningCycleUseCaseImpl) carryForward(ctx context.Context, cycle *entities.PlanningCycle, sector *entities.Sector) (bool, error) {
+	previous, err := uc.prioritizationRepo.GetLatestBySectorBefore(ctx, sector.ID, cycle.Year)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var order []int64
+	for _, initiativeID := range previous.PriorityOrder {
+		initiative, err := uc.initiativeRepo.GetByID(ctx, initiativeID)
+		if err != nil || initiative.Sector != sector.Name || !entities.IsPrioritizationEligible(initiative.Status) {
+			continue
+		}
+		order = append(order, initiativeID)
+	}
+
+	if len(order) == 0 {
+		return false, nil
+	}
+
+	draft := &entities.InitiativePrioritization{
+		SectorID:        sector.ID,
+		Year:            cycle.Year,
+		PriorityOrder:   order,
+		IsLocked:        false,
+		Status:          entities.PrioritizationStatusDraft,
+		CreatedByUserID: cycle.CreatedByUserID,
+	}
+	if err := uc.prioritizationRepo.Create(ctx, draft); err != nil {
+		return false, err
+	}
+
+	reason := fmt.Sprintf("Iniciativas não concluídas da priorização de %d", previous.Year)
+	uc.recordVersion(ctx, draft, entities.PrioritizationVersionSourceCarryForward, reason, cycle.CreatedByUserID)
+
+	return true, nil
+}
+
+func (uc *PlanningCycleUseCaseImpl) recordVersion(ctx context.Context, p *entities.InitiativePrioritization, source, reason string, userID int64) {
+	version := &entities.PrioritizationVersion{
+		PrioritizationID: p.ID,
+		PriorityOrder:    p.PriorityOrder,
+		UnrankedIDs:      p.UnrankedIDs,
+		Source:           source,
+		Reason:           reason,
+		CreatedByUserID:  userID,
+	}
+	if err := uc.prioritizationRepo.CreateVersion(ctx, version); err != nil {
+		// Log do erro mas não falha a operação
+		fmt.Printf("Erro ao registrar versão da priorização: %v\n", err)
+	}
+}
+
+// closeCycle envia (bloqueando) os rascunhos válidos do ano dos participantes, retorna os inválidos e marca o ciclo como encerrado
+func (uc *PlanningCycleUseCaseImpl) closeCycle(ctx context.Context, cycle *entities.PlanningCycle) (int, []*entities.PlanningCycleInvalidDraft, error) {
+	prioritizations, err := uc.prioritizationRepo.GetAllByYear(ctx, cycle.Year)
+	if err != nil {
+		return 0, nil, fmt.Errorf("erro ao buscar priorizações: %w", err)
+	}
+
+	locked := 0
+	var invalid []*entities.PlanningCycleInvalidDraft
+	for _, p := range prioritizations {
+		if p.Status != entities.PrioritizationStatusDraft || !cycle.IncludesSector(p.SectorID) {
+			continue
+		}
+
+		// Mesma validação do envio manual: rascunhos incompletos ficam sem envio e são reportados
+		err := validatePriorityOrder(ctx, uc.initiativeRepo, uc.sectorRepo, p.SectorID, p.PriorityOrder, p.UnrankedIDs, true)
+		var orderErr *entities.PriorityOrderValidationError
+		if errors.As(err, &orderErr) {
+			fmt.Printf("Priorização do setor %s não enviada no encerramento do ciclo %d: %v\n", p.SectorName, cycle.ID, err)
+			invalid = append(invalid, &entities.PlanningCycleInvalidDraft{
+				SectorID:         p.SectorID,
+				SectorName:       p.SectorName,
+				PrioritizationID: p.ID,
+				Issues:           orderErr.Issues,
+			})
+			continue
+		}
+		if err != nil {
+			return locked, invalid, err
+		}
+
+		if err := uc.prioritizationRepo.Submit(ctx, p.ID, nil); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return locked, invalid, fmt.Errorf("erro ao bloquear priorização do setor %s: %w", p.SectorName, err)
+		}
+		uc.recordVersion(ctx, p, entities.PrioritizationVersionSourceSubmit, fmt.Sprintf("Encerramento do ciclo %s", cycle.Name), cycle.CreatedByUserID)
+		locked++
+	}
+
+	if err := uc.cycleRepo.MarkClosed(ctx, cycle.ID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return locked, invalid, err
+	}
+
+	return locked, invalid, nil
+}
+
+func (uc *PlanningCycleUseCaseImpl) buildOverview(ctx context.Context, cycle *entities.PlanningCycle) (*entities.PlanningCycleOverview, error) {
+	sectors, err := uc.participants(ctx, cycle)
+	if err != nil {
+		return nil, err
+	}
+
+	prioritizations, err := uc.prioritizationRepo.GetAllByYear(ctx, cycle.Year)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar priorizações: %w", err)
+	}
+
+	bySector := make(map[int64]*entities.InitiativePrioritization, len(prioritizations))
+	for _, p := range prioritizations {
+		bySector[p.SectorID] = p
+	}
+
+	overview := &entities.PlanningCycleOverview{
+		Cycle:          cycle,
+		Sectors:        []*entities.PlanningCycleSectorStatus{},
+		PendingSectors: []string{},
+	}
+
+	for _, sector := range sectors {
+		status := &entities.PlanningCycleSectorStatus{
+			SectorID:   sector.ID,
+			SectorName: sector.Name,
+		}
+
+		if p, ok := bySector[sector.ID]; ok {
+			status.PrioritizationID = &p.ID
+			status.Status = p.Status
+			status.Submitted = p.Status == entities.PrioritizationStatusSubmitted
+			status.UpdatedAt = &p.UpdatedAt
+		}
+
+		if status.Submitted {
+			overview.SubmittedCount++
+		} else {
+			overview.PendingSectors = append(overview.PendingSectors, sector.Name)
+		}
+
+		overview.Sectors = append(overview.Sectors, status)
+	}
+
+	return overview, nil
+}
+
+// participants retorna os setores do ciclo (todos os ativos quando a lista está vazia)
+func (uc *PlanningCycleUseCaseImpl) participants(ctx context.Context, cycle *entities.PlanningCycle) ([]*entities.Sector, error) {
+	if len(cycle.SectorIDs) == 0 {
+		sectors, err := uc.sectorRepo.ListAll(ctx, true)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao buscar setores: %w", err)
+		}
+		return sectors, nil
+	}
+
+	var sectors []*entities.Sector
+	for _, sectorID := range cycle.SectorIDs {
+		sector, err := uc.sectorRepo.GetByID(ctx, sectorID)
+		if err != nil {
+			continue // Setor removido depois do cadastro do ciclo
+		}
+		sectors = append(sectors, sector)
+	}
+
+	return sectors, nil
+}
+
+// validateCycle confere a janela, os setores e a sobreposição com outros ciclos do ano
+func (uc *PlanningCycleUseCaseImpl) validateCycle(ctx context.Context, cycle *entities.PlanningCycle) error {
+	if cycle.OpensAt.IsZero() || cycle.ClosesAt.IsZero() {
+		return errors.New("informe a abertura e o encerramento do ciclo")
+	}
+
+	if !cycle.ClosesAt.After(cycle.OpensAt) {
+		return errors.New("o encerramento deve ser posterior à abertura")
+	}
+
+	seen := make(map[int64]bool)
+	for _, sectorID := range cycle.SectorIDs {
+		if seen[sectorID] {
+			return fmt.Errorf("setor %d informado mais de uma vez", sectorID)
+		}
+		seen[sectorID] = true
+
+		if _, err := uc.sectorRepo.GetByID(ctx, sectorID); err != nil {
+			return fmt.Errorf("setor %d não encontrado", sectorID)
+		}
+	}
+
+	overlap, err := uc.cycleRepo.HasOverlap(ctx, cycle.Year, cycle.OpensAt, cycle.ClosesAt, cycle.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao verificar ciclos do ano: %w", err)
+	}
+	if overlap {
+		return fmt.Errorf("já existe um ciclo de %d com janela sobreposta", cycle.Year)
+	}
+
+	return nil
+}
+
+func (uc *PlanningCycleUseCaseImpl) getCycle(ctx context.Context, cycleID int64) (*entities.PlanningCycle, error) {
+	cycle, err := uc.cycleRepo.GetByID(ctx, cycleID)
+	if err != nil {
+		return nil, errors.New("ciclo de planejamento não encontrado")
+	}
+
+	cycle.Status = cycle.StatusAt(time.Now())
+	return cycle, nil
+}
+
+func (uc *PlanningCycleUseCaseImpl) hasUserType(ctx context.Context, userID int64, names ...string) (bool, error) {
+	userTypes, err := uc.permRepo.GetUserTypes(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, userType := range userTypes {
+		if slices.Contains(names, userType.Name) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
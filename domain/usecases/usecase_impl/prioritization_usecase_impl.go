@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"hackathon-backend/domain/entities"
 	"hackathon-backend/infrastructure/repositories"
+	"hackathon-backend/settings_loader"
+	"slices"
+	"sort"
 	"time"
 )
 
@@ -16,6 +19,9 @@ type PrioritizationUseCaseImpl struct {
 	authRepo           repositories.AuthRepository
 	permRepo           repositories.PermissionRepository
 	sectorRepo         repositories.SectorRepository // NOVO
+	cycleRepo          repositories.PlanningCycleRepository
+	notificationRepo   repositories.NotificationRepository
+	settings           *settings_loader.SettingsLoader
 }
 
 func NewPrioritizationUseCaseImpl(
@@ -24,6 +30,9 @@ func NewPrioritizationUseCaseImpl(
 	authRepo repositories.AuthRepository,
 	permRepo repositories.PermissionRepository,
 	sectorRepo repositories.SectorRepository, // NOVO
+	cycleRepo repositories.PlanningCycleRepository,
+	notificationRepo repositories.NotificationRepository,
+	settings *settings_loader.SettingsLoader,
 ) *PrioritizationUseCaseImpl {
 	return &PrioritizationUseCaseImpl{
 		prioritizationRepo: prioritizationRepo,
@@ -31,11 +40,56 @@ func NewPrioritizationUseCaseImpl(
 		authRepo:           authRepo,
 		permRepo:           permRepo,
 		sectorRepo:         sectorRepo, // NOVO
+		cycleRepo:          cycleRepo,
+		notificationRepo:   notificationRepo,
+		settings:           settings,
 	}
 }
 
-// SavePrioritization salva a priorização do setor do usuário
+// SavePrioritization salva e envia a priorização do setor do usuário em uma única chamada
 func (uc *PrioritizationUseCaseImpl) SavePrioritization(ctx context.Context, req *entities.SavePrioritizationRequest, userID int64) (*entities.PrioritizationWithInitiatives, error) {
+	return uc.savePrioritization(ctx, req, userID, true)
+}
+
+// SaveDraft salva a priorização como rascunho: não bloqueia e só o setor a vê
+func (uc *PrioritizationUseCaseImpl) SaveDraft(ctx context.Context, req *entities.SavePrioritizationRequest, userID int64) (*entities.PrioritizationWithInitiatives, error) {
+	return uc.savePrioritization(ctx, req, userID, false)
+}
+
+// SubmitPrioritization envia o rascunho do ano (ou a ordem informada), bloqueando-o e notificando os gerentes
+func (uc *PrioritizationUseCaseImpl) SubmitPrioritization(ctx context.Context, req *entities.SavePrioritizationRequest, userID int64) (*entities.PrioritizationWithInitiatives, error) {
+	if len(req.PriorityOrder) > 0 {
+		return uc.savePrioritization(ctx, req, userID, true)
+	}
+
+	user, err := uc.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("usuário não encontrado")
+	}
+
+	if user.SectorID == nil {
+		return nil, errors.New("usuário não está vinculado a um setor")
+	}
+
+	draft, err := uc.prioritizationRepo.GetBySectorAndYear(ctx, *user.SectorID, req.Year)
+	if err != nil {
+		return nil, errors.New("nenhum rascunho de priorização para enviar neste ano")
+	}
+
+	if draft.Status != entities.PrioritizationStatusDraft {
+		return nil, errors.New("priorização já foi enviada")
+	}
+
+	return uc.savePrioritization(ctx, &entities.SavePrioritizationRequest{
+		Year:          req.Year,
+		PriorityOrder: draft.PriorityOrder,
+		UnrankedIDs:   draft.UnrankedIDs,
+		Reason:        req.Reason,
+	}, userID, true)
+}
+
+// savePrioritization grava a ordem do setor do usuário como rascunho e, se submit, envia (bloqueia)
+func (uc *PrioritizationUseCaseImpl) savePrioritization(ctx context.Context, req *entities.SavePrioritizationRequest, userID int64, submit bool) (*entities.PrioritizationWithInitiatives, error) {
 	// Validações
 	if req.Year < 2020 || req.Year > 2100 {
 		return nil, errors.New("ano inválido")
@@ -55,48 +109,183 @@ func (uc *PrioritizationUseCaseImpl) SavePrioritization(ctx context.Context, req
 		return nil, errors.New("usuário não está vinculado a um setor")
 	}
 
+	if err := uc.checkPlanningWindow(ctx, *user.SectorID, req.Year); err != nil {
+		return nil, err
+	}
+
+	// Rascunhos podem estar incompletos; o envio exige todas as elegíveis
+	if err := uc.validatePriorityOrder(ctx, *user.SectorID, req.PriorityOrder, req.UnrankedIDs, submit); err != nil {
+		return nil, err
+	}
+
 	// Verificar se já existe priorização para este setor/ano
-	existing, err := uc.prioritizationRepo.GetBySectorAndYear(ctx, *user.SectorID, req.Year)
+	prioritization, err := uc.prioritizationRepo.GetBySectorAndYear(ctx, *user.SectorID, req.Year)
+	draftChanged := false
 
-	isAdminOrManager, _ := uc.isAdminOrManager(ctx, userID)
+	switch {
+	case err == nil && prioritization.Status == entities.PrioritizationStatusSubmitted:
+		if !submit {
+			return nil, errors.New("priorização já foi enviada. Solicite uma mudança ou peça a reabertura a um gestor")
+		}
 
-	if err == nil {
-		// Já existe priorização
-		if existing.IsLocked && !isAdminOrManager {
+		// Admin/manager podem alterar a ordem enviada diretamente
+		isAdminOrManager, _ := uc.isAdminOrManager(ctx, userID)
+		if !isAdminOrManager {
 			return nil, errors.New("priorização já está bloqueada.  Solicite aprovação para alterá-la")
 		}
 
-		// Atualizar priorização existente
-		existing.PriorityOrder = req.PriorityOrder
-		existing.IsLocked = true // Bloquear ao salvar
+		previousOrder := prioritization.PriorityOrder
+		prioritization.PriorityOrder = req.PriorityOrder
+		prioritization.UnrankedIDs = req.UnrankedIDs
 
-		if err := uc.prioritizationRepo.Update(ctx, existing); err != nil {
+		if err := uc.prioritizationRepo.Update(ctx, prioritization); err != nil {
 			return nil, fmt.Errorf("erro ao atualizar priorização: %w", err)
 		}
 
-		return uc.buildPrioritizationWithInitiatives(ctx, existing)
-	}
+		if !slices.Equal(previousOrder, prioritization.PriorityOrder) {
+			uc.recordVersion(ctx, prioritization, entities.PrioritizationVersionSourceSave, req.Reason, userID, nil)
+		}
 
-	// Criar nova priorização
-	prioritization := &entities.InitiativePrioritization{
-		SectorID:        *user.SectorID,
-		Year:            req.Year,
-		PriorityOrder:   req.PriorityOrder,
-		IsLocked:        true, // Bloquear ao salvar
-		CreatedByUserID: userID,
+		return uc.buildPrioritizationWithInitiatives(ctx, prioritization)
+
+	case err == nil:
+		// Atualizar rascunho existente
+		draftChanged = !slices.Equal(prioritization.PriorityOrder, req.PriorityOrder) ||
+			!slices.Equal(prioritization.UnrankedIDs, req.UnrankedIDs)
+		prioritization.PriorityOrder = req.PriorityOrder
+		prioritization.UnrankedIDs = req.UnrankedIDs
+		prioritization.IsLocked = false
+
+		if err := uc.prioritizationRepo.Update(ctx, prioritization); err != nil {
+			return nil, fmt.Errorf("erro ao atualizar priorização: %w", err)
+		}
+
+	case errors.Is(err, sql.ErrNoRows):
+		// Criar nova priorização como rascunho
+		prioritization = &entities.InitiativePrioritization{
+			SectorID:        *user.SectorID,
+			Year:            req.Year,
+			PriorityOrder:   req.PriorityOrder,
+			UnrankedIDs:     req.UnrankedIDs,
+			IsLocked:        false,
+			Status:          entities.PrioritizationStatusDraft,
+			CreatedByUserID: userID,
+		}
+
+		if err := uc.prioritizationRepo.Create(ctx, prioritization); err != nil {
+			return nil, fmt.Errorf("erro ao criar priorização: %w", err)
+		}
+		draftChanged = true
+
+	default:
+		return nil, fmt.Errorf("erro ao buscar priorização: %w", err)
 	}
 
-	if err := uc.prioritizationRepo.Create(ctx, prioritization); err != nil {
-		return nil, fmt.Errorf("erro ao criar priorização: %w", err)
+	if submit {
+		if err := uc.prioritizationRepo.Submit(ctx, prioritization.ID, &userID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errors.New("priorização já foi enviada")
+			}
+			return nil, fmt.Errorf("erro ao enviar priorização: %w", err)
+		}
+
+		uc.recordVersion(ctx, prioritization, entities.PrioritizationVersionSourceSubmit, req.Reason, userID, nil)
+		uc.notifyManagers(ctx, prioritization, userID)
+	} else if draftChanged {
+		// Rascunhos também entram no histórico, para poder voltar a uma ordem anterior
+		uc.recordVersion(ctx, prioritization, entities.PrioritizationVersionSourceDraft, req.Reason, userID, nil)
 	}
 
 	// Buscar novamente para pegar os dados completos
-	created, err := uc.prioritizationRepo.GetBySectorAndYear(ctx, *user.SectorID, req.Year)
+	saved, err := uc.prioritizationRepo.GetByID(ctx, prioritization.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	return uc.buildPrioritizationWithInitiatives(ctx, created)
+	return uc.buildPrioritizationWithInitiatives(ctx, saved)
+}
+
+// ReopenPrioritization volta uma priorização enviada para rascunho (admin/manager), com justificativa
+func (uc *PrioritizationUseCaseImpl) ReopenPrioritization(ctx context.Context, prioritizationID int64, req *entities.ReopenPrioritizationRequest, userID int64) (*entities.PrioritizationWithInitiatives, error) {
+	isAdminOrManager, err := uc.isAdminOrManager(ctx, userID)
+	if err != nil || !isAdminOrManager {
+		return nil, errors.New("apenas administradores e gerentes podem reabrir priorizações")
+	}
+
+	if len(req.Reason) < 10 {
+		return nil, errors.New("motivo deve ter no mínimo 10 caracteres")
+	}
+
+	prioritization, err := uc.prioritizationRepo.GetByID(ctx, prioritizationID)
+	if err != nil {
+		return nil, errors.New("priorização não encontrada")
+	}
+
+	if prioritization.Status != entities.PrioritizationStatusSubmitted {
+		return nil, errors.New("apenas priorizações enviadas podem ser reabertas")
+	}
+
+	// A aplicação de uma mudança pendente bloquearia o rascunho novamente
+	if hasPending, err := uc.prioritizationRepo.HasPendingChangeRequest(ctx, prioritizationID); err == nil && hasPending {
+		return nil, errors.New("existe uma solicitação de mudança pendente. Revise-a antes de reabrir a priorização")
+	}
+
+	if err := uc.prioritizationRepo.Reopen(ctx, prioritizationID, &userID, req.Reason); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("apenas priorizações enviadas podem ser reabertas")
+		}
+		return nil, fmt.Errorf("erro ao reabrir priorização: %w", err)
+	}
+
+	uc.recordVersion(ctx, prioritization, entities.PrioritizationVersionSourceReopen, req.Reason, userID, nil)
+
+	recipient := prioritization.CreatedByUserID
+	if prioritization.SubmittedByUserID != nil {
+		recipient = *prioritization.SubmittedByUserID
+	}
+	if recipient != userID {
+		notify(ctx, uc.notificationRepo, &entities.Notification{
+			UserID:  recipient,
+			Type:    entities.NotificationTypePrioritizationReopened,
+			Title:   "Priorização reaberta",
+			Message: fmt.Sprintf("A priorização de %d do setor %s voltou para rascunho: %s", prioritization.Year, prioritization.SectorName, req.Reason),
+		})
+	}
+
+	reopened, err := uc.prioritizationRepo.GetByID(ctx, prioritizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.buildPrioritizationWithInitiatives(ctx, reopened)
+}
+
+// notifyManagers avisa os gerentes que o setor enviou a priorização
+func (uc *PrioritizationUseCaseImpl) notifyManagers(ctx context.Context, prioritization *entities.InitiativePrioritization, submittedBy int64) {
+	managerIDs, err := uc.permRepo.ListUserIDsByType(ctx, "manager")
+	if err != nil {
+		fmt.Printf("Erro ao buscar gerentes: %v\n", err)
+		return
+	}
+
+	sectorName := prioritization.SectorName
+	if sectorName == "" {
+		if sector, err := uc.sectorRepo.GetByID(ctx, prioritization.SectorID); err == nil {
+			sectorName = sector.Name
+		}
+	}
+
+	for _, id := range managerIDs {
+		if id == submittedBy {
+			continue
+		}
+		notify(ctx, uc.notificationRepo, &entities.Notification{
+			UserID:  id,
+			Type:    entities.NotificationTypePrioritizationSubmitted,
+			Title:   "Priorização enviada",
+			Message: fmt.Sprintf("O setor %s enviou a priorização de %d", sectorName, prioritization.Year),
+		})
+	}
 }
 
 // GetPrioritization busca a priorização do setor do usuário
@@ -146,6 +335,24 @@ func (uc *PrioritizationUseCaseImpl) GetAllSectorsPrioritization(ctx context.Con
 	// Construir response com iniciativas de cada setor
 	var sectors []*entities.PrioritizationWithInitiatives
 	for _, p := range prioritizations {
+		// Rascunhos só são visíveis para o próprio setor
+		if p.Status == entities.PrioritizationStatusDraft {
+			sectors = append(sectors, &entities.PrioritizationWithInitiatives{
+				ID:              p.ID,
+				SectorID:        p.SectorID,
+				SectorName:      p.SectorName,
+				Year:            p.Year,
+				IsLocked:        p.IsLocked,
+				Status:          p.Status,
+				Initiatives:     []*entities.InitiativeListResponse{},
+				CreatedByUserID: p.CreatedByUserID,
+				CreatedByName:   p.CreatedByName,
+				CreatedAt:       p.CreatedAt.Format("2006-01-02 15:04:05"),
+				UpdatedAt:       p.UpdatedAt.Format("2006-01-02 15:04:05"),
+			})
+			continue
+		}
+
 		withInitiatives, err := uc.buildPrioritizationWithInitiatives(ctx, p)
 		if err != nil {
 			continue // Skip em caso de erro
@@ -188,19 +395,25 @@ func (uc *PrioritizationUseCaseImpl) buildEmptyPrioritizationForAllSectors(ctx c
 				initiative.Status == entities.StatusInAnalysis {
 
 				initiativesList = append(initiativesList, &entities.InitiativeListResponse{
-					ID:          initiative.ID,
-					Title:       initiative.Title,
-					Description: initiative.Description,
-					Status:      initiative.Status,
-					Type:        initiative.Type,
-					Priority:    initiative.Priority,
-					Sector:      initiative.Sector,
-					OwnerName:   initiative.OwnerName,
-					Date:        formatDate(initiative.CreatedAt),
+					ID:            initiative.ID,
+					Title:         initiative.Title,
+					Description:   listDescription(initiative.Description),
+					Status:        initiative.Status,
+					Type:          initiative.Type,
+					Priority:      initiative.Priority,
+					Score:         initiative.Score,
+					EffortDays:    initiative.EffortDays,
+					EstimatedCost: initiative.EstimatedCost,
+					RequiredSkill: initiative.RequiredSkill,
+					Sector:        initiative.Sector,
+					OwnerName:     initiative.OwnerName,
+					Date:          formatDate(initiative.CreatedAt),
 				})
 			}
 		}
 
+		uc.sortEmptyPrioritization(initiativesList)
+
 		sectorsWithInitiatives = append(sectorsWithInitiatives, &entities.PrioritizationWithInitiatives{
 			ID:          0, // Não existe ainda
 			SectorID:    sector.ID,
@@ -210,6 +423,7 @@ func (uc *PrioritizationUseCaseImpl) buildEmptyPrioritizationForAllSectors(ctx c
 			Initiatives: initiativesList,
 			CreatedAt:   time.Now().Format("2006-01-02 15:04:05"),
 			UpdatedAt:   time.Now().Format("2006-01-02 15:04:05"),
+			Capacity:    uc.sectorCapacity(ctx, sector.ID, year, initiativesList),
 		})
 	}
 
@@ -246,19 +460,25 @@ func (uc *PrioritizationUseCaseImpl) buildEmptyPrioritization(ctx context.Contex
 			initiative.Status == entities.StatusInAnalysis {
 
 			initiativesList = append(initiativesList, &entities.InitiativeListResponse{
-				ID:          initiative.ID,
-				Title:       initiative.Title,
-				Description: initiative.Description,
-				Status:      initiative.Status,
-				Type:        initiative.Type,
-				Priority:    initiative.Priority,
-				Sector:      initiative.Sector,
-				OwnerName:   initiative.OwnerName,
-				Date:        formatDate(initiative.CreatedAt),
+				ID:            initiative.ID,
+				Title:         initiative.Title,
+				Description:   listDescription(initiative.Description),
+				Status:        initiative.Status,
+				Type:          initiative.Type,
+				Priority:      initiative.Priority,
+				Score:         initiative.Score,
+				EffortDays:    initiative.EffortDays,
+				EstimatedCost: initiative.EstimatedCost,
+				RequiredSkill: initiative.RequiredSkill,
+				Sector:        initiative.Sector,
+				OwnerName:     initiative.OwnerName,
+				Date:          formatDate(initiative.CreatedAt),
 			})
 		}
 	}
 
+	uc.sortEmptyPrioritization(initiativesList)
+
 	return &entities.PrioritizationWithInitiatives{
 		ID:          0, // Não existe ainda
 		SectorID:    sectorID,
@@ -268,9 +488,25 @@ func (uc *PrioritizationUseCaseImpl) buildEmptyPrioritization(ctx context.Contex
 		Initiatives: initiativesList,
 		CreatedAt:   time.Now().Format("2006-01-02 15:04:05"),
 		UpdatedAt:   time.Now().Format("2006-01-02 15:04:05"),
+		Capacity:    uc.sectorCapacity(ctx, sectorID, year, initiativesList),
 	}, nil
 }
 
+// sortEmptyPrioritization ordena a sugestão inicial pela pontuação, se configurado (sem pontuação vão para o fim)
+func (uc *PrioritizationUseCaseImpl) sortEmptyPrioritization(initiatives []*entities.InitiativeListResponse) {
+	if uc.settings.Prioritization.EmptyOrderBy != settings_loader.EmptyOrderByScore {
+		return
+	}
+
+	sort.SliceStable(initiatives, func(i, j int) bool {
+		a, b := initiatives[i].Score, initiatives[j].Score
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return *a > *b
+	})
+}
+
 // RequestPrioritizationChange solicita mudança na priorização
 func (uc *PrioritizationUseCaseImpl) RequestPrioritizationChange(ctx context.Context, req *entities.RequestPrioritizationChangeRequest, userID int64, year int) (*entities.PrioritizationChangeRequest, error) {
 	// Validações
@@ -292,12 +528,20 @@ func (uc *PrioritizationUseCaseImpl) RequestPrioritizationChange(ctx context.Con
 		return nil, errors.New("usuário não está vinculado a um setor")
 	}
 
+	if err := uc.validatePriorityOrder(ctx, *user.SectorID, req.NewPriorityOrder, req.NewUnrankedIDs, true); err != nil {
+		return nil, err
+	}
+
 	// Buscar priorização existente
 	prioritization, err := uc.prioritizationRepo.GetBySectorAndYear(ctx, *user.SectorID, year)
 	if err != nil {
 		return nil, errors.New("priorização não encontrada")
 	}
 
+	if prioritization.Status == entities.PrioritizationStatusDraft {
+		return nil, errors.New("priorização ainda é um rascunho. Edite e envie diretamente")
+	}
+
 	// Verificar se já tem solicitação pendente
 	hasPending, err := uc.prioritizationRepo.HasPendingChangeRequest(ctx, prioritization.ID)
 	if err == nil && hasPending {
@@ -309,6 +553,8 @@ func (uc *PrioritizationUseCaseImpl) RequestPrioritizationChange(ctx context.Con
 		PrioritizationID:  prioritization.ID,
 		RequestedByUserID: userID,
 		NewPriorityOrder:  req.NewPriorityOrder,
+		NewUnrankedIDs:    req.NewUnrankedIDs,
+		BaseOrder:         prioritization.PriorityOrder,
 		Reason:            req.Reason,
 		Status:            entities.PrioritizationChangeStatusPending,
 	}
@@ -344,27 +590,83 @@ func (uc *PrioritizationUseCaseImpl) ReviewPrioritizationChange(ctx context.Cont
 		return errors.New("esta solicitação já foi revisada")
 	}
 
-	// Definir novo status
-	newStatus := entities.PrioritizationChangeStatusRejected
-	if req.Approved {
-		newStatus = entities.PrioritizationChangeStatusApproved
+	if !req.Approved {
+		if err := uc.prioritizationRepo.UpdateChangeRequestStatus(ctx, requestID, entities.PrioritizationChangeStatusRejected, userID, req.Reason); err != nil {
+			return fmt.Errorf("erro ao atualizar solicitação: %w", err)
+		}
+		return nil
 	}
 
-	// Atualizar status da solicitação
-	if err := uc.prioritizationRepo.UpdateChangeRequestStatus(ctx, requestID, newStatus, userID, req.Reason); err != nil {
-		return fmt.Errorf("erro ao atualizar solicitação: %w", err)
+	// Aprovada: a nova ordem é aplicada e a priorização volta a ficar bloqueada
+	conflict, err := uc.prioritizationRepo.ApplyChangeRequest(ctx, requestID, userID, req.Reason, req.Force)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("esta solicitação já foi revisada")
+		}
+		var invalid *entities.PriorityOrderValidationError
+		if errors.As(err, &invalid) {
+			return err
+		}
+		return fmt.Errorf("erro ao aplicar solicitação: %w", err)
 	}
 
-	// Se aprovado, desbloquear a priorização para permitir alteração
-	if req.Approved {
-		if err := uc.prioritizationRepo.UnlockPrioritization(ctx, changeRequest.PrioritizationID); err != nil {
-			return fmt.Errorf("erro ao desbloquear priorização:  %w", err)
-		}
+	if conflict != nil {
+		conflict.Positions = priorityPositionDiffs(conflict.BaseOrder, conflict.CurrentOrder, conflict.ProposedOrder)
+		return &entities.PrioritizationConflictError{Conflict: conflict}
 	}
 
 	return nil
 }
 
+// priorityPositionDiffs compara a posição de cada iniciativa nas três ordens
+func priorityPositionDiffs(base, current, proposed []int64) []*entities.PriorityPositionDiff {
+	positions := func(order []int64) map[int64]int {
+		result := make(map[int64]int, len(order))
+		for i, id := range order {
+			result[id] = i + 1
+		}
+		return result
+	}
+	basePos, currentPos, proposedPos := positions(base), positions(current), positions(proposed)
+
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, order := range [][]int64{proposed, current, base} {
+		for _, id := range order {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	lookup := func(m map[int64]int, id int64) *int {
+		if pos, ok := m[id]; ok {
+			return &pos
+		}
+		return nil
+	}
+	equal := func(a, b *int) bool {
+		return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+	}
+
+	var diffs []*entities.PriorityPositionDiff
+	for _, id := range ids {
+		diff := &entities.PriorityPositionDiff{
+			InitiativeID:     id,
+			BasePosition:     lookup(basePos, id),
+			CurrentPosition:  lookup(currentPos, id),
+			ProposedPosition: lookup(proposedPos, id),
+		}
+		diff.Conflict = !equal(diff.CurrentPosition, diff.BasePosition) &&
+			!equal(diff.ProposedPosition, diff.BasePosition) &&
+			!equal(diff.CurrentPosition, diff.ProposedPosition)
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
 // ListPendingChangeRequests lista solicitações pendentes
 func (uc *PrioritizationUseCaseImpl) ListPendingChangeRequests(ctx context.Context, userID int64) ([]*entities.PrioritizationChangeRequest, error) {
 	// Verificar se é admin ou manager
@@ -373,7 +675,328 @@ func (uc *PrioritizationUseCaseImpl) ListPendingChangeRequests(ctx context.Conte
 		return nil, errors.New("apenas administradores e gerentes podem visualizar solicitações")
 	}
 
-	return uc.prioritizationRepo.ListPendingChangeRequests(ctx)
+	requests, err := uc.prioritizationRepo.ListPendingChangeRequests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, req := range requests {
+		req.AgeHours, req.Overdue = pendingAge(uc.settings, entities.ReviewItemPrioritizationChange, req.CreatedAt)
+	}
+
+	return requests, nil
+}
+
+// checkPlanningWindow exige um ciclo aberto que inclua o setor quando o ano tem ciclos de planejamento.
+// Anos sem ciclos cadastrados continuam sem janela
+func (uc *PrioritizationUseCaseImpl) checkPlanningWindow(ctx context.Context, sectorID int64, year int) error {
+	cycles, err := uc.cycleRepo.List(ctx, year)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar ciclos de planejamento: %w", err)
+	}
+
+	if len(cycles) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	var next *entities.PlanningCycle
+	for _, cycle := range cycles {
+		if !cycle.IncludesSector(sectorID) {
+			continue
+		}
+
+		switch cycle.StatusAt(now) {
+		case entities.PlanningCycleOpen:
+			return nil
+		case entities.PlanningCycleScheduled:
+			if next == nil || cycle.OpensAt.Before(next.OpensAt) {
+				next = cycle
+			}
+		}
+	}
+
+	return &entities.PlanningWindowError{Year: year, Cycle: next}
+}
+
+func (uc *PrioritizationUseCaseImpl) validatePriorityOrder(ctx context.Context, sectorID int64, order, unranked []int64, requireComplete bool) error {
+	return validatePriorityOrder(ctx, uc.initiativeRepo, uc.sectorRepo, sectorID, order, unranked, requireComplete)
+}
+
+// validatePriorityOrder exige que cada ID seja uma iniciativa elegível do setor, sem repetição,
+// e (se requireComplete) que todas as elegíveis estejam no ranking ou marcadas como não ranqueadas.
+// Também usada no encerramento dos ciclos de planejamento
+func validatePriorityOrder(ctx context.Context, initiativeRepo repositories.InitiativeRepository, sectorRepo repositories.SectorRepository, sectorID int64, order, unranked []int64, requireComplete bool) error {
+	sector, err := sectorRepo.GetByID(ctx, sectorID)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar setor: %w", err)
+	}
+
+	initiatives, err := initiativeRepo.ListAllWithCancellation(ctx, &entities.InitiativeFilter{Sector: sector.Name})
+	if err != nil {
+		return fmt.Errorf("erro ao buscar iniciativas do setor: %w", err)
+	}
+
+	eligible := make(map[int64]bool)
+	var eligibleIDs []int64
+	for _, initiative := range initiatives {
+		if entities.IsPrioritizationEligible(initiative.Status) {
+			eligible[initiative.ID] = true
+			eligibleIDs = append(eligibleIDs, initiative.ID)
+		}
+	}
+
+	var issues []*entities.PriorityOrderIssue
+	addIssue := func(id int64, reason, message string) {
+		issues = append(issues, &entities.PriorityOrderIssue{InitiativeID: id, Reason: reason, Message: message})
+	}
+
+	ranked := make(map[int64]bool)
+	seen := make(map[int64]bool)
+	check := func(id int64, isRanked bool) {
+		if seen[id] {
+			if ranked[id] != isRanked {
+				addIssue(id, entities.PriorityIssueRankedAndUnranked, "iniciativa está no ranking e também marcada como não ranqueada")
+			} else {
+				addIssue(id, entities.PriorityIssueDuplicated, "iniciativa aparece mais de uma vez")
+			}
+			return
+		}
+		seen[id] = true
+		ranked[id] = isRanked
+
+		if eligible[id] {
+			return
+		}
+
+		initiative, err := initiativeRepo.GetByID(ctx, id)
+		switch {
+		case err != nil:
+			addIssue(id, entities.PriorityIssueNotFound, "iniciativa não encontrada")
+		case initiative.Sector != sector.Name:
+			addIssue(id, entities.PriorityIssueOtherSector, fmt.Sprintf("iniciativa pertence ao setor %s", initiative.Sector))
+		default:
+			addIssue(id, entities.PriorityIssueIneligibleStatus, fmt.Sprintf("status %s não pode ser priorizado", initiative.Status))
+		}
+	}
+
+	for _, id := range order {
+		check(id, true)
+	}
+	for _, id := range unranked {
+		check(id, false)
+	}
+
+	for _, id := range eligibleIDs {
+		if requireComplete && !seen[id] {
+			addIssue(id, entities.PriorityIssueMissing, "iniciativa elegível não está no ranking nem marcada como não ranqueada")
+		}
+	}
+
+	if len(issues) > 0 {
+		return &entities.PriorityOrderValidationError{Issues: issues}
+	}
+
+	return nil
+}
+
+// ListVersions lista as versões da ordem de prioridade (usuários: apenas do próprio setor)
+func (uc *PrioritizationUseCaseImpl) ListVersions(ctx context.Context, prioritizationID int64, userID int64) ([]*entities.PrioritizationVersion, error) {
+	if _, err := uc.getAccessiblePrioritization(ctx, prioritizationID, userID); err != nil {
+		return nil, err
+	}
+
+	versions, err := uc.prioritizationRepo.ListVersions(ctx, prioritizationID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar versões: %w", err)
+	}
+
+	return versions, nil
+}
+
+// DiffVersions compara duas versões: quem subiu, desceu, entrou ou saiu da ordem
+func (uc *PrioritizationUseCaseImpl) DiffVersions(ctx context.Context, prioritizationID int64, fromVersion, toVersion int, userID int64) (*entities.PrioritizationVersionDiff, error) {
+	if _, err := uc.getAccessiblePrioritization(ctx, prioritizationID, userID); err != nil {
+		return nil, err
+	}
+
+	from, err := uc.prioritizationRepo.GetVersion(ctx, prioritizationID, fromVersion)
+	if err != nil {
+		return nil, fmt.Errorf("versão %d não encontrada", fromVersion)
+	}
+
+	to, err := uc.prioritizationRepo.GetVersion(ctx, prioritizationID, toVersion)
+	if err != nil {
+		return nil, fmt.Errorf("versão %d não encontrada", toVersion)
+	}
+
+	diff := &entities.PrioritizationVersionDiff{
+		PrioritizationID: prioritizationID,
+		FromVersion:      fromVersion,
+		ToVersion:        toVersion,
+		Moves:            priorityMoves(from.PriorityOrder, to.PriorityOrder),
+	}
+
+	for _, move := range diff.Moves {
+		if initiative, err := uc.initiativeRepo.GetByID(ctx, move.InitiativeID); err == nil {
+			move.InitiativeTitle = initiative.Title
+		}
+
+		switch move.Move {
+		case entities.PriorityMoveUp:
+			diff.MovedUp++
+		case entities.PriorityMoveDown:
+			diff.MovedDown++
+		case entities.PriorityMoveAdded:
+			diff.Added++
+		case entities.PriorityMoveRemoved:
+			diff.Removed++
+		}
+	}
+
+	return diff, nil
+}
+
+// RollbackVersion volta a ordem de prioridade para uma versão anterior (apenas admin), gerando uma nova versão
+func (uc *PrioritizationUseCaseImpl) RollbackVersion(ctx context.Context, prioritizationID int64, version int, req *entities.RollbackPrioritizationRequest, userID int64) (*entities.PrioritizationWithInitiatives, error) {
+	isAdmin, err := uc.isAdmin(ctx, userID)
+	if err != nil || !isAdmin {
+		return nil, errors.New("apenas administradores podem reverter a priorização")
+	}
+
+	if len(req.Reason) < 5 {
+		return nil, errors.New("justificativa deve ter no mínimo 5 caracteres")
+	}
+
+	prioritization, err := uc.prioritizationRepo.GetByID(ctx, prioritizationID)
+	if err != nil {
+		return nil, errors.New("priorização não encontrada")
+	}
+
+	target, err := uc.prioritizationRepo.GetVersion(ctx, prioritizationID, version)
+	if err != nil {
+		return nil, fmt.Errorf("versão %d não encontrada", version)
+	}
+
+	// Versões antigas não guardam as não ranqueadas: mantém as atuais que não voltam para a ordem
+	unranked := target.UnrankedIDs
+	if unranked == nil {
+		for _, id := range prioritization.UnrankedIDs {
+			if !slices.Contains(target.PriorityOrder, id) {
+				unranked = append(unranked, id)
+			}
+		}
+	}
+
+	if slices.Equal(prioritization.PriorityOrder, target.PriorityOrder) && slices.Equal(prioritization.UnrankedIDs, unranked) {
+		return nil, errors.New("a ordem atual já é igual à desta versão")
+	}
+
+	// A ordem restaurada passa pelas mesmas regras de um salvamento (iniciativas que deixaram de ser elegíveis são rejeitadas)
+	requireComplete := prioritization.Status == entities.PrioritizationStatusSubmitted
+	if err := uc.validatePriorityOrder(ctx, prioritization.SectorID, target.PriorityOrder, unranked, requireComplete); err != nil {
+		return nil, err
+	}
+
+	prioritization.PriorityOrder = target.PriorityOrder
+	prioritization.UnrankedIDs = unranked
+	if err := uc.prioritizationRepo.Update(ctx, prioritization); err != nil {
+		return nil, fmt.Errorf("erro ao reverter priorização: %w", err)
+	}
+
+	uc.recordVersion(ctx, prioritization, entities.PrioritizationVersionSourceRollback, req.Reason, userID, &version)
+
+	return uc.buildPrioritizationWithInitiatives(ctx, prioritization)
+}
+
+// recordVersion grava uma nova versão da ordem de prioridade (com as não ranqueadas)
+func (uc *PrioritizationUseCaseImpl) recordVersion(ctx context.Context, p *entities.InitiativePrioritization, source, reason string, userID int64, restoredFrom *int) {
+	version := &entities.PrioritizationVersion{
+		PrioritizationID:    p.ID,
+		PriorityOrder:       p.PriorityOrder,
+		UnrankedIDs:         p.UnrankedIDs,
+		Source:              source,
+		Reason:              reason,
+		RestoredFromVersion: restoredFrom,
+		CreatedByUserID:     userID,
+	}
+	if err := uc.prioritizationRepo.CreateVersion(ctx, version); err != nil {
+		// Log do erro mas não falha a operação
+		fmt.Printf("Erro ao registrar versão da priorização: %v\n", err)
+	}
+}
+
+// getAccessiblePrioritization busca a priorização se o usuário for admin/manager ou do mesmo setor
+func (uc *PrioritizationUseCaseImpl) getAccessiblePrioritization(ctx context.Context, prioritizationID int64, userID int64) (*entities.InitiativePrioritization, error) {
+	prioritization, err := uc.prioritizationRepo.GetByID(ctx, prioritizationID)
+	if err != nil {
+		return nil, errors.New("priorização não encontrada")
+	}
+
+	if isAdminOrManager, _ := uc.isAdminOrManager(ctx, userID); isAdminOrManager {
+		return prioritization, nil
+	}
+
+	user, err := uc.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("usuário não encontrado")
+	}
+
+	if user.SectorID == nil || *user.SectorID != prioritization.SectorID {
+		return nil, errors.New("você só pode consultar a priorização do seu setor")
+	}
+
+	return prioritization, nil
+}
+
+// priorityMoves compara as posições (1..N) de cada iniciativa entre duas ordens
+func priorityMoves(from, to []int64) []*entities.PriorityMove {
+	fromPos := make(map[int64]int, len(from))
+	for i, id := range from {
+		fromPos[id] = i + 1
+	}
+	toPos := make(map[int64]int, len(to))
+	for i, id := range to {
+		toPos[id] = i + 1
+	}
+
+	var moves []*entities.PriorityMove
+	for i, id := range to {
+		position := i + 1
+		move := &entities.PriorityMove{InitiativeID: id, ToPosition: &position}
+
+		previous, ok := fromPos[id]
+		switch {
+		case !ok:
+			move.Move = entities.PriorityMoveAdded
+		case previous > position:
+			move.Move = entities.PriorityMoveUp
+		case previous < position:
+			move.Move = entities.PriorityMoveDown
+		default:
+			move.Move = entities.PriorityMoveUnchanged
+		}
+		if ok {
+			move.FromPosition = &previous
+			move.Delta = previous - position
+		}
+
+		moves = append(moves, move)
+	}
+
+	for i, id := range from {
+		if _, ok := toPos[id]; ok {
+			continue
+		}
+		position := i + 1
+		moves = append(moves, &entities.PriorityMove{
+			InitiativeID: id,
+			FromPosition: &position,
+			Move:         entities.PriorityMoveRemoved,
+		})
+	}
+
+	return moves
 }
 
 // Helper:  Construir priorização com iniciativas completas
@@ -388,15 +1011,19 @@ func (uc *PrioritizationUseCaseImpl) buildPrioritizationWithInitiatives(ctx cont
 		}
 
 		initiatives = append(initiatives, &entities.InitiativeListResponse{
-			ID:          initiative.ID,
-			Title:       initiative.Title,
-			Description: initiative.Description,
-			Status:      initiative.Status,
-			Type:        initiative.Type,
-			Priority:    initiative.Priority,
-			Sector:      initiative.Sector,
-			OwnerName:   initiative.OwnerName,
-			Date:        formatDate(initiative.CreatedAt),
+			ID:            initiative.ID,
+			Title:         initiative.Title,
+			Description:   listDescription(initiative.Description),
+			Status:        initiative.Status,
+			Type:          initiative.Type,
+			Priority:      initiative.Priority,
+			Score:         initiative.Score,
+			EffortDays:    initiative.EffortDays,
+			EstimatedCost: initiative.EstimatedCost,
+			RequiredSkill: initiative.RequiredSkill,
+			Sector:        initiative.Sector,
+			OwnerName:     initiative.OwnerName,
+			Date:          formatDate(initiative.CreatedAt),
 		})
 	}
 
@@ -406,14 +1033,111 @@ func (uc *PrioritizationUseCaseImpl) buildPrioritizationWithInitiatives(ctx cont
 		SectorName:      p.SectorName,
 		Year:            p.Year,
 		IsLocked:        p.IsLocked,
+		Status:          p.Status,
 		Initiatives:     initiatives,
+		UnrankedIDs:     p.UnrankedIDs,
 		CreatedByUserID: p.CreatedByUserID,
 		CreatedByName:   p.CreatedByName,
 		CreatedAt:       p.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt:       p.UpdatedAt.Format("2006-01-02 15:04:05"),
+		SubmittedAt:     p.SubmittedAt,
+		ReopenReason:    p.ReopenReason,
+		Capacity:        uc.sectorCapacity(ctx, p.SectorID, p.Year, initiatives),
 	}, nil
 }
 
+// sectorCapacity calcula a linha de corte da ordem; nil se o setor não tem capacidade definida no ano
+func (uc *PrioritizationUseCaseImpl) sectorCapacity(ctx context.Context, sectorID int64, year int, initiatives []*entities.InitiativeListResponse) *entities.PrioritizationCapacity {
+	capacity, err := uc.prioritizationRepo.GetCapacity(ctx, sectorID, year)
+	if err != nil {
+		return nil
+	}
+	return computeCapacity(capacity, initiatives)
+}
+
+// SetSectorCapacity define a capacidade (pessoas-dia e orçamento) do setor no ano (admin/manager)
+func (uc *PrioritizationUseCaseImpl) SetSectorCapacity(ctx context.Context, req *entities.SetSectorCapacityRequest, userID int64) (*entities.SectorCapacity, error) {
+	isAdminOrManager, err := uc.isAdminOrManager(ctx, userID)
+	if err != nil || !isAdminOrManager {
+		return nil, errors.New("apenas administradores e gerentes podem definir a capacidade dos setores")
+	}
+
+	if req.SectorID <= 0 {
+		return nil, errors.New("setor é obrigatório")
+	}
+	if req.Year <= 0 {
+		return nil, errors.New("ano inválido")
+	}
+	if req.CapacityDays < 0 {
+		return nil, errors.New("capacidade não pode ser negativa")
+	}
+	if req.Budget != nil && *req.Budget < 0 {
+		return nil, errors.New("orçamento não pode ser negativo")
+	}
+
+	if _, err := uc.sectorRepo.GetByID(ctx, req.SectorID); err != nil {
+		return nil, errors.New("setor não encontrado")
+	}
+
+	capacity := &entities.SectorCapacity{
+		SectorID:        req.SectorID,
+		Year:            req.Year,
+		CapacityDays:    req.CapacityDays,
+		Budget:          req.Budget,
+		UpdatedByUserID: userID,
+	}
+	if err := uc.prioritizationRepo.UpsertCapacity(ctx, capacity); err != nil {
+		return nil, fmt.Errorf("erro ao salvar capacidade: %w", err)
+	}
+
+	return uc.prioritizationRepo.GetCapacity(ctx, req.SectorID, req.Year)
+}
+
+// ListSectorCapacities lista as capacidades do ano; usuários sem perfil de gestão veem apenas o próprio setor
+func (uc *PrioritizationUseCaseImpl) ListSectorCapacities(ctx context.Context, year int, userID int64) ([]*entities.SectorCapacity, error) {
+	capacities, err := uc.prioritizationRepo.ListCapacitiesByYear(ctx, year)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar capacidades: %w", err)
+	}
+
+	isAdminOrManager, err := uc.isAdminOrManager(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if isAdminOrManager {
+		return capacities, nil
+	}
+
+	user, err := uc.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("usuário não encontrado")
+	}
+
+	var own []*entities.SectorCapacity
+	for _, capacity := range capacities {
+		if user.SectorID != nil && capacity.SectorID == *user.SectorID {
+			own = append(own, capacity)
+		}
+	}
+	return own, nil
+}
+
+// Helper: Verificar se é admin
+func (uc *PrioritizationUseCaseImpl) isAdmin(ctx context.Context, userID int64) (bool, error) {
+	userTypes, err := uc.permRepo.GetUserTypes(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, userType := range userTypes {
+		if userType.Name == "admin" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // Helper: Verificar se é admin ou manager
 func (uc *PrioritizationUseCaseImpl) isAdminOrManager(ctx context.Context, userID int64) (bool, error) {
 	userTypes, err := uc.permRepo.GetUserTypes(ctx, userID)
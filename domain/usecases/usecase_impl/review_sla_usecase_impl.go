@@ -0,0 +1,173 @@
+package usecase_impl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/infrastructure/repositories"
+	"hackathon-backend/settings_loader"
+	"time"
+)
+
+type ReviewSLAUseCaseImpl struct {
+	slaRepo            repositories.ReviewSLARepository
+	cancellationRepo   repositories.CancellationRepository
+	initiativeRepo     repositories.InitiativeRepository
+	prioritizationRepo repositories.PrioritizationRepository
+	historyRepo        repositories.InitiativeHistoryRepository
+	permRepo           repositories.PermissionRepository
+	notificationRepo   repositories.NotificationRepository
+	settings           *settings_loader.SettingsLoader
+}
+
+func NewReviewSLAUseCaseImpl(
+	slaRepo repositories.ReviewSLARepository,
+	cancellationRepo repositories.CancellationRepository,
+	initiativeRepo repositories.InitiativeRepository,
+	prioritizationRepo repositories.PrioritizationRepository,
+	historyRepo repositories.InitiativeHistoryRepository,
+	permRepo repositories.PermissionRepository,
+	notificationRepo repositories.NotificationRepository,
+	settings *settings_loader.SettingsLoader,
+) *ReviewSLAUseCaseImpl {
+	return &ReviewSLAUseCaseImpl{
+		slaRepo:            slaRepo,
+		cancellationRepo:   cancellationRepo,
+		initiativeRepo:     initiativeRepo,
+		prioritizationRepo: prioritizationRepo,
+		historyRepo:        historyRepo,
+		permRepo:           permRepo,
+		notificationRepo:   notificationRepo,
+		settings:           settings,
+	}
+}
+
+// RunOnce verifica todos os itens pendentes: expira os que passaram do prazo máximo
+// (se habilitado) e escalona os atrasados para o próximo nível de revisores
+func (uc *ReviewSLAUseCaseImpl) RunOnce(ctx context.Context) (*entities.ReviewSLARunResult, error) {
+	items, err := uc.slaRepo.ListPendingItems(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar itens pendentes: %w", err)
+	}
+
+	result := &entities.ReviewSLARunResult{Checked: len(items)}
+	tiers := uc.settings.SLA.EscalationTiers
+	expireAfter := time.Duration(uc.settings.SLA.ExpireAfterHours) * time.Hour
+
+	for _, item := range items {
+		age := time.Since(item.PendingSince)
+
+		// Iniciativas submetidas não expiram: apenas solicitações
+		if uc.settings.SLA.AutoExpire && item.Type != entities.ReviewItemInitiative && age >= expireAfter {
+			expired, err := uc.expire(ctx, item)
+			if err != nil {
+				return result, err
+			}
+			if expired {
+				result.Expired++
+			}
+			continue
+		}
+
+		sla := reviewSLA(uc.settings, item.Type)
+		if len(tiers) == 0 || sla <= 0 || age < sla {
+			continue
+		}
+
+		// Nível 1 = lembrete para quem revisa normalmente; cada SLA adicional sobe um nível
+		level := int(age / sla)
+		if level > len(tiers) {
+			level = len(tiers)
+		}
+		if level <= item.EscalationLevel {
+			continue
+		}
+
+		advanced, err := uc.slaRepo.AdvanceEscalation(ctx, item.Type, item.ID, item.Round, level)
+		if err != nil {
+			return result, fmt.Errorf("erro ao registrar escalonamento: %w", err)
+		}
+		if !advanced {
+			// Outra instância já escalonou
+			continue
+		}
+
+		uc.notifyTier(ctx, item, tiers[level-1], int(age.Hours()))
+		result.Escalated++
+	}
+
+	return result, nil
+}
+
+func (uc *ReviewSLAUseCaseImpl) expire(ctx context.Context, item *entities.PendingReviewItem) (bool, error) {
+	reason := fmt.Sprintf("Expirada automaticamente após %d horas sem decisão", uc.settings.SLA.ExpireAfterHours)
+
+	var err error
+	switch item.Type {
+	case entities.ReviewItemCancellation:
+		err = uc.cancellationRepo.Expire(ctx, item.ID, reason)
+	case entities.ReviewItemPrioritizationChange:
+		err = uc.prioritizationRepo.ExpireChangeRequest(ctx, item.ID, reason)
+	default:
+		return false, nil
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Revisada (ou expirada por outra instância) nesse meio tempo
+			return false, nil
+		}
+		return false, fmt.Errorf("erro ao expirar solicitação: %w", err)
+	}
+
+	if item.Type == entities.ReviewItemCancellation && item.InitiativeID != nil {
+		if initiative, err := uc.initiativeRepo.GetByID(ctx, *item.InitiativeID); err == nil {
+			history := &entities.InitiativeHistory{
+				InitiativeID: initiative.ID,
+				UserID:       item.RequestedByUserID,
+				OldStatus:    initiative.Status,
+				NewStatus:    initiative.Status,
+				Reason:       "⏱️ Solicitação de cancelamento expirada: " + reason,
+			}
+
+			if err := uc.historyRepo.Create(ctx, history); err != nil {
+				// Log do erro mas não falha a operação
+				fmt.Printf("Erro ao registrar histórico de expiração: %v\n", err)
+			}
+		}
+	}
+
+	notify(ctx, uc.notificationRepo, &entities.Notification{
+		UserID:       item.RequestedByUserID,
+		Type:         entities.NotificationTypeReviewExpired,
+		Title:        "Solicitação expirada",
+		Message:      fmt.Sprintf("Sua solicitação \"%s\" expirou sem decisão. Se ainda for necessária, abra uma nova.", item.Subject),
+		InitiativeID: item.InitiativeID,
+	})
+
+	return true, nil
+}
+
+// notifyTier avisa todos os usuários do nível de escalonamento (exceto o próprio solicitante)
+func (uc *ReviewSLAUseCaseImpl) notifyTier(ctx context.Context, item *entities.PendingReviewItem, tier string, ageHours int) {
+	userIDs, err := uc.permRepo.ListUserIDsByType(ctx, tier)
+	if err != nil {
+		fmt.Printf("Erro ao buscar revisores do nível %s: %v\n", tier, err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		if userID == item.RequestedByUserID {
+			continue
+		}
+
+		notify(ctx, uc.notificationRepo, &entities.Notification{
+			UserID:       userID,
+			Type:         entities.NotificationTypeReviewOverdue,
+			Title:        "Decisão pendente em atraso",
+			Message:      fmt.Sprintf("%s \"%s\" aguarda decisão há %d horas (solicitado por %s)", reviewItemLabel(item.Type), item.Subject, ageHours, item.RequestedByName),
+			InitiativeID: item.InitiativeID,
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package usecase_impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/infrastructure/repositories"
+	"hackathon-backend/settings_loader"
+	"math"
+	"slices"
+	"strings"
+)
+
+type ScoringUseCaseImpl struct {
+	scoringRepo    repositories.ScoringRepository
+	initiativeRepo repositories.InitiativeRepository
+	permRepo       repositories.PermissionRepository
+	settings       *settings_loader.SettingsLoader
+}
+
+func NewScoringUseCaseImpl(
+	scoringRepo repositories.ScoringRepository,
+	initiativeRepo repositories.InitiativeRepository,
+	permRepo repositories.PermissionRepository,
+	settings *settings_loader.SettingsLoader,
+) *ScoringUseCaseImpl {
+	return &ScoringUseCaseImpl{
+		scoringRepo:    scoringRepo,
+		initiativeRepo: initiativeRepo,
+		permRepo:       permRepo,
+		settings:       settings,
+	}
+}
+
+func (uc *ScoringUseCaseImpl) CreateCriterion(ctx context.Context, req *entities.CreateScoringCriterionRequest) (*entities.ScoringCriterion, error) {
+	name := strings.TrimSpace(req.Name)
+	if len(name) < 3 {
+		return nil, errors.New("nome do critério deve ter no mínimo 3 caracteres")
+	}
+
+	if req.Weight <= 0 {
+		return nil, errors.New("peso do critério deve ser positivo")
+	}
+
+	criterion := &entities.ScoringCriterion{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Weight:      req.Weight,
+		Inverted:    req.Inverted,
+		Active:      true,
+	}
+
+	if err := uc.scoringRepo.CreateCriterion(ctx, criterion); err != nil {
+		return nil, fmt.Errorf("erro ao criar critério: %w", err)
+	}
+
+	return criterion, nil
+}
+
+// UpdateCriterion altera o critério e recalcula a pontuação das iniciativas já avaliadas
+func (uc *ScoringUseCaseImpl) UpdateCriterion(ctx context.Context, criterionID int64, req *entities.UpdateScoringCriterionRequest) (*entities.ScoringCriterion, error) {
+	criterion, err := uc.scoringRepo.GetCriterionByID(ctx, criterionID)
+	if err != nil {
+		return nil, errors.New("critério não encontrado")
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if len(name) < 3 {
+			return nil, errors.New("nome do critério deve ter no mínimo 3 caracteres")
+		}
+		criterion.Name = name
+	}
+
+	if req.Description != nil {
+		criterion.Description = strings.TrimSpace(*req.Description)
+	}
+
+	if req.Weight != nil {
+		if *req.Weight <= 0 {
+			return nil, errors.New("peso do critério deve ser positivo")
+		}
+		criterion.Weight = *req.Weight
+	}
+
+	if req.Inverted != nil {
+		criterion.Inverted = *req.Inverted
+	}
+
+	if req.Active != nil {
+		criterion.Active = *req.Active
+	}
+
+	if err := uc.scoringRepo.UpdateCriterion(ctx, criterion); err != nil {
+		return nil, fmt.Errorf("erro ao atualizar critério: %w", err)
+	}
+
+	if req.Weight != nil || req.Inverted != nil || req.Active != nil {
+		uc.recalculateAll(ctx)
+	}
+
+	return criterion, nil
+}
+
+func (uc *ScoringUseCaseImpl) ListCriteria(ctx context.Context, activeOnly bool) ([]*entities.ScoringCriterion, error) {
+	return uc.scoringRepo.ListCriteria(ctx, activeOnly)
+}
+
+// ScoreInitiative grava as notas do revisor e recalcula a pontuação da iniciativa
+func (uc *ScoringUseCaseImpl) ScoreInitiative(ctx context.Context, initiativeID int64, req *entities.ScoreInitiativeRequest, userID int64) (*entities.InitiativeScoreBreakdown, error) {
+	userTypes, err := uc.permRepo.GetUserTypes(ctx, userID)
+	if err != nil {
+		return nil, errors.New("erro ao verificar permissões")
+	}
+	isReviewer := false
+	for _, userType := range userTypes {
+		if slices.Contains(uc.settings.Scoring.Reviewers, userType.Name) {
+			isReviewer = true
+			break
+		}
+	}
+	if !isReviewer {
+		return nil, errors.New("apenas revisores podem pontuar iniciativas")
+	}
+
+	if len(req.Scores) == 0 {
+		return nil, errors.New("informe ao menos uma nota")
+	}
+
+	initiative, err := uc.initiativeRepo.GetByID(ctx, initiativeID)
+	if err != nil {
+		return nil, errors.New("iniciativa não encontrada")
+	}
+	if initiative.OwnerID == userID {
+		return nil, errors.New("você não pode pontuar a sua própria iniciativa")
+	}
+
+	criteria, err := uc.scoringRepo.ListCriteria(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar critérios: %w", err)
+	}
+	active := make(map[int64]bool)
+	for _, criterion := range criteria {
+		active[criterion.ID] = true
+	}
+
+	seen := make(map[int64]bool)
+	for _, input := range req.Scores {
+		if !active[input.CriterionID] {
+			return nil, fmt.Errorf("critério %d não encontrado ou inativo", input.CriterionID)
+		}
+		if seen[input.CriterionID] {
+			return nil, fmt.Errorf("critério %d informado mais de uma vez", input.CriterionID)
+		}
+		seen[input.CriterionID] = true
+		if input.Score < entities.ScoreMin || input.Score > entities.ScoreMax {
+			return nil, fmt.Errorf("nota deve estar entre %d e %d", entities.ScoreMin, entities.ScoreMax)
+		}
+	}
+
+	for _, input := range req.Scores {
+		score := &entities.InitiativeCriterionScore{
+			InitiativeID: initiativeID,
+			CriterionID:  input.CriterionID,
+			ReviewerID:   userID,
+			Score:        input.Score,
+			Comment:      strings.TrimSpace(input.Comment),
+		}
+		if err := uc.scoringRepo.UpsertScore(ctx, score); err != nil {
+			return nil, fmt.Errorf("erro ao gravar nota: %w", err)
+		}
+	}
+
+	breakdown, err := uc.GetInitiativeScore(ctx, initiativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.scoringRepo.UpdateInitiativeScore(ctx, initiativeID, breakdown.Score); err != nil {
+		return nil, fmt.Errorf("erro ao atualizar pontuação da iniciativa: %w", err)
+	}
+
+	return breakdown, nil
+}
+
+// GetInitiativeScore calcula a pontuação da iniciativa com o detalhamento por critério
+func (uc *ScoringUseCaseImpl) GetInitiativeScore(ctx context.Context, initiativeID int64) (*entities.InitiativeScoreBreakdown, error) {
+	if _, err := uc.initiativeRepo.GetByID(ctx, initiativeID); err != nil {
+		return nil, errors.New("iniciativa não encontrada")
+	}
+
+	criteria, err := uc.scoringRepo.ListCriteria(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar critérios: %w", err)
+	}
+
+	scores, err := uc.scoringRepo.ListScoresByInitiative(ctx, initiativeID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar notas: %w", err)
+	}
+
+	score, summaries := computeInitiativeScore(criteria, scores)
+
+	return &entities.InitiativeScoreBreakdown{
+		InitiativeID: initiativeID,
+		Score:        score,
+		Criteria:     summaries,
+		Scores:       scores,
+	}, nil
+}
+
+// recalculateAll atualiza a pontuação de todas as iniciativas avaliadas (após mudar pesos ou critérios)
+func (uc *ScoringUseCaseImpl) recalculateAll(ctx context.Context) {
+	ids, err := uc.scoringRepo.ListScoredInitiativeIDs(ctx)
+	if err != nil {
+		fmt.Printf("Erro ao listar iniciativas pontuadas: %v\n", err)
+		return
+	}
+
+	for _, id := range ids {
+		breakdown, err := uc.GetInitiativeScore(ctx, id)
+		if err != nil {
+			fmt.Printf("Erro ao recalcular pontuação da iniciativa %d: %v\n", id, err)
+			continue
+		}
+		if err := uc.scoringRepo.UpdateInitiativeScore(ctx, id, breakdown.Score); err != nil {
+			fmt.Printf("Erro ao atualizar pontuação da iniciativa %d: %v\n", id, err)
+		}
+	}
+}
+
+// computeInitiativeScore: média das notas de cada critério normalizada para 0..1 (invertida quando
+// nota alta é ruim), ponderada pelos pesos dos critérios avaliados e escalada para 0..100
+func computeInitiativeScore(criteria []*entities.ScoringCriterion, scores []*entities.InitiativeCriterionScore) (*float64, []*entities.CriterionScoreSummary) {
+	sums := make(map[int64]int)
+	counts := make(map[int64]int)
+	for _, score := range scores {
+		sums[score.CriterionID] += score.Score
+		counts[score.CriterionID]++
+	}
+
+	var summaries []*entities.CriterionScoreSummary
+	var totalWeight float64
+	for _, criterion := range criteria {
+		summary := &entities.CriterionScoreSummary{
+			CriterionID: criterion.ID,
+			Name:        criterion.Name,
+			Weight:      criterion.Weight,
+			Inverted:    criterion.Inverted,
+			Reviewers:   counts[criterion.ID],
+		}
+		if summary.Reviewers > 0 {
+			summary.Average = float64(sums[criterion.ID]) / float64(summary.Reviewers)
+			totalWeight += criterion.Weight
+		}
+		summaries = append(summaries, summary)
+	}
+
+	if totalWeight == 0 {
+		return nil, summaries
+	}
+
+	var total float64
+	for _, summary := range summaries {
+		if summary.Reviewers == 0 {
+			continue
+		}
+		normalized := (summary.Average - entities.ScoreMin) / (entities.ScoreMax - entities.ScoreMin)
+		if summary.Inverted {
+			normalized = 1 - normalized
+		}
+		summary.Contribution = math.Round(normalized*summary.Weight/totalWeight*1000) / 10
+		total += normalized * summary.Weight / totalWeight * 100
+	}
+
+	score := math.Round(total*10) / 10
+	return &score, summaries
+}
@@ -0,0 +1,297 @@
+package usecase_impl
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/infrastructure/repositories"
+	"hackathon-backend/settings_loader"
+	"log"
+	"strings"
+)
+
+type SSOUseCaseImpl struct {
+	oidcRepo         repositories.OIDCRepository
+	authRepo         repositories.AuthRepository
+	permRepo         repositories.PermissionRepository
+	sectorRepo       repositories.SectorRepository
+	loginAttemptRepo repositories.LoginAttemptRepository
+	settings         *settings_loader.SettingsLoader
+}
+
+func NewSSOUseCaseImpl(
+	oidcRepo repositories.OIDCRepository,
+	authRepo repositories.AuthRepository,
+	permRepo repositories.PermissionRepository,
+	sectorRepo repositories.SectorRepository,
+	loginAttemptRepo repositories.LoginAttemptRepository,
+	settings *settings_loader.SettingsLoader,
+) *SSOUseCaseImpl {
+	return &SSOUseCaseImpl{
+		oidcRepo:         oidcRepo,
+		authRepo:         authRepo,
+		permRepo:         permRepo,
+		sectorRepo:       sectorRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		settings:         settings,
+	}
+}
+
+// BeginLogin gera state, nonce e o verificador PKCE e monta a URL do provedor
+func (uc *SSOUseCaseImpl) BeginLogin(ctx context.Context) (*entities.OIDCAuthRequest, error) {
+	if !uc.settings.OIDC.Enabled {
+		return nil, errors.New("login corporativo (SSO) não está habilitado")
+	}
+
+	flow := &entities.OIDCFlowState{}
+	for _, target := range []*string{&flow.State, &flow.Nonce, &flow.CodeVerifier} {
+		value, err := randomURLToken(32)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao gerar parâmetros do login: %w", err)
+		}
+		*target = value
+	}
+
+	challenge := sha256.Sum256([]byte(flow.CodeVerifier))
+
+	authURL, err := uc.oidcRepo.AuthorizationURL(ctx, flow.State, flow.Nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar login corporativo: %w", err)
+	}
+
+	return &entities.OIDCAuthRequest{
+		AuthorizationURL: authURL,
+		Flow:             flow,
+	}, nil
+}
+
+// CompleteLogin troca o código, valida o ID token e provisiona/atualiza o usuário local
+func (uc *SSOUseCaseImpl) CompleteLogin(ctx context.Context, code string, flow *entities.OIDCFlowState, ipAddress string) (*entities.User, error) {
+	if !uc.settings.OIDC.Enabled {
+		return nil, errors.New("login corporativo (SSO) não está habilitado")
+	}
+
+	rawIDToken, err := uc.oidcRepo.ExchangeCode(ctx, code, flow.CodeVerifier)
+	if err != nil {
+		log.Printf("Erro no login corporativo (token): %v", err)
+		return nil, errors.New("não foi possível concluir o login corporativo")
+	}
+
+	claims, err := uc.oidcRepo.VerifyIDToken(ctx, rawIDToken, flow.Nonce)
+	if err != nil {
+		log.Printf("Erro no login corporativo (id_token): %v", err)
+		return nil, errors.New("não foi possível concluir o login corporativo")
+	}
+
+	// Mesmo bloqueio por conta e por IP do login com senha
+	if err := checkLoginLockout(ctx, uc.loginAttemptRepo, normalizeEmail(claims.Email), ipAddress); err != nil {
+		uc.recordAttempt(ctx, claims.Email, ipAddress, false, entities.LoginFailureThrottled)
+		return nil, err
+	}
+
+	user, created, err := uc.provisionUser(ctx, claims)
+	if err != nil {
+		uc.recordAttempt(ctx, claims.Email, ipAddress, false, err.Error())
+		return nil, err
+	}
+
+	// Conta vinculada pelo subject pode ter email local diferente do informado pelo provedor
+	if account := normalizeEmail(user.Email); account != normalizeEmail(claims.Email) {
+		if err := checkLoginLockout(ctx, uc.loginAttemptRepo, account, ipAddress); err != nil {
+			uc.recordAttempt(ctx, account, ipAddress, false, entities.LoginFailureThrottled)
+			return nil, err
+		}
+	}
+
+	if err := uc.syncUserTypes(ctx, user, claims.Groups, created); err != nil {
+		return nil, err
+	}
+	uc.syncSector(ctx, user, claims.Sector)
+
+	uc.recordAttempt(ctx, user.Email, ipAddress, true, "")
+
+	// Recarregar para refletir tipos, setor e status do 2FA
+	return uc.authRepo.GetUserByID(ctx, user.ID)
+}
+
+// provisionUser encontra o usuário pelo vínculo OIDC, pelo email verificado ou cria um novo (just-in-time)
+func (uc *SSOUseCaseImpl) provisionUser(ctx context.Context, claims *entities.OIDCClaims) (*entities.User, bool, error) {
+	user, err := uc.authRepo.GetUserByOIDCSubject(ctx, claims.Issuer, claims.Subject)
+	if err == nil {
+		return user, false, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, false, fmt.Errorf("erro ao buscar usuário: %w", err)
+	}
+
+	if claims.Email == "" {
+		return nil, false, errors.New("provedor de identidade não informou o email")
+	}
+
+	// Usuário local já existente: vincular somente se o provedor garantir o email
+	user, err = uc.authRepo.GetUserByEmail(ctx, claims.Email)
+	if err == nil {
+		if !claims.EmailVerified {
+			return nil, false, errors.New("email não verificado no provedor de identidade")
+		}
+		if err := uc.authRepo.LinkOIDCSubject(ctx, user.ID, claims.Issuer, claims.Subject); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, false, errors.New("usuário já vinculado a outra identidade corporativa")
+			}
+			return nil, false, fmt.Errorf("erro ao vincular usuário: %w", err)
+		}
+		return user, false, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, false, fmt.Errorf("erro ao buscar usuário: %w", err)
+	}
+
+	// Sem email verificado o provedor poderia reservar o endereço de um colega ainda sem conta
+	if !claims.EmailVerified {
+		return nil, false, errors.New("email não verificado no provedor de identidade")
+	}
+
+	// Senha aleatória: contas criadas pelo SSO não fazem login local
+	randomPassword, err := randomURLToken(32)
+	if err != nil {
+		return nil, false, fmt.Errorf("erro ao gerar senha: %w", err)
+	}
+	hashedPassword, err := hashPassword(uc.settings.Password, randomPassword)
+	if err != nil {
+		return nil, false, fmt.Errorf("erro ao criptografar senha: %w", err)
+	}
+
+	name := claims.Name
+	if name == "" {
+		name, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	user = &entities.User{
+		Email:    claims.Email,
+		Name:     name,
+		Password: hashedPassword,
+	}
+
+	if err := uc.authRepo.CreateUser(ctx, user); err != nil {
+		return nil, false, fmt.Errorf("erro ao criar usuário: %w", err)
+	}
+
+	if err := uc.authRepo.LinkOIDCSubject(ctx, user.ID, claims.Issuer, claims.Subject); err != nil {
+		return nil, false, fmt.Errorf("erro ao vincular usuário: %w", err)
+	}
+
+	log.Printf("Usuário %s provisionado via login corporativo", user.Email)
+	return user, true, nil
+}
+
+// syncUserTypes aplica o mapeamento grupo → tipo. Se nenhum grupo estiver mapeado,
+// mantém os tipos atuais (novos usuários recebem o tipo padrão).
+// Administradores e contas de emergência não são alterados pelos grupos do provedor.
+func (uc *SSOUseCaseImpl) syncUserTypes(ctx context.Context, user *entities.User, groups []string, created bool) error {
+	userID := user.ID
+	if isBreakGlassAccount(uc.settings, normalizeEmail(user.Email)) {
+		return nil
+	}
+
+	wanted := make(map[string]bool)
+	for _, mapping := range uc.settings.OIDC.GroupUserTypes {
+		group, typeName, _ := strings.Cut(mapping, "=")
+		for _, userGroup := range groups {
+			if strings.EqualFold(strings.TrimSpace(group), userGroup) {
+				wanted[strings.TrimSpace(typeName)] = true
+			}
+		}
+	}
+
+	if len(wanted) == 0 {
+		if !created {
+			return nil
+		}
+		wanted[uc.settings.OIDC.DefaultUserType] = true
+	}
+
+	allTypes, err := uc.permRepo.GetAllUserTypes(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar tipos de usuário: %w", err)
+	}
+
+	currentTypes, err := uc.permRepo.GetUserTypes(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar tipos do usuário: %w", err)
+	}
+
+	for _, userType := range currentTypes {
+		if userType.Name == "admin" {
+			log.Printf("Tipos do administrador %s não sincronizados pelos grupos do SSO", user.Email)
+			return nil
+		}
+	}
+
+	current := make(map[string]bool)
+	for _, userType := range currentTypes {
+		current[userType.Name] = true
+		if !wanted[userType.Name] {
+			if err := uc.permRepo.RemoveUserType(ctx, userID, userType.ID); err != nil {
+				return fmt.Errorf("erro ao remover tipo: %w", err)
+			}
+		}
+	}
+
+	for _, userType := range allTypes {
+		if wanted[userType.Name] && !current[userType.Name] {
+			if err := uc.permRepo.AssignUserType(ctx, userID, userType.ID); err != nil {
+				return fmt.Errorf("erro ao atribuir tipo: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// syncSector atualiza o setor do usuário a partir da claim configurada (pelo nome do setor)
+func (uc *SSOUseCaseImpl) syncSector(ctx context.Context, user *entities.User, sectorName string) {
+	if sectorName == "" {
+		return
+	}
+
+	sector, err := uc.sectorRepo.GetByName(ctx, sectorName)
+	if err != nil {
+		log.Printf("Setor %q informado pelo provedor não encontrado", sectorName)
+		return
+	}
+
+	if user.SectorID != nil && *user.SectorID == sector.ID {
+		return
+	}
+
+	user.SectorID = &sector.ID
+	if err := uc.authRepo.UpdateUser(ctx, user); err != nil {
+		log.Printf("Erro ao atualizar setor do usuário: %v", err)
+	}
+}
+
+func (uc *SSOUseCaseImpl) recordAttempt(ctx context.Context, email, ipAddress string, success bool, failureReason string) {
+	attempt := &entities.LoginAttempt{
+		Email:         normalizeEmail(email),
+		IPAddress:     ipAddress,
+		Success:       success,
+		FailureReason: failureReason,
+	}
+
+	if err := uc.loginAttemptRepo.RecordAttempt(ctx, attempt); err != nil {
+		log.Printf("Erro ao registrar tentativa de login: %v", err)
+	}
+}
+
+func randomURLToken(size int) (string, error) {
+	raw := make([]byte, size)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
@@ -0,0 +1,230 @@
+package usecase_impl
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/infrastructure/repositories"
+	"hackathon-backend/settings_loader"
+	"hackathon-backend/utils/totp"
+	"strings"
+	"time"
+)
+
+type TwoFactorUseCaseImpl struct {
+	twoFactorRepo repositories.TwoFactorRepository
+	authRepo      repositories.AuthRepository
+	settings      *settings_loader.SettingsLoader
+}
+
+func NewTwoFactorUseCaseImpl(
+	twoFactorRepo repositories.TwoFactorRepository,
+	authRepo repositories.AuthRepository,
+	settings *settings_loader.SettingsLoader,
+) *TwoFactorUseCaseImpl {
+	return &TwoFactorUseCaseImpl{
+		twoFactorRepo: twoFactorRepo,
+		authRepo:      authRepo,
+		settings:      settings,
+	}
+}
+
+func (uc *TwoFactorUseCaseImpl) GetStatus(ctx context.Context, userID int64) (*entities.TwoFactorStatus, error) {
+	user, err := uc.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("usuário não encontrado")
+	}
+
+	status := &entities.TwoFactorStatus{
+		Enabled:  user.TwoFactorEnabled,
+		Required: user.TwoFactorRequired,
+	}
+
+	if user.TwoFactorEnabled {
+		remaining, err := uc.twoFactorRepo.CountRecoveryCodes(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao contar códigos de recuperação: %w", err)
+		}
+		status.RecoveryCodesRemaining = remaining
+	}
+
+	return status, nil
+}
+
+// Setup gera um novo segredo (ainda inativo) e a URI para o aplicativo autenticador
+func (uc *TwoFactorUseCaseImpl) Setup(ctx context.Context, userID int64) (*entities.TwoFactorSetupResponse, error) {
+	user, err := uc.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("usuário não encontrado")
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, errors.New("autenticação em dois fatores já está ativada")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar segredo: %w", err)
+	}
+
+	if err := uc.twoFactorRepo.SaveSecret(ctx, userID, secret); err != nil {
+		return nil, fmt.Errorf("erro ao salvar segredo: %w", err)
+	}
+
+	return &entities.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: totp.ProvisioningURI(uc.settings.Security.TwoFactorIssuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm ativa o 2FA após o primeiro código válido e retorna os códigos de recuperação
+func (uc *TwoFactorUseCaseImpl) Confirm(ctx context.Context, userID int64, code string) ([]string, error) {
+	tf, err := uc.twoFactorRepo.Get(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("inicie a configuração da autenticação em dois fatores")
+		}
+		return nil, fmt.Errorf("erro ao buscar autenticação em dois fatores: %w", err)
+	}
+
+	if tf.Enabled {
+		return nil, errors.New("autenticação em dois fatores já está ativada")
+	}
+
+	if err := verifyTOTPCode(ctx, uc.twoFactorRepo, tf, code); err != nil {
+		return nil, err
+	}
+
+	if err := uc.twoFactorRepo.Enable(ctx, userID); err != nil {
+		return nil, fmt.Errorf("erro ao ativar autenticação em dois fatores: %w", err)
+	}
+
+	return uc.replaceRecoveryCodes(ctx, userID)
+}
+
+// RegenerateRecoveryCodes invalida os códigos anteriores (exige um código TOTP válido)
+func (uc *TwoFactorUseCaseImpl) RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) ([]string, error) {
+	tf, err := uc.getEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyTOTPCode(ctx, uc.twoFactorRepo, tf, code); err != nil {
+		return nil, err
+	}
+
+	return uc.replaceRecoveryCodes(ctx, userID)
+}
+
+func (uc *TwoFactorUseCaseImpl) Disable(ctx context.Context, userID int64, code string) error {
+	user, err := uc.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return errors.New("usuário não encontrado")
+	}
+
+	if user.TwoFactorRequired {
+		return errors.New("autenticação em dois fatores é obrigatória para o seu tipo de usuário")
+	}
+
+	tf, err := uc.getEnabled(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyTOTPCode(ctx, uc.twoFactorRepo, tf, code); err != nil {
+		return err
+	}
+
+	if err := uc.twoFactorRepo.Disable(ctx, userID); err != nil {
+		return fmt.Errorf("erro ao desativar autenticação em dois fatores: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *TwoFactorUseCaseImpl) getEnabled(ctx context.Context, userID int64) (*entities.TwoFactor, error) {
+	tf, err := uc.twoFactorRepo.Get(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("autenticação em dois fatores não está ativada")
+		}
+		return nil, fmt.Errorf("erro ao buscar autenticação em dois fatores: %w", err)
+	}
+
+	if !tf.Enabled {
+		return nil, errors.New("autenticação em dois fatores não está ativada")
+	}
+
+	return tf, nil
+}
+
+func (uc *TwoFactorUseCaseImpl) replaceRecoveryCodes(ctx context.Context, userID int64) ([]string, error) {
+	codes, hashes, err := generateRecoveryCodes(entities.RecoveryCodeCount)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar códigos de recuperação: %w", err)
+	}
+
+	if err := uc.twoFactorRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("erro ao salvar códigos de recuperação: %w", err)
+	}
+
+	return codes, nil
+}
+
+// verifyTOTPCode valida o código e registra o contador usado (cada código vale uma única vez)
+func verifyTOTPCode(ctx context.Context, repo repositories.TwoFactorRepository, tf *entities.TwoFactor, code string) error {
+	step, ok := totp.Validate(tf.Secret, code, time.Now())
+	if !ok || step <= tf.LastUsedStep {
+		return errors.New("código de verificação inválido")
+	}
+
+	marked, err := repo.MarkStepUsed(ctx, tf.UserID, step)
+	if err != nil {
+		return fmt.Errorf("erro ao validar código: %w", err)
+	}
+	if !marked {
+		return errors.New("código de verificação inválido")
+	}
+
+	return nil
+}
+
+// generateRecoveryCodes gera códigos no formato xxxxx-xxxxx e seus hashes
+func generateRecoveryCodes(count int) ([]string, []string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	codes := make([]string, 0, count)
+	hashes := make([]string, 0, count)
+
+	for i := 0; i < count; i++ {
+		raw := make([]byte, 7)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+
+		encoded := strings.ToLower(encoding.EncodeToString(raw))[:10]
+		code := encoded[:5] + "-" + encoded[5:]
+
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode normaliza o código digitado (sem hífen/espaços, minúsculo) e gera o SHA-256.
+// Os códigos são aleatórios com 50 bits, então não precisam de bcrypt.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.TrimSpace(code))
+	normalized = strings.ReplaceAll(normalized, "-", "")
+	normalized = strings.ReplaceAll(normalized, " ", "")
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
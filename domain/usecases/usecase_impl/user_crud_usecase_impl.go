@@ -6,20 +6,33 @@ import (
 	"fmt"
 	"hackathon-backend/domain/entities"
 	"hackathon-backend/infrastructure/repositories"
+	"hackathon-backend/settings_loader"
 	"regexp"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserCrudUseCaseImpl struct {
-	authRepo repositories.AuthRepository
-	permRepo repositories.PermissionRepository
+	authRepo         repositories.AuthRepository
+	permRepo         repositories.PermissionRepository
+	loginAttemptRepo repositories.LoginAttemptRepository
+	twoFactorRepo    repositories.TwoFactorRepository
+	settings         *settings_loader.SettingsLoader
 }
 
-func NewUserCrudUseCaseImpl(authRepo repositories.AuthRepository, permRepo repositories.PermissionRepository) *UserCrudUseCaseImpl {
+func NewUserCrudUseCaseImpl(
+	authRepo repositories.AuthRepository,
+	permRepo repositories.PermissionRepository,
+	loginAttemptRepo repositories.LoginAttemptRepository,
+	twoFactorRepo repositories.TwoFactorRepository,
+	settings *settings_loader.SettingsLoader,
+) *UserCrudUseCaseImpl {
 	return &UserCrudUseCaseImpl{
-		authRepo: authRepo,
-		permRepo: permRepo,
+		authRepo:         authRepo,
+		permRepo:         permRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		twoFactorRepo:    twoFactorRepo,
+		settings:         settings,
 	}
 }
 
@@ -34,9 +47,9 @@ func (uc *UserCrudUseCaseImpl) CreateUser(ctx context.Context, req *entities.Cre
 		return nil, errors.New("nome deve ter no mínimo 3 caracteres")
 	}
 
-	// Validar senha
-	if len(req.Password) < 6 {
-		return nil, errors.New("senha deve ter no mínimo 6 caracteres")
+	// Validar senha conforme a política configurada
+	if err := validatePassword(uc.settings.Password, req.Password, req.Email, req.Name); err != nil {
+		return nil, err
 	}
 
 	// Validar tipos (obrigatório pelo menos 1)
@@ -45,7 +58,7 @@ func (uc *UserCrudUseCaseImpl) CreateUser(ctx context.Context, req *entities.Cre
 	}
 
 	// Hash da senha
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := hashPassword(uc.settings.Password, req.Password)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criptografar senha:   %w", err)
 	}
@@ -54,11 +67,11 @@ func (uc *UserCrudUseCaseImpl) CreateUser(ctx context.Context, req *entities.Cre
 	user := &entities.User{
 		Email:    req.Email,
 		Name:     req.Name,
-		Password: string(hashedPassword),
+		Password: hashedPassword,
 		SectorID: req.SectorID, // NOVO
 	}
 
-	if err := uc.authRepo.CreateUser(ctx, user); err != nil {
+	if err := uc.authRepo.CreateUserWithPasswordHistory(ctx, user); err != nil {
 		return nil, fmt.Errorf("erro ao criar usuário: %w", err)
 	}
 
@@ -186,25 +199,76 @@ func (uc *UserCrudUseCaseImpl) ChangePassword(ctx context.Context, userID int64,
 		return errors.New("senha atual incorreta")
 	}
 
-	// Validar nova senha
-	if len(req.NewPassword) < 6 {
-		return errors.New("nova senha deve ter no mínimo 6 caracteres")
+	// Validar nova senha conforme a política configurada
+	policy := uc.settings.Password
+	if err := validatePassword(policy, req.NewPassword, user.Email, user.Name); err != nil {
+		return err
+	}
+
+	// Impedir reutilização das últimas senhas
+	if policy.HistoryCount > 0 {
+		history, err := uc.authRepo.ListPasswordHistory(ctx, userID, policy.HistoryCount)
+		if err != nil {
+			return fmt.Errorf("erro ao buscar histórico de senhas: %w", err)
+		}
+
+		if isPasswordReused(req.NewPassword, append(history, user.Password)) {
+			return fmt.Errorf("nova senha não pode ser igual a nenhuma das últimas %d senhas", policy.HistoryCount)
+		}
 	}
 
 	// Hash da nova senha
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	hashedPassword, err := hashPassword(policy, req.NewPassword)
 	if err != nil {
 		return fmt.Errorf("erro ao criptografar senha: %w", err)
 	}
 
 	// Atualizar senha
-	if err := uc.authRepo.UpdatePassword(ctx, userID, string(hashedPassword)); err != nil {
+	if err := uc.authRepo.UpdatePasswordWithHistory(ctx, userID, hashedPassword); err != nil {
 		return fmt.Errorf("erro ao atualizar senha: %w", err)
 	}
 
 	return nil
 }
 
+// UnlockUser remove o bloqueio de login da conta do usuário (admin)
+func (uc *UserCrudUseCaseImpl) UnlockUser(ctx context.Context, userID int64) error {
+	user, err := uc.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return errors.New("usuário não encontrado")
+	}
+
+	if err := uc.loginAttemptRepo.ClearLockout(ctx, entities.LoginLockoutScopeAccount, normalizeEmail(user.Email)); err != nil {
+		return fmt.Errorf("erro ao desbloquear usuário: %w", err)
+	}
+
+	return nil
+}
+
+// ResetTwoFactor remove o 2FA do usuário que perdeu o dispositivo e os códigos (admin).
+// Se o tipo do usuário exigir 2FA, ele precisará cadastrar novamente no próximo acesso.
+func (uc *UserCrudUseCaseImpl) ResetTwoFactor(ctx context.Context, userID int64) error {
+	if _, err := uc.authRepo.GetUserByID(ctx, userID); err != nil {
+		return errors.New("usuário não encontrado")
+	}
+
+	if err := uc.twoFactorRepo.Disable(ctx, userID); err != nil {
+		return fmt.Errorf("erro ao redefinir autenticação em dois fatores: %w", err)
+	}
+
+	return nil
+}
+
+// ListLoginAttempts lista a auditoria de tentativas de login (admin)
+func (uc *UserCrudUseCaseImpl) ListLoginAttempts(ctx context.Context, filter *entities.LoginAttemptFilter) ([]*entities.LoginAttempt, error) {
+	attempts, err := uc.loginAttemptRepo.ListAttempts(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar tentativas de login: %w", err)
+	}
+
+	return attempts, nil
+}
+
 func isValidEmail(email string) bool {
 	emailRegex, _ := regexp.Compile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	return emailRegex.MatchString(email)
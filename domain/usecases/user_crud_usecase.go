@@ -12,4 +12,7 @@ type UserCrudUseCase interface {
 	GetUserByID(ctx context.Context, userID int64) (*entities.User, error)
 	ListUsers(ctx context.Context) ([]*entities.UserListResponse, error)
 	ChangePassword(ctx context.Context, userID int64, req *entities.ChangePasswordRequest) error
+	UnlockUser(ctx context.Context, userID int64) error
+	ResetTwoFactor(ctx context.Context, userID int64) error
+	ListLoginAttempts(ctx context.Context, filter *entities.LoginAttemptFilter) ([]*entities.LoginAttempt, error)
 }
@@ -10,6 +10,20 @@ import (
 	"net/http"
 )
 
+// Rotas acessíveis enquanto o usuário precisa trocar a senha
+var passwordChangeAllowedPaths = map[string]bool{
+	"/api/private/change-password": true,
+	"/api/private/me":              true,
+}
+
+// Rotas acessíveis enquanto o usuário precisa cadastrar o 2FA exigido pelo seu tipo
+var twoFactorSetupAllowedPaths = map[string]bool{
+	"/api/private/me":                 true,
+	"/api/private/two-factor":         true,
+	"/api/private/two-factor/setup":   true,
+	"/api/private/two-factor/confirm": true,
+}
+
 func NewAuthMiddleware(authRepo *repository_impl.AuthRepositoryImpl, settings *settings_loader.SettingsLoader) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -35,7 +49,19 @@ func NewAuthMiddleware(authRepo *repository_impl.AuthRepositoryImpl, settings *s
 				return
 			}
 
-			// 4. Injetar usuário no contexto
+			// 4. Senha expirada: liberar apenas a troca de senha e os dados do próprio usuário
+			if user.MustChangePassword && !passwordChangeAllowedPaths[r.URL.Path] {
+				http_error.Forbidden(w, "Sua senha expirou. Altere a senha para continuar")
+				return
+			}
+
+			// 5. 2FA exigido pelo tipo de usuário e ainda não cadastrado: liberar apenas o cadastro
+			if user.TwoFactorRequired && !user.TwoFactorEnabled && !twoFactorSetupAllowedPaths[r.URL.Path] {
+				http_error.Forbidden(w, "Configure a autenticação em dois fatores para continuar")
+				return
+			}
+
+			// 6. Injetar usuário no contexto
 			ctx := contextutil.SetUserInContext(r.Context(), user)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
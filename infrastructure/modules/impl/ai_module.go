@@ -2,11 +2,13 @@ package module_impl
 
 import (
 	"encoding/json"
+	"errors"
 	"hackathon-backend/domain/entities"
 	"hackathon-backend/domain/usecases"
 	contextutil "hackathon-backend/utils/context"
 	"hackathon-backend/utils/http_error"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 )
@@ -22,7 +24,15 @@ func NewAIModule(aiUseCase usecases.AIUseCase) *AIModule {
 }
 
 func (m *AIModule) RegisterRoutes(router *mux.Router) {
+	// Prompt livre (apenas admin)
 	router.HandleFunc("/ai/process-text", m.ProcessText).Methods("POST")
+
+	// Templates de prompt (cadastro e versões apenas admin)
+	router.HandleFunc("/ai/templates", m.ListTemplates).Methods("GET")
+	router.HandleFunc("/ai/templates", m.CreateTemplate).Methods("POST")
+	router.HandleFunc("/ai/templates/run", m.RunTemplate).Methods("POST")
+	router.HandleFunc("/ai/templates/{id}", m.UpdateTemplate).Methods("PUT")
+	router.HandleFunc("/ai/templates/{id}/versions", m.ListTemplateVersions).Methods("GET")
 }
 
 func (m *AIModule) ProcessText(w http.ResponseWriter, r *http.Request) {
@@ -50,3 +60,146 @@ func (m *AIModule) ProcessText(w http.ResponseWriter, r *http.Request) {
 		"data":    result,
 	})
 }
+
+// RunTemplate executa um template pelo nome com os campos da iniciativa
+func (m *AIModule) RunTemplate(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	var req entities.RunAIPromptTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	result, err := m.aiUseCase.RunPromptTemplate(r.Context(), &req, user.ID)
+	if err != nil {
+		var invalid *entities.AIPromptVariablesError
+		if errors.As(err, &invalid) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   invalid.Error(),
+				"code":    http.StatusBadRequest,
+				"missing": invalid.Missing,
+				"unknown": invalid.Unknown,
+			})
+			return
+		}
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (m *AIModule) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	templates, err := m.aiUseCase.ListPromptTemplates(r.Context(), user.ID)
+	if err != nil {
+		http_error.InternalServerError(w, "Erro ao listar templates")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    templates,
+		"count":   len(templates),
+	})
+}
+
+func (m *AIModule) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	var req entities.CreateAIPromptTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	template, err := m.aiUseCase.CreatePromptTemplate(r.Context(), &req, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Template criado com sucesso",
+		"data":    template,
+	})
+}
+
+func (m *AIModule) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	var req entities.UpdateAIPromptTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	template, err := m.aiUseCase.UpdatePromptTemplate(r.Context(), id, &req, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Template atualizado com sucesso",
+		"data":    template,
+	})
+}
+
+func (m *AIModule) ListTemplateVersions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	versions, err := m.aiUseCase.ListPromptTemplateVersions(r.Context(), id)
+	if err != nil {
+		http_error.NotFound(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    versions,
+		"count":   len(versions),
+	})
+}
@@ -0,0 +1,126 @@
+package module_impl
+
+import (
+	"encoding/json"
+	"errors"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/domain/usecases"
+	contextutil "hackathon-backend/utils/context"
+	"hackathon-backend/utils/http_error"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type ApprovalModule struct {
+	approvalUseCase usecases.ApprovalUseCase
+}
+
+func NewApprovalModule(approvalUseCase usecases.ApprovalUseCase) *ApprovalModule {
+	return &ApprovalModule{
+		approvalUseCase: approvalUseCase,
+	}
+}
+
+func (m *ApprovalModule) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/approvals", m.ListApprovals).Methods("GET")
+	router.HandleFunc("/approvals/counts", m.CountApprovals).Methods("GET")
+	router.HandleFunc("/approvals/{type}/{id}/decision", m.Decide).Methods("POST")
+}
+
+// ListApprovals lista as decisões pendentes do revisor (?type=initiative|cancellation|prioritization_change)
+func (m *ApprovalModule) ListApprovals(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	itemType := r.URL.Query().Get("type")
+	if itemType != "" && !entities.IsValidReviewItemType(itemType) {
+		http_error.BadRequest(w, "Tipo inválido. Use: initiative, cancellation ou prioritization_change")
+		return
+	}
+
+	// A caixa já vem filtrada pelo que o usuário pode decidir: erros aqui são internos
+	inbox, err := m.approvalUseCase.ListApprovals(r.Context(), user.ID, itemType)
+	if err != nil {
+		http_error.InternalServerError(w, "Erro ao listar aprovações")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    inbox.Items,
+		"count":   len(inbox.Items),
+		"counts":  inbox.Counts,
+	})
+}
+
+// CountApprovals retorna apenas os contadores (badge do dashboard)
+func (m *ApprovalModule) CountApprovals(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	counts, err := m.approvalUseCase.CountApprovals(r.Context(), user.ID)
+	if err != nil {
+		http_error.InternalServerError(w, "Erro ao contar aprovações")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    counts,
+	})
+}
+
+// Decide aprova ou reprova qualquer item da caixa de aprovações
+func (m *ApprovalModule) Decide(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	var req entities.ApprovalDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	if err := m.approvalUseCase.Decide(r.Context(), vars["type"], id, &req, user.ID); err != nil {
+		var conflict *entities.PrioritizationConflictError
+		if errors.As(err, &conflict) {
+			writePrioritizationConflict(w, conflict)
+			return
+		}
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	message := "Item reprovado com sucesso"
+	if req.Decision == entities.ReviewDecisionReturn {
+		message = "Item devolvido para ajustes"
+	} else if req.Approved {
+		message = "Item aprovado com sucesso"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": message,
+	})
+}
@@ -1,7 +1,9 @@
 package module_impl
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/securecookie"
 	"hackathon-backend/domain/entities"
@@ -9,29 +11,61 @@ import (
 	"hackathon-backend/settings_loader"
 	contextutil "hackathon-backend/utils/context"
 	"hackathon-backend/utils/http_error"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// Cookie da etapa intermediária do login (senha correta, aguardando o código 2FA)
+const pendingTwoFactorCookie = "auth_pending_2fa"
+
+// Cookie com state/nonce/PKCE do login corporativo, válido até o callback
+const oidcFlowCookie = "oidc_flow"
+const oidcFlowTimeout = 10 * time.Minute
+
 type AuthModule struct {
-	authUseCase usecases.AuthUseCase
-	settings    *settings_loader.SettingsLoader
+	authUseCase      usecases.AuthUseCase
+	twoFactorUseCase usecases.TwoFactorUseCase
+	ssoUseCase       usecases.SSOUseCase
+	settings         *settings_loader.SettingsLoader
 }
 
-func NewAuthModule(authUseCase usecases.AuthUseCase, settings *settings_loader.SettingsLoader) *AuthModule {
+func NewAuthModule(
+	authUseCase usecases.AuthUseCase,
+	twoFactorUseCase usecases.TwoFactorUseCase,
+	ssoUseCase usecases.SSOUseCase,
+	settings *settings_loader.SettingsLoader,
+) *AuthModule {
 	return &AuthModule{
-		authUseCase: authUseCase,
-		settings:    settings,
+		authUseCase:      authUseCase,
+		twoFactorUseCase: twoFactorUseCase,
+		ssoUseCase:       ssoUseCase,
+		settings:         settings,
 	}
 }
 
 func (m *AuthModule) RegisterPublicRoutes(router *mux.Router) {
 	router.HandleFunc("/login", m.Login).Methods("POST")
+	router.HandleFunc("/login/two-factor", m.LoginTwoFactor).Methods("POST")
 	router.HandleFunc("/logout", m.Logout).Methods("POST")
+
+	// Login corporativo (OpenID Connect)
+	router.HandleFunc("/auth/oidc/login", m.OIDCLogin).Methods("GET")
+	router.HandleFunc("/auth/oidc/callback", m.OIDCCallback).Methods("GET")
 }
 
 func (m *AuthModule) RegisterPrivateRoutes(router *mux.Router) {
 	router.HandleFunc("/me", m.GetCurrentUser).Methods("GET")
+
+	// Autenticação em dois fatores do próprio usuário
+	router.HandleFunc("/two-factor", m.GetTwoFactorStatus).Methods("GET")
+	router.HandleFunc("/two-factor", m.DisableTwoFactor).Methods("DELETE")
+	router.HandleFunc("/two-factor/setup", m.SetupTwoFactor).Methods("POST")
+	router.HandleFunc("/two-factor/confirm", m.ConfirmTwoFactor).Methods("POST")
+	router.HandleFunc("/two-factor/recovery-codes", m.RegenerateRecoveryCodes).Methods("POST")
 }
 
 func (m *AuthModule) Login(w http.ResponseWriter, r *http.Request) {
@@ -48,30 +82,184 @@ func (m *AuthModule) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Executar login
-	user, _, err := m.authUseCase.Login(r.Context(), req.Email, req.Password)
+	user, _, err := m.authUseCase.Login(r.Context(), req.Email, req.Password, m.clientIP(r))
 	if err != nil {
+		var throttled *entities.LoginThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			http_error.TooManyRequests(w, err.Error())
+			return
+		}
 		http_error.Unauthorized(w, err.Error())
 		return
 	}
 
-	// Criar cookie seguro
+	// 2FA ativo: a sessão só é criada após a verificação do código
+	if user.TwoFactorEnabled {
+		if err := m.startPendingTwoFactor(w, user.ID); err != nil {
+			http_error.InternalServerError(w, "Erro ao criar sessão")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":             true,
+			"two_factor_required": true,
+			"message":             "Informe o código do aplicativo autenticador",
+		})
+		return
+	}
+
+	if err := m.startSession(w, user.ID); err != nil {
+		http_error.InternalServerError(w, "Erro ao criar sessão")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"user":    user,
+	})
+}
+
+// OIDCLogin redireciona o navegador para o provedor de identidade
+func (m *AuthModule) OIDCLogin(w http.ResponseWriter, r *http.Request) {
+	authRequest, err := m.ssoUseCase.BeginLogin(r.Context())
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
 	sc := securecookie.New([]byte(m.settings.Security.CookieEncryptionKey), nil)
-	encoded, err := sc.Encode("auth_token", user.ID)
+	sc.MaxAge(int(oidcFlowTimeout.Seconds()))
+
+	encoded, err := sc.Encode(oidcFlowCookie, authRequest.Flow)
+	if err != nil {
+		http_error.InternalServerError(w, "Erro ao iniciar login corporativo")
+		return
+	}
+
+	m.setCookie(w, oidcFlowCookie, encoded, oidcFlowTimeout)
+	http.Redirect(w, r, authRequest.AuthorizationURL, http.StatusFound)
+}
+
+// OIDCCallback recebe o código do provedor, conclui o login e cria a sessão
+func (m *AuthModule) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if providerError := query.Get("error"); providerError != "" {
+		http_error.Unauthorized(w, "Login corporativo recusado: "+providerError)
+		return
+	}
+
+	cookie, err := r.Cookie(oidcFlowCookie)
+	if err != nil {
+		http_error.Unauthorized(w, "Login corporativo não iniciado ou expirado")
+		return
+	}
+	m.setCookie(w, oidcFlowCookie, "", -1)
+
+	sc := securecookie.New([]byte(m.settings.Security.CookieEncryptionKey), nil)
+	sc.MaxAge(int(oidcFlowTimeout.Seconds()))
+
+	var flow entities.OIDCFlowState
+	if err := sc.Decode(oidcFlowCookie, cookie.Value, &flow); err != nil {
+		http_error.Unauthorized(w, "Login corporativo não iniciado ou expirado")
+		return
+	}
+
+	state := query.Get("state")
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(flow.State)) != 1 {
+		http_error.Unauthorized(w, "Parâmetro state inválido")
+		return
+	}
+
+	code := query.Get("code")
+	if code == "" {
+		http_error.BadRequest(w, "Código de autorização ausente")
+		return
+	}
+
+	user, err := m.ssoUseCase.CompleteLogin(r.Context(), code, &flow, m.clientIP(r))
+	if err != nil {
+		http_error.Unauthorized(w, err.Error())
+		return
+	}
+
+	result := map[string]interface{}{
+		"success": true,
+	}
+
+	if user.TwoFactorEnabled {
+		err = m.startPendingTwoFactor(w, user.ID)
+		result["two_factor_required"] = true
+	} else {
+		err = m.startSession(w, user.ID)
+		result["user"] = user
+	}
 	if err != nil {
 		http_error.InternalServerError(w, "Erro ao criar sessão")
 		return
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "auth_token",
-		Value:    encoded,
-		Path:     "/",
-		Domain:   m.settings.Security.CookieDomain,
-		Expires:  time.Now().Add(24 * time.Hour),
-		Secure:   m.settings.Security.CookieSecure,
-		HttpOnly: m.settings.Security.CookieHTTPOnly,
-		SameSite: http.SameSiteLaxMode,
-	})
+	// Navegador volta para o frontend; sem URL configurada, responde em JSON
+	if redirectURL := m.settings.OIDC.PostLoginRedirectURL; redirectURL != "" {
+		if user.TwoFactorEnabled {
+			redirectURL += "?two_factor_required=true"
+		}
+		http.Redirect(w, r, redirectURL, http.StatusFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// LoginTwoFactor conclui o login com o código TOTP ou um código de recuperação
+func (m *AuthModule) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie(pendingTwoFactorCookie)
+	if err != nil {
+		http_error.Unauthorized(w, "Login não iniciado ou expirado")
+		return
+	}
+
+	sc := securecookie.New([]byte(m.settings.Security.CookieEncryptionKey), nil)
+	sc.MaxAge(m.settings.Security.TwoFactorLoginTimeoutSeconds)
+
+	var userID int64
+	if err := sc.Decode(pendingTwoFactorCookie, cookie.Value, &userID); err != nil {
+		http_error.Unauthorized(w, "Login não iniciado ou expirado")
+		return
+	}
+
+	var req entities.TwoFactorLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	if req.Code == "" && req.RecoveryCode == "" {
+		http_error.BadRequest(w, "Código de verificação é obrigatório")
+		return
+	}
+
+	user, err := m.authUseCase.VerifyTwoFactor(r.Context(), userID, req.Code, req.RecoveryCode, m.clientIP(r))
+	if err != nil {
+		var throttled *entities.LoginThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			http_error.TooManyRequests(w, err.Error())
+			return
+		}
+		http_error.Unauthorized(w, err.Error())
+		return
+	}
+
+	if err := m.startSession(w, user.ID); err != nil {
+		http_error.InternalServerError(w, "Erro ao criar sessão")
+		return
+	}
+	m.setCookie(w, pendingTwoFactorCookie, "", -1)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -80,6 +268,67 @@ func (m *AuthModule) Login(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// startPendingTwoFactor grava o cookie da etapa intermediária (aguardando o código 2FA)
+func (m *AuthModule) startPendingTwoFactor(w http.ResponseWriter, userID int64) error {
+	sc := securecookie.New([]byte(m.settings.Security.CookieEncryptionKey), nil)
+	sc.MaxAge(m.settings.Security.TwoFactorLoginTimeoutSeconds)
+
+	encoded, err := sc.Encode(pendingTwoFactorCookie, userID)
+	if err != nil {
+		return err
+	}
+
+	m.setCookie(w, pendingTwoFactorCookie, encoded, time.Duration(m.settings.Security.TwoFactorLoginTimeoutSeconds)*time.Second)
+	return nil
+}
+
+// startSession cria o cookie seguro da sessão
+func (m *AuthModule) startSession(w http.ResponseWriter, userID int64) error {
+	sc := securecookie.New([]byte(m.settings.Security.CookieEncryptionKey), nil)
+	encoded, err := sc.Encode("auth_token", userID)
+	if err != nil {
+		return err
+	}
+
+	m.setCookie(w, "auth_token", encoded, 24*time.Hour)
+	return nil
+}
+
+// setCookie grava um cookie com as flags de segurança configuradas (duração negativa remove o cookie)
+func (m *AuthModule) setCookie(w http.ResponseWriter, name, value string, duration time.Duration) {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Domain:   m.settings.Security.CookieDomain,
+		Expires:  time.Now().Add(duration),
+		Secure:   m.settings.Security.CookieSecure,
+		HttpOnly: m.settings.Security.CookieHTTPOnly,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if duration < 0 {
+		cookie.MaxAge = -1
+	}
+
+	http.SetCookie(w, cookie)
+}
+
+// clientIP retorna o IP do cliente, considerando X-Forwarded-For apenas se configurado
+func (m *AuthModule) clientIP(r *http.Request) string {
+	if m.settings.Security.TrustProxyHeaders {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 func (m *AuthModule) Logout(w http.ResponseWriter, r *http.Request) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     "auth_token",
@@ -112,3 +361,125 @@ func (m *AuthModule) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 		"user":    user,
 	})
 }
+
+func (m *AuthModule) GetTwoFactorStatus(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	status, err := m.twoFactorUseCase.GetStatus(r.Context(), user.ID)
+	if err != nil {
+		http_error.InternalServerError(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    status,
+	})
+}
+
+// SetupTwoFactor gera o segredo e a URI otpauth:// (QR code) para o aplicativo autenticador
+func (m *AuthModule) SetupTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	setup, err := m.twoFactorUseCase.Setup(r.Context(), user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Escaneie o QR code e confirme com o código gerado",
+		"data":    setup,
+	})
+}
+
+// ConfirmTwoFactor ativa o 2FA e retorna os códigos de recuperação (exibidos uma única vez)
+func (m *AuthModule) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	var req entities.TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	codes, err := m.twoFactorUseCase.Confirm(r.Context(), user.ID, req.Code)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Autenticação em dois fatores ativada. Guarde os códigos de recuperação em local seguro",
+		"data":    entities.RecoveryCodesResponse{RecoveryCodes: codes},
+	})
+}
+
+func (m *AuthModule) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	var req entities.TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	codes, err := m.twoFactorUseCase.RegenerateRecoveryCodes(r.Context(), user.ID, req.Code)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Novos códigos de recuperação gerados; os anteriores foram invalidados",
+		"data":    entities.RecoveryCodesResponse{RecoveryCodes: codes},
+	})
+}
+
+func (m *AuthModule) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	var req entities.TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	if err := m.twoFactorUseCase.Disable(r.Context(), user.ID, req.Code); err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Autenticação em dois fatores desativada",
+	})
+}
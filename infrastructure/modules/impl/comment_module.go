@@ -27,6 +27,11 @@ func (m *CommentModule) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/initiatives/{initiativeId}/comments", m.ListComments).Methods("GET")
 	router.HandleFunc("/comments/{id}", m.UpdateComment).Methods("PUT")
 	router.HandleFunc("/comments/{id}", m.DeleteComment).Methods("DELETE")
+	router.HandleFunc("/comments/{id}/reactions", m.AddReaction).Methods("POST")
+	router.HandleFunc("/comments/{id}/reactions", m.RemoveReaction).Methods("DELETE")
+	router.HandleFunc("/comments/{id}/revisions", m.ListRevisions).Methods("GET")
+	router.HandleFunc("/comments/{id}/restore", m.RestoreComment).Methods("POST")
+	router.HandleFunc("/comments/{id}/purge", m.PurgeComment).Methods("DELETE")
 }
 
 func (m *CommentModule) CreateComment(w http.ResponseWriter, r *http.Request) {
@@ -65,6 +70,12 @@ func (m *CommentModule) CreateComment(w http.ResponseWriter, r *http.Request) {
 }
 
 func (m *CommentModule) ListComments(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
 	vars := mux.Vars(r)
 	initiativeID, err := strconv.ParseInt(vars["initiativeId"], 10, 64)
 	if err != nil {
@@ -72,17 +83,23 @@ func (m *CommentModule) ListComments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	comments, err := m.commentUseCase.ListComments(r.Context(), initiativeID)
+	comments, err := m.commentUseCase.ListComments(r.Context(), initiativeID, user.ID)
 	if err != nil {
 		http_error.InternalServerError(w, "Erro ao listar comentários")
 		return
 	}
 
+	total := len(comments)
+	for _, comment := range comments {
+		total += countReplies(comment)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"success": true,
 		"data":    comments,
-		"count":   len(comments),
+		"count":   len(comments), // Comentários de primeiro nível
+		"total":   total,         // Incluindo respostas
 	})
 }
 
@@ -145,3 +162,162 @@ func (m *CommentModule) DeleteComment(w http.ResponseWriter, r *http.Request) {
 		"message": "Comentário deletado com sucesso",
 	})
 }
+
+// AddReaction adiciona uma reação ao comentário ({"emoji": "👍"})
+func (m *CommentModule) AddReaction(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	commentID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID de comentário inválido")
+		return
+	}
+
+	var req entities.CommentReactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	if err := m.commentUseCase.AddReaction(r.Context(), commentID, req.Emoji, user.ID); err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Reação adicionada",
+	})
+}
+
+// RemoveReaction remove a reação do usuário (?emoji=👍)
+func (m *CommentModule) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	commentID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID de comentário inválido")
+		return
+	}
+
+	emoji := r.URL.Query().Get("emoji")
+	if emoji == "" {
+		http_error.BadRequest(w, "Parâmetro emoji é obrigatório")
+		return
+	}
+
+	if err := m.commentUseCase.RemoveReaction(r.Context(), commentID, emoji, user.ID); err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Reação removida",
+	})
+}
+
+// ListRevisions lista as versões anteriores do comentário
+func (m *CommentModule) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	commentID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID de comentário inválido")
+		return
+	}
+
+	revisions, err := m.commentUseCase.ListRevisions(r.Context(), commentID, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    revisions,
+		"count":   len(revisions),
+	})
+}
+
+// RestoreComment restaura um comentário removido (admin)
+func (m *CommentModule) RestoreComment(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	commentID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID de comentário inválido")
+		return
+	}
+
+	comment, err := m.commentUseCase.RestoreComment(r.Context(), commentID, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Comentário restaurado com sucesso",
+		"data":    comment,
+	})
+}
+
+// PurgeComment exclui o comentário definitivamente (admin)
+func (m *CommentModule) PurgeComment(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	commentID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID de comentário inválido")
+		return
+	}
+
+	if err := m.commentUseCase.PurgeComment(r.Context(), commentID, user.ID); err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Comentário excluído definitivamente",
+	})
+}
+
+func countReplies(comment *entities.CommentListResponse) int {
+	count := len(comment.Replies)
+	for _, reply := range comment.Replies {
+		count += countReplies(reply)
+	}
+	return count
+}
@@ -0,0 +1,187 @@
+package module_impl
+
+import (
+	"encoding/json"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/domain/usecases"
+	contextutil "hackathon-backend/utils/context"
+	"hackathon-backend/utils/http_error"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type ConsolidationModule struct {
+	consolidationUseCase usecases.ConsolidationUseCase
+}
+
+func NewConsolidationModule(consolidationUseCase usecases.ConsolidationUseCase) *ConsolidationModule {
+	return &ConsolidationModule{
+		consolidationUseCase: consolidationUseCase,
+	}
+}
+
+func (m *ConsolidationModule) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/prioritization/consolidated", m.GetRanking).Methods("GET")
+	router.HandleFunc("/prioritization/consolidated/generate", m.GenerateRanking).Methods("POST")
+	router.HandleFunc("/prioritization/consolidated/{id}/submit", m.SubmitRanking).Methods("POST")
+	router.HandleFunc("/prioritization/consolidated/{id}/review", m.ReviewRanking).Methods("POST")
+	router.HandleFunc("/prioritization/consolidated/{id}/reopen", m.ReopenRanking).Methods("POST")
+}
+
+// GetRanking busca o ranking consolidado do ano (?year=2025)
+func (m *ConsolidationModule) GetRanking(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	year := time.Now().Year()
+	if y, err := strconv.Atoi(r.URL.Query().Get("year")); err == nil {
+		year = y
+	}
+
+	ranking, err := m.consolidationUseCase.GetRanking(r.Context(), year, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    ranking,
+	})
+}
+
+// GenerateRanking gera (ou regera) o ranking consolidado do ano como rascunho (apenas admin)
+func (m *ConsolidationModule) GenerateRanking(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	var req entities.GenerateConsolidatedRankingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	ranking, err := m.consolidationUseCase.GenerateRanking(r.Context(), &req, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Ranking consolidado gerado com sucesso",
+		"data":    ranking,
+	})
+}
+
+// SubmitRanking envia o ranking para aprovação do comitê (apenas admin)
+func (m *ConsolidationModule) SubmitRanking(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	ranking, err := m.consolidationUseCase.SubmitRanking(r.Context(), id, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Ranking consolidado enviado para aprovação",
+		"data":    ranking,
+	})
+}
+
+// ReviewRanking aprova ou reprova o ranking consolidado (aprovadores configurados)
+func (m *ConsolidationModule) ReviewRanking(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	var req entities.ReviewConsolidatedRankingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	ranking, err := m.consolidationUseCase.ReviewRanking(r.Context(), id, &req, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	message := "Ranking consolidado reprovado"
+	if req.Approved {
+		message = "Ranking consolidado aprovado e bloqueado"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": message,
+		"data":    ranking,
+	})
+}
+
+// ReopenRanking desbloqueia um ranking aprovado (apenas admin)
+func (m *ConsolidationModule) ReopenRanking(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	var req entities.ReopenConsolidatedRankingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	ranking, err := m.consolidationUseCase.ReopenRanking(r.Context(), id, &req, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Ranking consolidado reaberto para edição",
+		"data":    ranking,
+	})
+}
@@ -41,12 +41,20 @@ func (m *InitiativeModule) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/initiatives/{id}/status", m.ChangeStatus).Methods("PATCH")
 	router.HandleFunc("/initiatives/{id}/review", m.ReviewInitiative).Methods("POST") // NOVO
 	router.HandleFunc("/initiatives/{id}/history", m.GetHistory).Methods("GET")
+	router.HandleFunc("/initiatives/{id}/approval-chain", m.GetApprovalChain).Methods("GET")
+	router.HandleFunc("/initiatives/{id}/resubmit", m.ResubmitInitiative).Methods("POST")
+	router.HandleFunc("/initiatives/{id}/review-rounds", m.ListReviewRounds).Methods("GET")
+	router.HandleFunc("/initiatives/{id}/versions", m.ListVersions).Methods("GET")
+	router.HandleFunc("/initiatives/{id}/versions/diff", m.DiffVersions).Methods("GET")
+	router.HandleFunc("/initiatives/{id}/versions/{version}/restore", m.RestoreVersion).Methods("POST")
 	router.HandleFunc("/initiatives/{id}/request-cancellation", m.RequestCancellation).Methods("POST")
 	router.HandleFunc("/my-initiatives", m.GetMyInitiatives).Methods("GET")
 
 	// Rotas de gerenciamento de cancelamentos
 	router.HandleFunc("/cancellation-requests", m.ListPendingCancellations).Methods("GET")
 	router.HandleFunc("/cancellation-requests/{id}/review", m.ReviewCancellation).Methods("POST")
+	router.HandleFunc("/cancellation-requests/{id}/withdraw", m.WithdrawCancellation).Methods("POST")
+	router.HandleFunc("/my-cancellation-requests", m.ListMyCancellations).Methods("GET")
 }
 
 func (m *InitiativeModule) CreateInitiative(w http.ResponseWriter, r *http.Request) {
@@ -162,11 +170,19 @@ func (m *InitiativeModule) ReviewInitiative(w http.ResponseWriter, r *http.Reque
 	if req.Approved {
 		action = "aprovada"
 	}
+	message := fmt.Sprintf("Iniciativa %s com sucesso", action)
+
+	if req.Decision == entities.ReviewDecisionReturn {
+		message = "Iniciativa devolvida ao dono para ajustes"
+	} else if initiative, err := m.initiativeUseCase.GetInitiativeByID(r.Context(), id); err == nil && initiative.Status == entities.StatusSubmitted {
+		// Fluxo de aprovação em etapas: a aprovação pode ser apenas parcial
+		message = "Aprovação registrada; a iniciativa segue para as próximas etapas do fluxo"
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"success": true,
-		"message": fmt.Sprintf("Iniciativa %s com sucesso", action),
+		"message": message,
 	})
 }
 
@@ -326,6 +342,182 @@ func (m *InitiativeModule) GetHistory(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetApprovalChain retorna as etapas do fluxo de aprovação e as decisões de cada uma
+func (m *InitiativeModule) GetApprovalChain(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	chain, err := m.initiativeUseCase.GetApprovalChain(r.Context(), id, user.ID)
+	if err != nil {
+		http_error.NotFound(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    chain,
+	})
+}
+
+// ResubmitInitiative reenvia uma iniciativa devolvida, retornando o que mudou desde a submissão anterior
+func (m *InitiativeModule) ResubmitInitiative(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	// Corpo opcional
+	var req entities.ResubmitInitiativeRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http_error.BadRequest(w, "Payload inválido")
+			return
+		}
+	}
+
+	round, err := m.initiativeUseCase.ResubmitInitiative(r.Context(), id, &req, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    round,
+		"message": "Iniciativa reenviada para revisão",
+	})
+}
+
+// ListReviewRounds lista as rodadas de revisão (submissões, devoluções e diffs)
+func (m *InitiativeModule) ListReviewRounds(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	rounds, err := m.initiativeUseCase.ListReviewRounds(r.Context(), id)
+	if err != nil {
+		http_error.NotFound(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    rounds,
+		"count":   len(rounds),
+	})
+}
+
+// ListVersions lista as versões do conteúdo da iniciativa
+func (m *InitiativeModule) ListVersions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	versions, err := m.initiativeUseCase.ListVersions(r.Context(), id)
+	if err != nil {
+		http_error.NotFound(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    versions,
+		"count":   len(versions),
+	})
+}
+
+// DiffVersions compara duas versões (?from=1&to=3)
+func (m *InitiativeModule) DiffVersions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	from, errFrom := strconv.Atoi(r.URL.Query().Get("from"))
+	to, errTo := strconv.Atoi(r.URL.Query().Get("to"))
+	if errFrom != nil || errTo != nil {
+		http_error.BadRequest(w, "Informe as versões em from e to")
+		return
+	}
+
+	diff, err := m.initiativeUseCase.DiffVersions(r.Context(), id, from, to)
+	if err != nil {
+		http_error.NotFound(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    diff,
+	})
+}
+
+// RestoreVersion restaura o conteúdo de uma versão anterior (apenas admin)
+func (m *InitiativeModule) RestoreVersion(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	version, err := strconv.Atoi(vars["version"])
+	if err != nil {
+		http_error.BadRequest(w, "Versão inválida")
+		return
+	}
+
+	initiative, err := m.initiativeUseCase.RestoreVersion(r.Context(), id, version, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    initiative,
+		"message": fmt.Sprintf("Versão %d restaurada com sucesso", version),
+	})
+}
+
 func (m *InitiativeModule) RequestCancellation(w http.ResponseWriter, r *http.Request) {
 	user, ok := contextutil.GetUserFromContext(r.Context())
 	if !ok {
@@ -418,3 +610,61 @@ func (m *InitiativeModule) ReviewCancellation(w http.ResponseWriter, r *http.Req
 		"message": fmt.Sprintf("Solicitação de cancelamento %s", action),
 	})
 }
+
+// WithdrawCancellation retira uma solicitação pendente feita pelo próprio usuário
+func (m *InitiativeModule) WithdrawCancellation(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	// Corpo opcional: {"reason": "..."}
+	var req entities.WithdrawCancellationRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http_error.BadRequest(w, "Payload inválido")
+			return
+		}
+	}
+
+	if err := m.cancellationUseCase.WithdrawCancellation(r.Context(), id, &req, user.ID); err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Solicitação de cancelamento retirada",
+	})
+}
+
+// ListMyCancellations lista as solicitações de cancelamento feitas pelo usuário
+func (m *InitiativeModule) ListMyCancellations(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	requests, err := m.cancellationUseCase.ListMyCancellations(r.Context(), user.ID)
+	if err != nil {
+		http_error.InternalServerError(w, "Erro ao listar solicitações de cancelamento")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    requests,
+		"count":   len(requests),
+	})
+}
@@ -0,0 +1,104 @@
+package module_impl
+
+import (
+	"encoding/json"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/domain/usecases"
+	contextutil "hackathon-backend/utils/context"
+	"hackathon-backend/utils/http_error"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type NotificationModule struct {
+	notificationUseCase usecases.NotificationUseCase
+}
+
+func NewNotificationModule(notificationUseCase usecases.NotificationUseCase) *NotificationModule {
+	return &NotificationModule{
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+func (m *NotificationModule) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/notifications", m.ListNotifications).Methods("GET")
+	router.HandleFunc("/notifications/read-all", m.MarkAllAsRead).Methods("POST")
+	router.HandleFunc("/notifications/{id}/read", m.MarkAsRead).Methods("POST")
+}
+
+// ListNotifications lista as notificações do usuário (?unread=true&limit=50)
+func (m *NotificationModule) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	filter := &entities.NotificationFilter{
+		UnreadOnly: r.URL.Query().Get("unread") == "true",
+	}
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+		filter.Limit = limit
+	}
+
+	notifications, unread, err := m.notificationUseCase.ListNotifications(r.Context(), user.ID, filter)
+	if err != nil {
+		http_error.InternalServerError(w, "Erro ao listar notificações")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    notifications,
+		"count":   len(notifications),
+		"unread":  unread,
+	})
+}
+
+func (m *NotificationModule) MarkAsRead(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	notificationID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID de notificação inválido")
+		return
+	}
+
+	if err := m.notificationUseCase.MarkAsRead(r.Context(), notificationID, user.ID); err != nil {
+		http_error.NotFound(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Notificação marcada como lida",
+	})
+}
+
+func (m *NotificationModule) MarkAllAsRead(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	if err := m.notificationUseCase.MarkAllAsRead(r.Context(), user.ID); err != nil {
+		http_error.InternalServerError(w, "Erro ao marcar notificações como lidas")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Todas as notificações foram marcadas como lidas",
+	})
+}
@@ -3,6 +3,7 @@ package module_impl
 import (
 	"encoding/json"
 	"github.com/gorilla/mux"
+	"hackathon-backend/domain/entities"
 	"hackathon-backend/domain/usecases"
 	contextutil "hackathon-backend/utils/context"
 	"hackathon-backend/utils/http_error"
@@ -25,6 +26,7 @@ func (m *PermissionModule) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/user-types", m.GetAllUserTypes).Methods("GET") // NOVO
 	router.HandleFunc("/admin/users/{userId}/types/{typeId}", m.AssignUserType).Methods("POST")
 	router.HandleFunc("/admin/users/{userId}/types/{typeId}", m.RemoveUserType).Methods("DELETE")
+	router.HandleFunc("/admin/user-types/{typeId}/two-factor", m.SetTwoFactorRequired).Methods("PUT")
 }
 
 func (m *PermissionModule) GetPersonalInformation(w http.ResponseWriter, r *http.Request) {
@@ -113,3 +115,30 @@ func (m *PermissionModule) RemoveUserType(w http.ResponseWriter, r *http.Request
 		"message": "Tipo removido com sucesso",
 	})
 }
+
+// SetTwoFactorRequired define se o tipo de usuário exige autenticação em dois fatores
+func (m *PermissionModule) SetTwoFactorRequired(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	typeID, err := strconv.ParseInt(vars["typeId"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID de tipo inválido")
+		return
+	}
+
+	var req entities.TwoFactorRequirementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	if err := m.permUseCase.SetTwoFactorRequired(r.Context(), typeID, req.Required); err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Política de autenticação em dois fatores atualizada",
+	})
+}
@@ -0,0 +1,170 @@
+package module_impl
+
+import (
+	"encoding/json"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/domain/usecases"
+	contextutil "hackathon-backend/utils/context"
+	"hackathon-backend/utils/http_error"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type PlanningCycleModule struct {
+	planningCycleUseCase usecases.PlanningCycleUseCase
+}
+
+func NewPlanningCycleModule(planningCycleUseCase usecases.PlanningCycleUseCase) *PlanningCycleModule {
+	return &PlanningCycleModule{
+		planningCycleUseCase: planningCycleUseCase,
+	}
+}
+
+func (m *PlanningCycleModule) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/prioritization/cycles", m.ListCycles).Methods("GET")
+	router.HandleFunc("/prioritization/cycles", m.CreateCycle).Methods("POST")
+	router.HandleFunc("/prioritization/cycles/{id}", m.UpdateCycle).Methods("PUT")
+	router.HandleFunc("/prioritization/cycles/{id}/status", m.GetCycleOverview).Methods("GET")
+	router.HandleFunc("/prioritization/cycles/{id}/close", m.CloseCycle).Methods("POST")
+}
+
+// ListCycles lista os ciclos de planejamento (?year=2025; sem ano: todos)
+func (m *PlanningCycleModule) ListCycles(w http.ResponseWriter, r *http.Request) {
+	year := 0
+	if y, err := strconv.Atoi(r.URL.Query().Get("year")); err == nil {
+		year = y
+	}
+
+	cycles, err := m.planningCycleUseCase.ListCycles(r.Context(), year)
+	if err != nil {
+		http_error.InternalServerError(w, "Erro ao listar ciclos de planejamento")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    cycles,
+		"count":   len(cycles),
+	})
+}
+
+// CreateCycle cadastra um ciclo de planejamento (apenas admin)
+func (m *PlanningCycleModule) CreateCycle(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	var req entities.CreatePlanningCycleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	cycle, err := m.planningCycleUseCase.CreateCycle(r.Context(), &req, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Ciclo de planejamento criado com sucesso",
+		"data":    cycle,
+	})
+}
+
+// UpdateCycle altera a janela ou os setores de um ciclo não encerrado (apenas admin)
+func (m *PlanningCycleModule) UpdateCycle(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	cycleID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	var req entities.UpdatePlanningCycleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	cycle, err := m.planningCycleUseCase.UpdateCycle(r.Context(), cycleID, &req, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Ciclo de planejamento atualizado com sucesso",
+		"data":    cycle,
+	})
+}
+
+// GetCycleOverview mostra a situação do ciclo e os setores que ainda não enviaram (admin/manager)
+func (m *PlanningCycleModule) GetCycleOverview(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	cycleID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	overview, err := m.planningCycleUseCase.GetCycleOverview(r.Context(), cycleID, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    overview,
+	})
+}
+
+// CloseCycle encerra o ciclo antes do prazo e bloqueia as priorizações (apenas admin)
+func (m *PlanningCycleModule) CloseCycle(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	cycleID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	overview, err := m.planningCycleUseCase.CloseCycle(r.Context(), cycleID, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Ciclo de planejamento encerrado. Priorizações bloqueadas",
+		"data":    overview,
+	})
+}
@@ -1,7 +1,9 @@
 package module_impl
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hackathon-backend/domain/entities"
 	"hackathon-backend/domain/usecases"
@@ -28,12 +30,25 @@ func (m *PrioritizationModule) RegisterRoutes(router *mux.Router) {
 	// Rotas para usuários (seu setor)
 	router.HandleFunc("/prioritization", m.GetMyPrioritization).Methods("GET")
 	router.HandleFunc("/prioritization", m.SavePrioritization).Methods("POST")
+	router.HandleFunc("/prioritization/draft", m.SaveDraft).Methods("POST")
+	router.HandleFunc("/prioritization/submit", m.SubmitPrioritization).Methods("POST")
 	router.HandleFunc("/prioritization/request-change", m.RequestChange).Methods("POST")
 
 	// Rotas para admin/manager (todos os setores)
 	router.HandleFunc("/prioritization/all", m.GetAllSectorsPrioritization).Methods("GET")
 	router.HandleFunc("/prioritization/change-requests", m.ListPendingChangeRequests).Methods("GET")
 	router.HandleFunc("/prioritization/change-requests/{id}/review", m.ReviewChangeRequest).Methods("POST")
+
+	router.HandleFunc("/prioritization/{id}/reopen", m.ReopenPrioritization).Methods("POST")
+
+	// Capacidade dos setores (definição apenas admin/manager)
+	router.HandleFunc("/prioritization/capacity", m.ListSectorCapacities).Methods("GET")
+	router.HandleFunc("/prioritization/capacity", m.SetSectorCapacity).Methods("PUT")
+
+	// Versões (rollback apenas admin)
+	router.HandleFunc("/prioritization/{id}/versions", m.ListVersions).Methods("GET")
+	router.HandleFunc("/prioritization/{id}/versions/diff", m.DiffVersions).Methods("GET")
+	router.HandleFunc("/prioritization/{id}/versions/{version}/rollback", m.RollbackVersion).Methods("POST")
 }
 
 // GetMyPrioritization busca a priorização do setor do usuário
@@ -66,8 +81,27 @@ func (m *PrioritizationModule) GetMyPrioritization(w http.ResponseWriter, r *htt
 	})
 }
 
-// SavePrioritization salva a priorização do setor do usuário
+// SavePrioritization salva e envia a priorização do setor do usuário
 func (m *PrioritizationModule) SavePrioritization(w http.ResponseWriter, r *http.Request) {
+	m.handleSave(w, r, m.prioritizationUseCase.SavePrioritization, "Priorização salva com sucesso")
+}
+
+// SaveDraft salva a priorização como rascunho (não bloqueia)
+func (m *PrioritizationModule) SaveDraft(w http.ResponseWriter, r *http.Request) {
+	m.handleSave(w, r, m.prioritizationUseCase.SaveDraft, "Rascunho salvo com sucesso")
+}
+
+// SubmitPrioritization envia o rascunho (ou a ordem informada), bloqueando a priorização
+func (m *PrioritizationModule) SubmitPrioritization(w http.ResponseWriter, r *http.Request) {
+	m.handleSave(w, r, m.prioritizationUseCase.SubmitPrioritization, "Priorização enviada com sucesso")
+}
+
+func (m *PrioritizationModule) handleSave(
+	w http.ResponseWriter,
+	r *http.Request,
+	save func(ctx context.Context, req *entities.SavePrioritizationRequest, userID int64) (*entities.PrioritizationWithInitiatives, error),
+	message string,
+) {
 	user, ok := contextutil.GetUserFromContext(r.Context())
 	if !ok {
 		http_error.Unauthorized(w, "Usuário não autenticado")
@@ -80,8 +114,18 @@ func (m *PrioritizationModule) SavePrioritization(w http.ResponseWriter, r *http
 		return
 	}
 
-	prioritization, err := m.prioritizationUseCase.SavePrioritization(r.Context(), &req, user.ID)
+	prioritization, err := save(r.Context(), &req, user.ID)
 	if err != nil {
+		var invalid *entities.PriorityOrderValidationError
+		if errors.As(err, &invalid) {
+			writePriorityOrderValidationError(w, invalid)
+			return
+		}
+		var outsideWindow *entities.PlanningWindowError
+		if errors.As(err, &outsideWindow) {
+			http_error.Forbidden(w, err.Error())
+			return
+		}
 		http_error.BadRequest(w, err.Error())
 		return
 	}
@@ -90,11 +134,104 @@ func (m *PrioritizationModule) SavePrioritization(w http.ResponseWriter, r *http
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"success": true,
-		"message": "Priorização salva com sucesso",
+		"message": message,
+		"data":    prioritization,
+	})
+}
+
+// ReopenPrioritization volta uma priorização enviada para rascunho (Admin/Manager)
+func (m *PrioritizationModule) ReopenPrioritization(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	var req entities.ReopenPrioritizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	prioritization, err := m.prioritizationUseCase.ReopenPrioritization(r.Context(), id, &req, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Priorização reaberta como rascunho",
 		"data":    prioritization,
 	})
 }
 
+// ListSectorCapacities lista a capacidade dos setores no ano
+func (m *PrioritizationModule) ListSectorCapacities(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	// Pegar ano da query (default: ano atual)
+	yearStr := r.URL.Query().Get("year")
+	year := time.Now().Year()
+	if yearStr != "" {
+		if y, err := strconv.Atoi(yearStr); err == nil {
+			year = y
+		}
+	}
+
+	capacities, err := m.prioritizationUseCase.ListSectorCapacities(r.Context(), year, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    capacities,
+		"count":   len(capacities),
+	})
+}
+
+// SetSectorCapacity define a capacidade de um setor no ano (Admin/Manager)
+func (m *PrioritizationModule) SetSectorCapacity(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	var req entities.SetSectorCapacityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	capacity, err := m.prioritizationUseCase.SetSectorCapacity(r.Context(), &req, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Capacidade do setor atualizada",
+		"data":    capacity,
+	})
+}
+
 // RequestChange solicita mudança na priorização (usuário normal)
 func (m *PrioritizationModule) RequestChange(w http.ResponseWriter, r *http.Request) {
 	user, ok := contextutil.GetUserFromContext(r.Context())
@@ -120,6 +257,11 @@ func (m *PrioritizationModule) RequestChange(w http.ResponseWriter, r *http.Requ
 
 	changeRequest, err := m.prioritizationUseCase.RequestPrioritizationChange(r.Context(), &req, user.ID, year)
 	if err != nil {
+		var invalid *entities.PriorityOrderValidationError
+		if errors.As(err, &invalid) {
+			writePriorityOrderValidationError(w, invalid)
+			return
+		}
 		http_error.BadRequest(w, err.Error())
 		return
 	}
@@ -207,13 +349,23 @@ func (m *PrioritizationModule) ReviewChangeRequest(w http.ResponseWriter, r *htt
 	}
 
 	if err := m.prioritizationUseCase.ReviewPrioritizationChange(r.Context(), requestID, &req, user.ID); err != nil {
+		var conflict *entities.PrioritizationConflictError
+		if errors.As(err, &conflict) {
+			writePrioritizationConflict(w, conflict)
+			return
+		}
+		var invalid *entities.PriorityOrderValidationError
+		if errors.As(err, &invalid) {
+			writePriorityOrderValidationError(w, invalid)
+			return
+		}
 		http_error.BadRequest(w, err.Error())
 		return
 	}
 
 	action := "reprovada"
 	if req.Approved {
-		action = "aprovada e a priorização foi desbloqueada para alteração"
+		action = "aprovada e a nova ordem foi aplicada"
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -222,3 +374,137 @@ func (m *PrioritizationModule) ReviewChangeRequest(w http.ResponseWriter, r *htt
 		"message": fmt.Sprintf("Solicitação de mudança %s", action),
 	})
 }
+
+// ListVersions lista as versões da ordem de prioridade
+func (m *PrioritizationModule) ListVersions(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	versions, err := m.prioritizationUseCase.ListVersions(r.Context(), id, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    versions,
+		"count":   len(versions),
+	})
+}
+
+// DiffVersions compara duas versões (?from=1&to=3)
+func (m *PrioritizationModule) DiffVersions(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	from, errFrom := strconv.Atoi(r.URL.Query().Get("from"))
+	to, errTo := strconv.Atoi(r.URL.Query().Get("to"))
+	if errFrom != nil || errTo != nil {
+		http_error.BadRequest(w, "Informe as versões em from e to")
+		return
+	}
+
+	diff, err := m.prioritizationUseCase.DiffVersions(r.Context(), id, from, to, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    diff,
+	})
+}
+
+// RollbackVersion volta a ordem de prioridade para uma versão anterior (apenas admin)
+func (m *PrioritizationModule) RollbackVersion(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	version, err := strconv.Atoi(vars["version"])
+	if err != nil {
+		http_error.BadRequest(w, "Versão inválida")
+		return
+	}
+
+	var req entities.RollbackPrioritizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	prioritization, err := m.prioritizationUseCase.RollbackVersion(r.Context(), id, version, &req, user.ID)
+	if err != nil {
+		var invalid *entities.PriorityOrderValidationError
+		if errors.As(err, &invalid) {
+			writePriorityOrderValidationError(w, invalid)
+			return
+		}
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    prioritization,
+		"message": fmt.Sprintf("Priorização revertida para a versão %d", version),
+	})
+}
+
+// writePrioritizationConflict responde 409 com a comparação em três vias das ordens
+func writePrioritizationConflict(w http.ResponseWriter, conflict *entities.PrioritizationConflictError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  false,
+		"error":    conflict.Error(),
+		"code":     http.StatusConflict,
+		"conflict": conflict.Conflict,
+	})
+}
+
+// writePriorityOrderValidationError responde 400 listando cada ID rejeitado e o motivo
+func writePriorityOrderValidationError(w http.ResponseWriter, invalid *entities.PriorityOrderValidationError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   invalid.Error(),
+		"code":    http.StatusBadRequest,
+		"issues":  invalid.Issues,
+	})
+}
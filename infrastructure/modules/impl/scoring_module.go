@@ -0,0 +1,154 @@
+package module_impl
+
+import (
+	"encoding/json"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/domain/usecases"
+	contextutil "hackathon-backend/utils/context"
+	"hackathon-backend/utils/http_error"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type ScoringModule struct {
+	scoringUseCase usecases.ScoringUseCase
+}
+
+func NewScoringModule(scoringUseCase usecases.ScoringUseCase) *ScoringModule {
+	return &ScoringModule{
+		scoringUseCase: scoringUseCase,
+	}
+}
+
+func (m *ScoringModule) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/scoring/criteria", m.ListCriteria).Methods("GET")
+	router.HandleFunc("/scoring/criteria", m.CreateCriterion).Methods("POST")
+	router.HandleFunc("/scoring/criteria/{id}", m.UpdateCriterion).Methods("PUT")
+
+	router.HandleFunc("/initiatives/{id}/scores", m.GetInitiativeScore).Methods("GET")
+	router.HandleFunc("/initiatives/{id}/scores", m.ScoreInitiative).Methods("PUT")
+}
+
+func (m *ScoringModule) ListCriteria(w http.ResponseWriter, r *http.Request) {
+	activeOnly := r.URL.Query().Get("active_only") == "true"
+
+	criteria, err := m.scoringUseCase.ListCriteria(r.Context(), activeOnly)
+	if err != nil {
+		http_error.InternalServerError(w, "Erro ao listar critérios")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    criteria,
+		"count":   len(criteria),
+	})
+}
+
+func (m *ScoringModule) CreateCriterion(w http.ResponseWriter, r *http.Request) {
+	var req entities.CreateScoringCriterionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	criterion, err := m.scoringUseCase.CreateCriterion(r.Context(), &req)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Critério criado com sucesso",
+		"data":    criterion,
+	})
+}
+
+// UpdateCriterion altera o critério; mudanças de peso recalculam as pontuações
+func (m *ScoringModule) UpdateCriterion(w http.ResponseWriter, r *http.Request) {
+	criterionID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	var req entities.UpdateScoringCriterionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	criterion, err := m.scoringUseCase.UpdateCriterion(r.Context(), criterionID, &req)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Critério atualizado com sucesso",
+		"data":    criterion,
+	})
+}
+
+// GetInitiativeScore retorna a pontuação da iniciativa com o detalhamento por critério
+func (m *ScoringModule) GetInitiativeScore(w http.ResponseWriter, r *http.Request) {
+	initiativeID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	breakdown, err := m.scoringUseCase.GetInitiativeScore(r.Context(), initiativeID)
+	if err != nil {
+		http_error.NotFound(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    breakdown,
+	})
+}
+
+// ScoreInitiative grava as notas do revisor autenticado
+func (m *ScoringModule) ScoreInitiative(w http.ResponseWriter, r *http.Request) {
+	user, ok := contextutil.GetUserFromContext(r.Context())
+	if !ok {
+		http_error.Unauthorized(w, "Usuário não autenticado")
+		return
+	}
+
+	initiativeID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	var req entities.ScoreInitiativeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http_error.BadRequest(w, "Payload inválido")
+		return
+	}
+
+	breakdown, err := m.scoringUseCase.ScoreInitiative(r.Context(), initiativeID, &req, user.ID)
+	if err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Notas registradas com sucesso",
+		"data":    breakdown,
+	})
+}
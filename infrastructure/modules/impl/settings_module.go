@@ -0,0 +1,33 @@
+package module_impl
+
+import (
+	"encoding/json"
+	"hackathon-backend/settings_loader"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type SettingsModule struct {
+	settings *settings_loader.SettingsLoader
+}
+
+func NewSettingsModule(settings *settings_loader.SettingsLoader) *SettingsModule {
+	return &SettingsModule{
+		settings: settings,
+	}
+}
+
+func (m *SettingsModule) RegisterRoutes(router *mux.Router) {
+	// Apenas admin (controlado via user_type_permissions)
+	router.HandleFunc("/admin/settings", m.GetSettings).Methods("GET")
+}
+
+// GetSettings retorna a configuração efetiva com os segredos mascarados
+func (m *SettingsModule) GetSettings(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    m.settings.Redacted(),
+	})
+}
@@ -28,6 +28,9 @@ func (m *UserCrudModule) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/users/{id}", m.GetUser).Methods("GET")
 	router.HandleFunc("/users/{id}", m.UpdateUser).Methods("PUT")
 	router.HandleFunc("/users/{id}", m.DeleteUser).Methods("DELETE")
+	router.HandleFunc("/users/{id}/unlock", m.UnlockUser).Methods("POST")
+	router.HandleFunc("/users/{id}/two-factor", m.ResetTwoFactor).Methods("DELETE")
+	router.HandleFunc("/login-attempts", m.ListLoginAttempts).Methods("GET")
 
 	// Rota para qualquer usuário autenticado mudar sua própria senha
 	router.HandleFunc("/change-password", m.ChangePassword).Methods("POST")
@@ -175,3 +178,73 @@ func (m *UserCrudModule) ChangePassword(w http.ResponseWriter, r *http.Request)
 		"message": "Senha alterada com sucesso",
 	})
 }
+
+// UnlockUser remove o bloqueio de login de um usuário (admin)
+func (m *UserCrudModule) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	if err := m.userCrudUseCase.UnlockUser(r.Context(), userID); err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Usuário desbloqueado com sucesso",
+	})
+}
+
+// ResetTwoFactor remove o 2FA de um usuário que perdeu o dispositivo (admin)
+func (m *UserCrudModule) ResetTwoFactor(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http_error.BadRequest(w, "ID inválido")
+		return
+	}
+
+	if err := m.userCrudUseCase.ResetTwoFactor(r.Context(), userID); err != nil {
+		http_error.BadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Autenticação em dois fatores redefinida com sucesso",
+	})
+}
+
+// ListLoginAttempts lista a auditoria de tentativas de login (admin)
+func (m *UserCrudModule) ListLoginAttempts(w http.ResponseWriter, r *http.Request) {
+	filter := &entities.LoginAttemptFilter{
+		Email:      r.URL.Query().Get("email"),
+		IPAddress:  r.URL.Query().Get("ip"),
+		OnlyFailed: r.URL.Query().Get("failed_only") == "true",
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = limit
+		}
+	}
+
+	attempts, err := m.userCrudUseCase.ListLoginAttempts(r.Context(), filter)
+	if err != nil {
+		http_error.InternalServerError(w, "Erro ao listar tentativas de login")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data":    attempts,
+		"count":   len(attempts),
+	})
+}
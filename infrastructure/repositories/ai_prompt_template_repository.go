@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type AIPromptTemplateRepository interface {
+	Create(ctx context.Context, template *entities.AIPromptTemplate) error
+	Update(ctx context.Context, template *entities.AIPromptTemplate, newVersion bool, userID int64) error
+	GetByID(ctx context.Context, templateID int64) (*entities.AIPromptTemplate, error)
+	GetByName(ctx context.Context, name string) (*entities.AIPromptTemplate, error)
+	List(ctx context.Context, activeOnly bool) ([]*entities.AIPromptTemplate, error)
+	ListVersions(ctx context.Context, templateID int64) ([]*entities.AIPromptTemplateVersion, error)
+}
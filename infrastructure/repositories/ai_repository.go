@@ -7,4 +7,5 @@ import (
 
 type AIRepository interface {
 	ProcessText(ctx context.Context, req *entities.AIRequest) (*entities.AIResponse, error)
+	Complete(ctx context.Context, prompt string, params *entities.AIModelParams) (*entities.AIResponse, error)
 }
@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type ApprovalDecisionRepository interface {
+	Create(ctx context.Context, decision *entities.ApprovalDecision) error
+	ListByInitiative(ctx context.Context, initiativeID int64) ([]*entities.ApprovalDecision, error)
+}
@@ -14,4 +14,19 @@ type AuthRepository interface {
 	ListAllUsers(ctx context.Context) ([]*entities.User, error)
 	UpdatePassword(ctx context.Context, userID int64, hashedPassword string) error
 	RemoveAllUserTypes(ctx context.Context, userID int64) error
+
+	// Histórico e expiração de senhas
+	RehashPassword(ctx context.Context, userID int64, hashedPassword string) error
+	SetMustChangePassword(ctx context.Context, userID int64, mustChange bool) error
+	AddPasswordHistory(ctx context.Context, userID int64, hashedPassword string) error
+	CreateUserWithPasswordHistory(ctx context.Context, user *entities.User) error
+	UpdatePasswordWithHistory(ctx context.Context, userID int64, hashedPassword string) error
+	ListPasswordHistory(ctx context.Context, userID int64, limit int) ([]string, error)
+
+	// Resolve menções (@usuario ou @email) para usuários
+	FindUsersByHandles(ctx context.Context, handles []string) ([]*entities.User, error)
+
+	// Vínculo com a identidade do provedor OIDC (SSO)
+	GetUserByOIDCSubject(ctx context.Context, issuer, subject string) (*entities.User, error)
+	LinkOIDCSubject(ctx context.Context, userID int64, issuer, subject string) error
 }
@@ -12,4 +12,8 @@ type CancellationRepository interface {
 	ListPending(ctx context.Context) ([]*entities.InitiativeCancellationRequest, error)
 	UpdateStatus(ctx context.Context, requestID int64, status string, reviewedByUserID int64, reviewReason string) error
 	HasPendingRequest(ctx context.Context, initiativeID int64) (bool, error)
+	ListByRequester(ctx context.Context, userID int64) ([]*entities.InitiativeCancellationRequest, error)
+	GetLatestRejected(ctx context.Context, initiativeID int64) (*entities.InitiativeCancellationRequest, error)
+	Withdraw(ctx context.Context, requestID int64, reason string) error
+	Expire(ctx context.Context, requestID int64, reason string) error
 }
@@ -7,9 +7,22 @@ import (
 
 type CommentRepository interface {
 	Create(ctx context.Context, comment *entities.Comment) error
-	Update(ctx context.Context, comment *entities.Comment) error
-	Delete(ctx context.Context, commentID int64) error
+	Update(ctx context.Context, comment *entities.Comment, editedBy int64) error
+	Delete(ctx context.Context, commentID int64) error // Remoção definitiva (purge)
+	SoftDelete(ctx context.Context, commentID, deletedBy int64) error
+	Restore(ctx context.Context, commentID int64) error
+	ListRevisions(ctx context.Context, commentID int64) ([]*entities.CommentRevision, error)
 	GetByID(ctx context.Context, commentID int64) (*entities.Comment, error)
 	ListByInitiative(ctx context.Context, initiativeID int64) ([]*entities.Comment, error)
 	CountByInitiative(ctx context.Context, initiativeID int64) (int, error)
+
+	// Menções (@usuario)
+	SetMentions(ctx context.Context, commentID int64, userIDs []int64) error
+	ListMentions(ctx context.Context, commentID int64) ([]*entities.CommentMention, error)
+	ListMentionsByInitiative(ctx context.Context, initiativeID int64) ([]*entities.CommentMention, error)
+
+	// Reações
+	AddReaction(ctx context.Context, reaction *entities.CommentReaction) error
+	RemoveReaction(ctx context.Context, commentID, userID int64, emoji string) error
+	ListReactionsByInitiative(ctx context.Context, initiativeID int64) ([]*entities.CommentReaction, error)
 }
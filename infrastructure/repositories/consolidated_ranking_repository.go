@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type ConsolidatedRankingRepository interface {
+	Save(ctx context.Context, ranking *entities.ConsolidatedRanking) error
+	GetByYear(ctx context.Context, year int) (*entities.ConsolidatedRanking, error)
+	GetByID(ctx context.Context, rankingID int64) (*entities.ConsolidatedRanking, error)
+	Submit(ctx context.Context, rankingID int64, userID int64) error
+	Review(ctx context.Context, rankingID int64, status string, userID int64, reason string) error
+	Reopen(ctx context.Context, rankingID int64, userID int64, reason string) error
+}
@@ -0,0 +1,278 @@
+package repository_impl
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"hackathon-backend/domain/entities"
+)
+
+type AIPromptTemplateRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewAIPromptTemplateRepositoryImpl(db *sql.DB) *AIPromptTemplateRepositoryImpl {
+	return &AIPromptTemplateRepositoryImpl{db: db}
+}
+
+// Create grava o template na versão 1
+func (r *AIPromptTemplateRepositoryImpl) Create(ctx context.Context, template *entities.AIPromptTemplate) error {
+	variablesJSON, err := json.Marshal(template.Variables)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO ai_prompt_templates (name, description, template, variables, version, temperature, max_tokens, model,
+		                                 active, created_by_user_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, 1, $5, $6, $7, true, $8, NOW(), NOW())
+		RETURNING id, version, active, created_at, updated_at
+	`
+
+	err = tx.QueryRowContext(ctx, query,
+		template.Name,
+		template.Description,
+		template.Template,
+		variablesJSON,
+		template.Temperature,
+		template.MaxTokens,
+		template.Model,
+		template.CreatedByUserID,
+	).Scan(&template.ID, &template.Version, &template.Active, &template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := insertAIPromptTemplateVersion(ctx, tx, template, variablesJSON, template.CreatedByUserID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update grava as alterações; com newVersion, incrementa a versão e registra o histórico
+func (r *AIPromptTemplateRepositoryImpl) Update(ctx context.Context, template *entities.AIPromptTemplate, newVersion bool, userID int64) error {
+	variablesJSON, err := json.Marshal(template.Variables)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE ai_prompt_templates
+		SET description = $1, template = $2, variables = $3, temperature = $4, max_tokens = $5, model = $6,
+		    active = $7, version = CASE WHEN $8 THEN version + 1 ELSE version END, updated_at = NOW()
+		WHERE id = $9
+		RETURNING version, updated_at
+	`
+
+	err = tx.QueryRowContext(ctx, query,
+		template.Description,
+		template.Template,
+		variablesJSON,
+		template.Temperature,
+		template.MaxTokens,
+		template.Model,
+		template.Active,
+		newVersion,
+		template.ID,
+	).Scan(&template.Version, &template.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if newVersion {
+		if err := insertAIPromptTemplateVersion(ctx, tx, template, variablesJSON, &userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func insertAIPromptTemplateVersion(ctx context.Context, tx *sql.Tx, template *entities.AIPromptTemplate, variablesJSON []byte, userID *int64) error {
+	query := `
+		INSERT INTO ai_prompt_template_versions (template_id, version, template, variables, temperature, max_tokens, model,
+		                                         created_by_user_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+	`
+
+	_, err := tx.ExecContext(ctx, query,
+		template.ID,
+		template.Version,
+		template.Template,
+		variablesJSON,
+		template.Temperature,
+		template.MaxTokens,
+		template.Model,
+		userID,
+	)
+	return err
+}
+
+func (r *AIPromptTemplateRepositoryImpl) GetByID(ctx context.Context, templateID int64) (*entities.AIPromptTemplate, error) {
+	query := `
+		SELECT id, name, description, template, variables, version, temperature, max_tokens, model,
+		       active, created_by_user_id, created_at, updated_at
+		FROM ai_prompt_templates
+		WHERE id = $1
+	`
+
+	return scanAIPromptTemplate(r.db.QueryRowContext(ctx, query, templateID))
+}
+
+// GetByName busca o template pelo nome, sem diferenciar maiúsculas
+func (r *AIPromptTemplateRepositoryImpl) GetByName(ctx context.Context, name string) (*entities.AIPromptTemplate, error) {
+	query := `
+		SELECT id, name, description, template, variables, version, temperature, max_tokens, model,
+		       active, created_by_user_id, created_at, updated_at
+		FROM ai_prompt_templates
+		WHERE LOWER(name) = LOWER($1)
+	`
+
+	return scanAIPromptTemplate(r.db.QueryRowContext(ctx, query, name))
+}
+
+func (r *AIPromptTemplateRepositoryImpl) List(ctx context.Context, activeOnly bool) ([]*entities.AIPromptTemplate, error) {
+	query := `
+		SELECT id, name, description, template, variables, version, temperature, max_tokens, model,
+		       active, created_by_user_id, created_at, updated_at
+		FROM ai_prompt_templates
+	`
+	if activeOnly {
+		query += " WHERE active = true"
+	}
+	query += " ORDER BY name ASC"
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []*entities.AIPromptTemplate
+	for rows.Next() {
+		template, err := scanAIPromptTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+func (r *AIPromptTemplateRepositoryImpl) ListVersions(ctx context.Context, templateID int64) ([]*entities.AIPromptTemplateVersion, error) {
+	query := `
+		SELECT v.template_id, v.version, v.template, v.variables, v.temperature, v.max_tokens, v.model,
+		       v.created_by_user_id, u.name, v.created_at
+		FROM ai_prompt_template_versions v
+		LEFT JOIN users u ON u.id = v.created_by_user_id
+		WHERE v.template_id = $1
+		ORDER BY v.version ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []*entities.AIPromptTemplateVersion
+	for rows.Next() {
+		version := &entities.AIPromptTemplateVersion{}
+		var variablesJSON []byte
+		var temperature sql.NullFloat64
+		var maxTokens, createdBy sql.NullInt64
+		var createdByName sql.NullString
+
+		err := rows.Scan(
+			&version.TemplateID,
+			&version.Version,
+			&version.Template,
+			&variablesJSON,
+			&temperature,
+			&maxTokens,
+			&version.Model,
+			&createdBy,
+			&createdByName,
+			&version.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(variablesJSON, &version.Variables); err != nil {
+			return nil, err
+		}
+		version.Temperature, version.MaxTokens = nullModelParams(temperature, maxTokens)
+		if createdBy.Valid {
+			version.CreatedByUserID = &createdBy.Int64
+		}
+		version.CreatedByName = createdByName.String
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+func scanAIPromptTemplate(row rowScanner) (*entities.AIPromptTemplate, error) {
+	template := &entities.AIPromptTemplate{}
+	var variablesJSON []byte
+	var temperature sql.NullFloat64
+	var maxTokens, createdBy sql.NullInt64
+
+	err := row.Scan(
+		&template.ID,
+		&template.Name,
+		&template.Description,
+		&template.Template,
+		&variablesJSON,
+		&template.Version,
+		&temperature,
+		&maxTokens,
+		&template.Model,
+		&template.Active,
+		&createdBy,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(variablesJSON, &template.Variables); err != nil {
+		return nil, err
+	}
+	template.Temperature, template.MaxTokens = nullModelParams(temperature, maxTokens)
+	if createdBy.Valid {
+		template.CreatedByUserID = &createdBy.Int64
+	}
+
+	return template, nil
+}
+
+func nullModelParams(temperature sql.NullFloat64, maxTokens sql.NullInt64) (*float64, *int) {
+	var t *float64
+	var m *int
+	if temperature.Valid {
+		t = &temperature.Float64
+	}
+	if maxTokens.Valid {
+		n := int(maxTokens.Int64)
+		m = &n
+	}
+	return t, m
+}
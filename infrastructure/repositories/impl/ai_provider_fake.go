@@ -0,0 +1,39 @@
+package repository_impl
+
+import (
+	"context"
+	"hackathon-backend/settings_loader"
+	"strings"
+)
+
+// fakeProvider devolve respostas determinísticas sem rede: o prompt ecoado, limitado a MaxTokens palavras
+type fakeProvider struct{}
+
+func newFakeProvider(settings *settings_loader.SettingsLoader) AIProvider {
+	return &fakeProvider{}
+}
+
+func (p *fakeProvider) Name() string  { return settings_loader.AIProviderFake }
+func (p *fakeProvider) Model() string { return "fake-echo" }
+
+func (p *fakeProvider) Generate(ctx context.Context, gen *AIGeneration) (*AICompletion, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	words := strings.Fields(gen.Prompt)
+	output := words
+	finishReason := "stop"
+	if gen.MaxTokens > 0 && len(output) > gen.MaxTokens {
+		output = output[:gen.MaxTokens]
+		finishReason = "length"
+	}
+
+	return &AICompletion{
+		Text:             "[fake] " + strings.Join(output, " "),
+		PromptTokens:     len(words),
+		CompletionTokens: len(output) + 1,
+		TotalTokens:      len(words) + len(output) + 1,
+		FinishReason:     finishReason,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package repository_impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hackathon-backend/settings_loader"
+	"net/http"
+)
+
+type geminiProvider struct {
+	apiKey string
+	model  string
+	client *http.Client
+}
+
+func newGeminiProvider(settings *settings_loader.SettingsLoader) AIProvider {
+	return &geminiProvider{
+		apiKey: settings.GetGeminiAPIKey(),
+		model:  settings.GetGeminiModel(),
+		client: newAIHTTPClient(settings.GetAIRequestTimeout()),
+	}
+}
+
+// Estruturas para comunicação com a API Gemini
+type geminiRequest struct {
+	Contents         []geminiContent        `json:"contents"`
+	GenerationConfig geminiGenerationConfig `json:"generationConfig"`
+}
+
+type geminiContent struct {
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiGenerationConfig struct {
+	Temperature     float64 `json:"temperature"`
+	MaxOutputTokens int     `json:"maxOutputTokens"`
+}
+
+type geminiResponse struct {
+	Candidates []struct {
+		Content struct {
+			Parts []struct {
+				Text string `json:"text"`
+			} `json:"parts"`
+		} `json:"content"`
+		FinishReason string `json:"finishReason"`
+	} `json:"candidates"`
+	UsageMetadata struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+		TotalTokenCount      int `json:"totalTokenCount"`
+	} `json:"usageMetadata"`
+}
+
+func (p *geminiProvider) Name() string  { return settings_loader.AIProviderGemini }
+func (p *geminiProvider) Model() string { return p.model }
+
+func (p *geminiProvider) Generate(ctx context.Context, gen *AIGeneration) (*AICompletion, error) {
+	req := geminiRequest{
+		Contents: []geminiContent{
+			{Parts: []geminiPart{{Text: gen.Prompt}}},
+		},
+		GenerationConfig: geminiGenerationConfig{
+			Temperature:     gen.Temperature,
+			MaxOutputTokens: gen.MaxTokens,
+		},
+	}
+
+	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent", modelOrDefault(gen, p.model))
+	headers := map[string]string{"X-Goog-Api-Key": p.apiKey}
+
+	var resp geminiResponse
+	if err := postJSON(ctx, p.client, "Gemini", url, headers, req, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Candidates) == 0 {
+		return nil, errors.New("resposta vazia da API Gemini")
+	}
+	if len(resp.Candidates[0].Content.Parts) == 0 {
+		return nil, errors.New("resposta sem conteúdo da API Gemini")
+	}
+
+	return &AICompletion{
+		Text:             resp.Candidates[0].Content.Parts[0].Text,
+		PromptTokens:     resp.UsageMetadata.PromptTokenCount,
+		CompletionTokens: resp.UsageMetadata.CandidatesTokenCount,
+		TotalTokens:      resp.UsageMetadata.TotalTokenCount,
+		FinishReason:     resp.Candidates[0].FinishReason,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package repository_impl
+
+import (
+	"context"
+	"hackathon-backend/settings_loader"
+	"net/http"
+	"strings"
+)
+
+// ollamaProvider chama um servidor local no formato do Ollama (/api/generate)
+type ollamaProvider struct {
+	baseURL string
+	model   string
+	client  *http.Client
+}
+
+func newOllamaProvider(settings *settings_loader.SettingsLoader) AIProvider {
+	return &ollamaProvider{
+		baseURL: strings.TrimRight(settings.AI.OllamaBaseURL, "/"),
+		model:   settings.AI.OllamaModel,
+		client:  newAIHTTPClient(settings.AI.OllamaTimeout),
+	}
+}
+
+type ollamaRequest struct {
+	Model   string        `json:"model"`
+	Prompt  string        `json:"prompt"`
+	Stream  bool          `json:"stream"`
+	Options ollamaOptions `json:"options"`
+}
+
+type ollamaOptions struct {
+	Temperature float64 `json:"temperature"`
+	NumPredict  int     `json:"num_predict"`
+}
+
+type ollamaResponse struct {
+	Response        string `json:"response"`
+	DoneReason      string `json:"done_reason"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+}
+
+func (p *ollamaProvider) Name() string  { return settings_loader.AIProviderOllama }
+func (p *ollamaProvider) Model() string { return p.model }
+
+func (p *ollamaProvider) Generate(ctx context.Context, gen *AIGeneration) (*AICompletion, error) {
+	req := ollamaRequest{
+		Model:  modelOrDefault(gen, p.model),
+		Prompt: gen.Prompt,
+		Stream: false,
+		Options: ollamaOptions{
+			Temperature: gen.Temperature,
+			NumPredict:  gen.MaxTokens,
+		},
+	}
+
+	var resp ollamaResponse
+	if err := postJSON(ctx, p.client, "Ollama", p.baseURL+"/api/generate", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &AICompletion{
+		Text:             resp.Response,
+		PromptTokens:     resp.PromptEvalCount,
+		CompletionTokens: resp.EvalCount,
+		TotalTokens:      resp.PromptEvalCount + resp.EvalCount,
+		FinishReason:     resp.DoneReason,
+	}, nil
+}
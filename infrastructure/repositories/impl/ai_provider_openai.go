@@ -0,0 +1,84 @@
+package repository_impl
+
+import (
+	"context"
+	"errors"
+	"hackathon-backend/settings_loader"
+	"net/http"
+	"strings"
+)
+
+// openAIProvider atende a API oficial e qualquer servidor compatível com /chat/completions
+type openAIProvider struct {
+	baseURL string
+	apiKey  string
+	model   string
+	client  *http.Client
+}
+
+func newOpenAIProvider(settings *settings_loader.SettingsLoader) AIProvider {
+	return &openAIProvider{
+		baseURL: strings.TrimRight(settings.AI.OpenAIBaseURL, "/"),
+		apiKey:  settings.AI.OpenAIAPIKey,
+		model:   settings.AI.OpenAIModel,
+		client:  newAIHTTPClient(settings.AI.OpenAITimeout),
+	}
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIRequest struct {
+	Model       string          `json:"model"`
+	Messages    []openAIMessage `json:"messages"`
+	Temperature float64         `json:"temperature"`
+	MaxTokens   int             `json:"max_tokens"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message      openAIMessage `json:"message"`
+		FinishReason string        `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+func (p *openAIProvider) Name() string  { return settings_loader.AIProviderOpenAI }
+func (p *openAIProvider) Model() string { return p.model }
+
+func (p *openAIProvider) Generate(ctx context.Context, gen *AIGeneration) (*AICompletion, error) {
+	req := openAIRequest{
+		Model:       modelOrDefault(gen, p.model),
+		Messages:    []openAIMessage{{Role: "user", Content: gen.Prompt}},
+		Temperature: gen.Temperature,
+		MaxTokens:   gen.MaxTokens,
+	}
+
+	headers := map[string]string{}
+	if p.apiKey != "" {
+		headers["Authorization"] = "Bearer " + p.apiKey
+	}
+
+	var resp openAIResponse
+	if err := postJSON(ctx, p.client, "OpenAI", p.baseURL+"/chat/completions", headers, req, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, errors.New("resposta vazia da API OpenAI")
+	}
+
+	return &AICompletion{
+		Text:             resp.Choices[0].Message.Content,
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+		TotalTokens:      resp.Usage.TotalTokens,
+		FinishReason:     resp.Choices[0].FinishReason,
+	}, nil
+}
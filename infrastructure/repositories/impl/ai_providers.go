@@ -0,0 +1,188 @@
+package repository_impl
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hackathon-backend/settings_loader"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Espera máxima entre tentativas
+const aiMaxBackoff = 10 * time.Second
+
+// Parâmetros de geração enviados ao provedor
+type AIGeneration struct {
+	Prompt      string
+	Model       string // Vazio = modelo configurado no provedor
+	Temperature float64
+	MaxTokens   int
+}
+
+func modelOrDefault(gen *AIGeneration, model string) string {
+	if gen.Model != "" {
+		return gen.Model
+	}
+	return model
+}
+
+// Resultado bruto do provedor
+type AICompletion struct {
+	Text             string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	FinishReason     string
+}
+
+// AIProvider é implementado por cada backend de IA (Gemini, OpenAI, Ollama, fake)
+type AIProvider interface {
+	Name() string
+	Model() string
+	Generate(ctx context.Context, gen *AIGeneration) (*AICompletion, error)
+}
+
+type AIProviderFactory func(settings *settings_loader.SettingsLoader) AIProvider
+
+var aiProviders = map[string]AIProviderFactory{
+	settings_loader.AIProviderGemini: newGeminiProvider,
+	settings_loader.AIProviderOpenAI: newOpenAIProvider,
+	settings_loader.AIProviderOllama: newOllamaProvider,
+	settings_loader.AIProviderFake:   newFakeProvider,
+}
+
+// RegisterAIProvider registra (ou substitui) um provedor selecionável por ai.provider
+func RegisterAIProvider(name string, factory AIProviderFactory) {
+	aiProviders[name] = factory
+}
+
+// Erro HTTP devolvido pelo provedor; 429 e 5xx podem ser repetidos
+type aiHTTPError struct {
+	Provider   string
+	StatusCode int
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *aiHTTPError) Error() string {
+	return fmt.Sprintf("erro da API %s (HTTP %d): %s", e.Provider, e.StatusCode, e.Message)
+}
+
+func (e *aiHTTPError) retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// newAIHTTPClient cria o cliente com verificação TLS padrão (CAs do sistema) e o timeout do provedor
+func newAIHTTPClient(timeoutSeconds int) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+	return &http.Client{
+		Timeout:   time.Duration(timeoutSeconds) * time.Second,
+		Transport: transport,
+	}
+}
+
+// postJSON envia o payload e decodifica a resposta em out; status fora de 2xx vira aiHTTPError
+func postJSON(ctx context.Context, client *http.Client, provider, url string, headers map[string]string, payload, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("erro ao montar payload: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("erro ao chamar API %s: %w", provider, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("erro ao ler resposta: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		httpErr := &aiHTTPError{
+			Provider:   provider,
+			StatusCode: resp.StatusCode,
+			Message:    providerErrorMessage(respBody),
+		}
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			httpErr.RetryAfter = time.Duration(seconds) * time.Second
+		}
+		return httpErr
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("erro ao decodificar resposta: %w", err)
+	}
+	return nil
+}
+
+// providerErrorMessage extrai a mensagem dos formatos {"error":{"message"}} e {"error":"..."}
+func providerErrorMessage(body []byte) string {
+	var nested struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &nested) == nil && nested.Error.Message != "" {
+		return nested.Error.Message
+	}
+
+	var flat struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &flat) == nil && flat.Error != "" {
+		return flat.Error
+	}
+
+	if len(body) > 200 {
+		body = body[:200]
+	}
+	return string(body)
+}
+
+// generateWithRetry chama o provedor repetindo em 429/5xx com espera exponencial (ou Retry-After)
+func generateWithRetry(ctx context.Context, provider AIProvider, gen *AIGeneration, maxRetries int, backoff time.Duration) (*AICompletion, int, error) {
+	for attempt := 1; ; attempt++ {
+		completion, err := provider.Generate(ctx, gen)
+		if err == nil {
+			return completion, attempt, nil
+		}
+
+		var httpErr *aiHTTPError
+		if !errors.As(err, &httpErr) || !httpErr.retryable() || attempt > maxRetries {
+			return nil, attempt, err
+		}
+
+		wait := backoff << (attempt - 1)
+		if httpErr.RetryAfter > 0 {
+			wait = httpErr.RetryAfter
+		}
+		if wait > aiMaxBackoff {
+			wait = aiMaxBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, attempt, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
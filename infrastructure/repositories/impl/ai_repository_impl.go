@@ -1,81 +1,37 @@
 package repository_impl
 
 import (
-	"bytes"
 	"context"
-	"crypto/tls"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"hackathon-backend/domain/entities"
 	"hackathon-backend/settings_loader"
-	"net/http"
+	"log"
 	"time"
 )
 
 type AIRepositoryImpl struct {
 	settings *settings_loader.SettingsLoader
-	client   *http.Client
+	provider AIProvider
 }
 
 func NewAIRepositoryImpl(settings *settings_loader.SettingsLoader) *AIRepositoryImpl {
-	timeout := time.Duration(settings.GetAIRequestTimeout()) * time.Second
-
-	// Configure HTTP client with TLS settings
-	// Note: InsecureSkipVerify is set to true for development
-	// In production, ensure proper CA certificates are installed
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: true,
-		},
+	factory, ok := aiProviders[settings.GetAIProvider()]
+	if !ok {
+		log.Printf("⚠️  Provedor de IA %q não registrado, usando %s", settings.GetAIProvider(), settings_loader.AIProviderGemini)
+		factory = aiProviders[settings_loader.AIProviderGemini]
 	}
 
 	return &AIRepositoryImpl{
 		settings: settings,
-		client: &http.Client{
-			Timeout:   timeout,
-			Transport: transport,
-		},
+		provider: factory(settings),
 	}
 }
 
-// Estruturas para comunicação com a API Gemini
-type geminiRequest struct {
-	Contents []geminiContent `json:"contents"`
-	GenerationConfig geminiGenerationConfig `json:"generationConfig"`
-}
-
-type geminiContent struct {
-	Parts []geminiPart `json:"parts"`
-}
-
-type geminiPart struct {
-	Text string `json:"text"`
-}
-
-type geminiGenerationConfig struct {
-	Temperature float64 `json:"temperature"`
-	MaxOutputTokens int `json:"maxOutputTokens"`
-}
-
-type geminiResponse struct {
-	Candidates []struct {
-		Content struct {
-			Parts []struct {
-				Text string `json:"text"`
-			} `json:"parts"`
-		} `json:"content"`
-	} `json:"candidates"`
-	Error *struct {
-		Message string `json:"message"`
-		Code    int    `json:"code"`
-	} `json:"error,omitempty"`
-}
-
 func (r *AIRepositoryImpl) ProcessText(ctx context.Context, req *entities.AIRequest) (*entities.AIResponse, error) {
 	// Verificar se IA está habilitada
 	if !r.settings.IsAIEnabled() {
-		return nil, errors.New("funcionalidade de IA não está habilitada. Configure ai.gemini_api_key no settings.toml")
+		return nil, fmt.Errorf("funcionalidade de IA não está habilitada. Configure o provedor %q na seção [ai] do settings.toml", r.provider.Name())
 	}
 
 	// Validações
@@ -92,80 +48,55 @@ func (r *AIRepositoryImpl) ProcessText(ctx context.Context, req *entities.AIRequ
 	}
 
 	// Combinar prompt com texto
-	fullPrompt := fmt.Sprintf("%s\n\nTexto:\n%s", req.Prompt, req.Text)
-
-	// Montar requisição para Gemini
-	geminiReq := geminiRequest{
-		Contents: []geminiContent{
-			{
-				Parts: []geminiPart{
-					{Text: fullPrompt},
-				},
-			},
-		},
-		GenerationConfig: geminiGenerationConfig{
-			Temperature: r.settings.GetAITemperature(),
-			MaxOutputTokens: r.settings.GetAIMaxTokens(),
-		},
-	}
-
-	payload, err := json.Marshal(geminiReq)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao montar payload: %w", err)
-	}
-
-	// Criar requisição HTTP
-	apiKey := r.settings.GetGeminiAPIKey()
-	model := r.settings.GetGeminiModel()
-	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent", model)
-
-	httpReq, err := http.NewRequestWithContext(
-		ctx,
-		"POST",
-		url,
-		bytes.NewReader(payload),
-	)
+	resp, err := r.Complete(ctx, fmt.Sprintf("%s\n\nTexto:\n%s", req.Prompt, req.Text), nil)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
+		return nil, err
 	}
 
-	httpReq.Header.Set("X-Goog-Api-Key", apiKey)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	// Executar requisição
-	resp, err := r.client.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao chamar API Gemini: %w", err)
-	}
-	defer resp.Body.Close()
+	resp.OriginalText = req.Text
+	resp.Prompt = req.Prompt
+	return resp, nil
+}
 
-	// Parse da resposta
-	var geminiResp geminiResponse
-	if err := json.NewDecoder(resp.Body).Decode(&geminiResp); err != nil {
-		return nil, fmt.Errorf("erro ao decodificar resposta: %w", err)
+// Complete envia o prompt já montado ao provedor; params sobrescreve os padrões da seção [ai]
+func (r *AIRepositoryImpl) Complete(ctx context.Context, prompt string, params *entities.AIModelParams) (*entities.AIResponse, error) {
+	if !r.settings.IsAIEnabled() {
+		return nil, fmt.Errorf("funcionalidade de IA não está habilitada. Configure o provedor %q na seção [ai] do settings.toml", r.provider.Name())
 	}
 
-	// Verificar erro na resposta
-	if geminiResp.Error != nil {
-		return nil, fmt.Errorf("erro da API Gemini: %s", geminiResp.Error.Message)
+	gen := &AIGeneration{
+		Prompt:      prompt,
+		Temperature: r.settings.GetAITemperature(),
+		MaxTokens:   r.settings.GetAIMaxTokens(),
 	}
-
-	// Verificar se tem candidates
-	if len(geminiResp.Candidates) == 0 {
-		return nil, errors.New("resposta vazia da API Gemini")
+	if params != nil {
+		gen.Model = params.Model
+		if params.Temperature != nil {
+			gen.Temperature = *params.Temperature
+		}
+		if params.MaxTokens != nil {
+			gen.MaxTokens = *params.MaxTokens
+		}
 	}
 
-	// Verificar se tem parts
-	if len(geminiResp.Candidates[0].Content.Parts) == 0 {
-		return nil, errors.New("resposta sem conteúdo da API Gemini")
+	backoff := time.Duration(r.settings.AI.RetryBackoffMs) * time.Millisecond
+	start := time.Now()
+	completion, attempts, err := generateWithRetry(ctx, r.provider, gen, r.settings.AI.MaxRetries, backoff)
+	if err != nil {
+		return nil, err
 	}
 
-	generatedText := geminiResp.Candidates[0].Content.Parts[0].Text
-
 	return &entities.AIResponse{
-		OriginalText: req.Text,
-		GeneratedText: generatedText,
-		Prompt:       req.Prompt,
-		Model:        model,
+		GeneratedText: completion.Text,
+		Model:         modelOrDefault(gen, r.provider.Model()),
+		Metadata: &entities.AIResponseMetadata{
+			Provider:         r.provider.Name(),
+			PromptTokens:     completion.PromptTokens,
+			CompletionTokens: completion.CompletionTokens,
+			TotalTokens:      completion.TotalTokens,
+			LatencyMs:        time.Since(start).Milliseconds(),
+			FinishReason:     completion.FinishReason,
+			Attempts:         attempts,
+		},
 	}, nil
 }
@@ -0,0 +1,77 @@
+package repository_impl
+
+import (
+	"context"
+	"database/sql"
+	"hackathon-backend/domain/entities"
+)
+
+type ApprovalDecisionRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewApprovalDecisionRepositoryImpl(db *sql.DB) *ApprovalDecisionRepositoryImpl {
+	return &ApprovalDecisionRepositoryImpl{db: db}
+}
+
+// Create registra a decisão na rodada de revisão atual; retorna sql.ErrNoRows se o usuário já decidiu nesta etapa
+func (r *ApprovalDecisionRepositoryImpl) Create(ctx context.Context, decision *entities.ApprovalDecision) error {
+	query := `
+		INSERT INTO initiative_approval_decisions (initiative_id, round, step_index, step_name, user_id, approved, reason, created_at)
+		VALUES ($1, (SELECT COALESCE(MAX(round), 1) FROM initiative_review_rounds WHERE initiative_id = $1),
+		        $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (initiative_id, round, step_index, user_id) DO NOTHING
+		RETURNING id, round, created_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		decision.InitiativeID,
+		decision.StepIndex,
+		decision.StepName,
+		decision.UserID,
+		decision.Approved,
+		decision.Reason,
+	).Scan(&decision.ID, &decision.Round, &decision.CreatedAt)
+}
+
+// ListByInitiative lista as decisões da rodada de revisão atual
+func (r *ApprovalDecisionRepositoryImpl) ListByInitiative(ctx context.Context, initiativeID int64) ([]*entities.ApprovalDecision, error) {
+	query := `
+		SELECT d.id, d.initiative_id, d.step_index, d.step_name, d.user_id, u.name,
+		       d.round, d.approved, d.reason, d.created_at
+		FROM initiative_approval_decisions d
+		INNER JOIN users u ON u.id = d.user_id
+		WHERE d.initiative_id = $1
+		  AND d.round = (SELECT COALESCE(MAX(round), 1) FROM initiative_review_rounds WHERE initiative_id = $1)
+		ORDER BY d.created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, initiativeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var decisions []*entities.ApprovalDecision
+	for rows.Next() {
+		decision := &entities.ApprovalDecision{}
+		err := rows.Scan(
+			&decision.ID,
+			&decision.InitiativeID,
+			&decision.StepIndex,
+			&decision.StepName,
+			&decision.UserID,
+			&decision.UserName,
+			&decision.Round,
+			&decision.Approved,
+			&decision.Reason,
+			&decision.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, decision)
+	}
+
+	return decisions, nil
+}
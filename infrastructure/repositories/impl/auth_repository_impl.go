@@ -3,7 +3,9 @@ package repository_impl
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"hackathon-backend/domain/entities"
+	"strings"
 )
 
 type AuthRepositoryImpl struct {
@@ -16,8 +18,16 @@ func NewAuthRepositoryImpl(db *sql.DB) *AuthRepositoryImpl {
 
 func (r *AuthRepositoryImpl) GetUserByEmail(ctx context.Context, email string) (*entities.User, error) {
 	query := `
-		SELECT u.id, u.email, u.name, u.password, u.sector_id, u.created_at, u.updated_at
+		SELECT u.id, u.email, u.name, u.password, u.sector_id, u.created_at, u.updated_at,
+		       u.password_changed_at, u.must_change_password,
+		       COALESCE(tf.enabled, false) AS two_factor_enabled,
+		       EXISTS (
+		           SELECT 1 FROM type_user tu
+		           INNER JOIN user_type ut ON ut.id = tu.user_type_id
+		           WHERE tu.user_id = u.id AND ut.require_two_factor
+		       ) AS two_factor_required
 		FROM users u
+		LEFT JOIN user_two_factor tf ON tf.user_id = u.id
 		WHERE u.email = $1
 	`
 
@@ -32,6 +42,10 @@ func (r *AuthRepositoryImpl) GetUserByEmail(ctx context.Context, email string) (
 		&sectorID,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.PasswordChangedAt,
+		&user.MustChangePassword,
+		&user.TwoFactorEnabled,
+		&user.TwoFactorRequired,
 	)
 
 	if err != nil {
@@ -50,8 +64,16 @@ func (r *AuthRepositoryImpl) GetUserByEmail(ctx context.Context, email string) (
 
 func (r *AuthRepositoryImpl) GetUserByID(ctx context.Context, userID int64) (*entities.User, error) {
 	query := `
-		SELECT u.id, u.email, u.name, u.password, u.sector_id, u.created_at, u.updated_at
+		SELECT u.id, u.email, u.name, u.password, u.sector_id, u.created_at, u.updated_at,
+		       u.password_changed_at, u.must_change_password,
+		       COALESCE(tf.enabled, false) AS two_factor_enabled,
+		       EXISTS (
+		           SELECT 1 FROM type_user tu
+		           INNER JOIN user_type ut ON ut.id = tu.user_type_id
+		           WHERE tu.user_id = u.id AND ut.require_two_factor
+		       ) AS two_factor_required
 		FROM users u
+		LEFT JOIN user_two_factor tf ON tf.user_id = u.id
 		WHERE u.id = $1
 	`
 
@@ -66,6 +88,10 @@ func (r *AuthRepositoryImpl) GetUserByID(ctx context.Context, userID int64) (*en
 		&sectorID,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.PasswordChangedAt,
+		&user.MustChangePassword,
+		&user.TwoFactorEnabled,
+		&user.TwoFactorRequired,
 	)
 
 	if err != nil {
@@ -162,7 +188,7 @@ func (r *AuthRepositoryImpl) ListAllUsers(ctx context.Context) ([]*entities.User
 func (r *AuthRepositoryImpl) UpdatePassword(ctx context.Context, userID int64, hashedPassword string) error {
 	query := `
 		UPDATE users
-		SET password = $1, updated_at = NOW()
+		SET password = $1, password_changed_at = NOW(), must_change_password = false, updated_at = NOW()
 		WHERE id = $2
 	`
 
@@ -175,3 +201,192 @@ func (r *AuthRepositoryImpl) RemoveAllUserTypes(ctx context.Context, userID int6
 	_, err := r.db.ExecContext(ctx, query, userID)
 	return err
 }
+
+// RehashPassword troca o hash (ex: aumento do custo do bcrypt) sem alterar a data de troca da senha
+func (r *AuthRepositoryImpl) RehashPassword(ctx context.Context, userID int64, hashedPassword string) error {
+	query := `UPDATE users SET password = $1 WHERE id = $2`
+	_, err := r.db.ExecContext(ctx, query, hashedPassword, userID)
+	return err
+}
+
+func (r *AuthRepositoryImpl) SetMustChangePassword(ctx context.Context, userID int64, mustChange bool) error {
+	query := `UPDATE users SET must_change_password = $1, updated_at = NOW() WHERE id = $2`
+	_, err := r.db.ExecContext(ctx, query, mustChange, userID)
+	return err
+}
+
+func (r *AuthRepositoryImpl) AddPasswordHistory(ctx context.Context, userID int64, hashedPassword string) error {
+	query := `
+		INSERT INTO password_history (user_id, password_hash, created_at)
+		VALUES ($1, $2, NOW())
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, hashedPassword)
+	return err
+}
+
+// CreateUserWithPasswordHistory cria o usuário e registra a senha inicial no histórico na mesma transação
+func (r *AuthRepositoryImpl) CreateUserWithPasswordHistory(ctx context.Context, user *entities.User) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO users (email, name, password, sector_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+	err = tx.QueryRowContext(ctx, query, user.Email, user.Name, user.Password, user.SectorID).Scan(
+		&user.ID,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	historyQuery := `
+		INSERT INTO password_history (user_id, password_hash, created_at)
+		VALUES ($1, $2, NOW())
+	`
+	if _, err := tx.ExecContext(ctx, historyQuery, user.ID, user.Password); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdatePasswordWithHistory troca a senha e registra o novo hash no histórico na mesma transação
+func (r *AuthRepositoryImpl) UpdatePasswordWithHistory(ctx context.Context, userID int64, hashedPassword string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE users
+		SET password = $1, password_changed_at = NOW(), must_change_password = false, updated_at = NOW()
+		WHERE id = $2
+	`
+	if _, err := tx.ExecContext(ctx, query, hashedPassword, userID); err != nil {
+		return err
+	}
+
+	historyQuery := `
+		INSERT INTO password_history (user_id, password_hash, created_at)
+		VALUES ($1, $2, NOW())
+	`
+	if _, err := tx.ExecContext(ctx, historyQuery, userID, hashedPassword); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListPasswordHistory retorna os hashes das últimas senhas do usuário (mais recentes primeiro)
+func (r *AuthRepositoryImpl) ListPasswordHistory(ctx context.Context, userID int64, limit int) ([]string, error) {
+	query := `
+		SELECT password_hash
+		FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+// GetUserByOIDCSubject busca o usuário vinculado ao sub do provedor OIDC
+func (r *AuthRepositoryImpl) GetUserByOIDCSubject(ctx context.Context, issuer, subject string) (*entities.User, error) {
+	query := `SELECT id FROM users WHERE oidc_issuer = $1 AND oidc_subject = $2`
+
+	var userID int64
+	if err := r.db.QueryRowContext(ctx, query, issuer, subject).Scan(&userID); err != nil {
+		return nil, err
+	}
+
+	return r.GetUserByID(ctx, userID)
+}
+
+// LinkOIDCSubject vincula o usuário local à identidade do provedor.
+// Retorna sql.ErrNoRows se o usuário já estiver vinculado a outra identidade.
+func (r *AuthRepositoryImpl) LinkOIDCSubject(ctx context.Context, userID int64, issuer, subject string) error {
+	query := `
+		UPDATE users
+		SET oidc_issuer = $1, oidc_subject = $2, updated_at = NOW()
+		WHERE id = $3
+		  AND (oidc_subject IS NULL OR (oidc_issuer = $1 AND oidc_subject = $2))
+	`
+
+	result, err := r.db.ExecContext(ctx, query, issuer, subject, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// FindUsersByHandles busca usuários pelo email completo ou pela parte antes do @ (sem diferenciar maiúsculas)
+func (r *AuthRepositoryImpl) FindUsersByHandles(ctx context.Context, handles []string) ([]*entities.User, error) {
+	if len(handles) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(handles))
+	args := make([]interface{}, len(handles))
+	for i, handle := range handles {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = strings.ToLower(handle)
+	}
+	in := strings.Join(placeholders, ", ")
+
+	query := fmt.Sprintf(`
+		SELECT id, email, name
+		FROM users
+		WHERE LOWER(email) IN (%s)
+		   OR LOWER(split_part(email, '@', 1)) IN (%s)
+	`, in, in)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*entities.User
+	for rows.Next() {
+		user := &entities.User{}
+		if err := rows.Scan(&user.ID, &user.Email, &user.Name); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
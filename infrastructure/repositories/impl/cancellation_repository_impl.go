@@ -31,49 +31,96 @@ func (r *CancellationRepositoryImpl) Create(ctx context.Context, request *entiti
 
 func (r *CancellationRepositoryImpl) GetByID(ctx context.Context, requestID int64) (*entities.InitiativeCancellationRequest, error) {
 	query := `
-		SELECT cr.id, cr.initiative_id, cr.requested_by_user_id, u1.name as requested_by_name,
+		SELECT cr.id, cr.initiative_id, i.title, cr.requested_by_user_id, u1.name as requested_by_name,
 		       cr.reason, cr.status, cr.reviewed_by_user_id, u2.name as reviewed_by_name,
-		       cr.review_reason, cr.created_at, cr.reviewed_at
+		       cr.review_reason, cr.created_at, cr.reviewed_at, cr.withdrawn_at, cr.withdraw_reason
 		FROM initiative_cancellation_requests cr
+		INNER JOIN initiatives i ON i.id = cr.initiative_id
 		INNER JOIN users u1 ON u1.id = cr.requested_by_user_id
 		LEFT JOIN users u2 ON u2.id = cr.reviewed_by_user_id
 		WHERE cr.id = $1
 	`
 
-	req := &entities.InitiativeCancellationRequest{}
-	var reviewedByUserID sql.NullInt64
-	var reviewedByName, reviewReason sql.NullString
-	var reviewedAt sql.NullTime
+	return scanCancellationRequest(r.db.QueryRowContext(ctx, query, requestID))
+}
 
-	err := r.db.QueryRowContext(ctx, query, requestID).Scan(
-		&req.ID,
-		&req.InitiativeID,
-		&req.RequestedByUserID,
-		&req.RequestedByName,
-		&req.Reason,
-		&req.Status,
-		&reviewedByUserID,
-		&reviewedByName,
-		&reviewReason,
-		&req.CreatedAt,
-		&reviewedAt,
-	)
+// ListByRequester lista todas as solicitações feitas pelo usuário (mais recentes primeiro)
+func (r *CancellationRepositoryImpl) ListByRequester(ctx context.Context, userID int64) ([]*entities.InitiativeCancellationRequest, error) {
+	query := `
+		SELECT cr.id, cr.initiative_id, i.title, cr.requested_by_user_id, u1.name as requested_by_name,
+		       cr.reason, cr.status, cr.reviewed_by_user_id, u2.name as reviewed_by_name,
+		       cr.review_reason, cr.created_at, cr.reviewed_at, cr.withdrawn_at, cr.withdraw_reason
+		FROM initiative_cancellation_requests cr
+		INNER JOIN initiatives i ON i.id = cr.initiative_id
+		INNER JOIN users u1 ON u1.id = cr.requested_by_user_id
+		LEFT JOIN users u2 ON u2.id = cr.reviewed_by_user_id
+		WHERE cr.requested_by_user_id = $1
+		ORDER BY cr.created_at DESC, cr.id DESC
+	`
 
+	rows, err := r.db.QueryContext(ctx, query, userID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	if reviewedByUserID.Valid {
-		req.ReviewedByUserID = &reviewedByUserID.Int64
-		req.ReviewedByName = reviewedByName.String
-		req.ReviewReason = reviewReason.String
+	var requests []*entities.InitiativeCancellationRequest
+	for rows.Next() {
+		req, err := scanCancellationRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
 	}
 
-	if reviewedAt.Valid {
-		req.ReviewedAt = &reviewedAt.Time
+	return requests, nil
+}
+
+// GetLatestRejected busca a reprovação mais recente da iniciativa
+func (r *CancellationRepositoryImpl) GetLatestRejected(ctx context.Context, initiativeID int64) (*entities.InitiativeCancellationRequest, error) {
+	query := `
+		SELECT cr.id, cr.initiative_id, i.title, cr.requested_by_user_id, u1.name as requested_by_name,
+		       cr.reason, cr.status, cr.reviewed_by_user_id, u2.name as reviewed_by_name,
+		       cr.review_reason, cr.created_at, cr.reviewed_at, cr.withdrawn_at, cr.withdraw_reason
+		FROM initiative_cancellation_requests cr
+		INNER JOIN initiatives i ON i.id = cr.initiative_id
+		INNER JOIN users u1 ON u1.id = cr.requested_by_user_id
+		LEFT JOIN users u2 ON u2.id = cr.reviewed_by_user_id
+		WHERE cr.initiative_id = $1 AND cr.status = $2
+		ORDER BY cr.reviewed_at DESC
+		LIMIT 1
+	`
+
+	return scanCancellationRequest(r.db.QueryRowContext(ctx, query, initiativeID, entities.CancellationStatusRejected))
+}
+
+// Withdraw marca a solicitação pendente como retirada pelo solicitante
+func (r *CancellationRepositoryImpl) Withdraw(ctx context.Context, requestID int64, reason string) error {
+	query := `
+		UPDATE initiative_cancellation_requests
+		SET status = $1, withdrawn_at = NOW(), withdraw_reason = $2
+		WHERE id = $3 AND status = $4
+	`
+
+	var withdrawReason sql.NullString
+	if reason != "" {
+		withdrawReason = sql.NullString{String: reason, Valid: true}
 	}
 
-	return req, nil
+	result, err := r.db.ExecContext(ctx, query, entities.CancellationStatusWithdrawn, withdrawReason, requestID, entities.CancellationStatusPending)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
 }
 
 func (r *CancellationRepositoryImpl) GetPendingByInitiative(ctx context.Context, initiativeID int64) (*entities.InitiativeCancellationRequest, error) {
@@ -158,3 +205,72 @@ func (r *CancellationRepositoryImpl) HasPendingRequest(ctx context.Context, init
 	err := r.db.QueryRowContext(ctx, query, initiativeID, entities.CancellationStatusPending).Scan(&exists)
 	return exists, err
 }
+
+// Expire encerra uma solicitação pendente que ficou sem decisão (sem revisor)
+func (r *CancellationRepositoryImpl) Expire(ctx context.Context, requestID int64, reason string) error {
+	query := `
+		UPDATE initiative_cancellation_requests
+		SET status = $1, review_reason = $2, reviewed_at = NOW()
+		WHERE id = $3 AND status = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, entities.CancellationStatusExpired, reason, requestID, entities.CancellationStatusPending)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanCancellationRequest(scanner rowScanner) (*entities.InitiativeCancellationRequest, error) {
+	req := &entities.InitiativeCancellationRequest{}
+	var reviewedByUserID sql.NullInt64
+	var reviewedByName, reviewReason, withdrawReason sql.NullString
+	var reviewedAt, withdrawnAt sql.NullTime
+
+	err := scanner.Scan(
+		&req.ID,
+		&req.InitiativeID,
+		&req.InitiativeTitle,
+		&req.RequestedByUserID,
+		&req.RequestedByName,
+		&req.Reason,
+		&req.Status,
+		&reviewedByUserID,
+		&reviewedByName,
+		&reviewReason,
+		&req.CreatedAt,
+		&reviewedAt,
+		&withdrawnAt,
+		&withdrawReason,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if reviewedByUserID.Valid {
+		req.ReviewedByUserID = &reviewedByUserID.Int64
+		req.ReviewedByName = reviewedByName.String
+		req.ReviewReason = reviewReason.String
+	}
+
+	if reviewedAt.Valid {
+		req.ReviewedAt = &reviewedAt.Time
+	}
+
+	if withdrawnAt.Valid {
+		req.WithdrawnAt = &withdrawnAt.Time
+		req.WithdrawReason = withdrawReason.String
+	}
+
+	return req, nil
+}
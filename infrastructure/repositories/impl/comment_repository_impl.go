@@ -16,54 +16,142 @@ func NewCommentRepositoryImpl(db *sql.DB) *CommentRepositoryImpl {
 
 func (r *CommentRepositoryImpl) Create(ctx context.Context, comment *entities.Comment) error {
 	query := `
-		INSERT INTO initiative_comments (initiative_id, user_id, content, created_at, updated_at)
-		VALUES ($1, $2, $3, NOW(), NOW())
+		INSERT INTO initiative_comments (initiative_id, parent_id, user_id, content, visibility, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
 		RETURNING id, created_at, updated_at
 	`
 
 	return r.db.QueryRowContext(ctx, query,
 		comment.InitiativeID,
+		comment.ParentID,
 		comment.UserID,
 		comment.Content,
+		comment.Visibility,
 	).Scan(&comment.ID, &comment.CreatedAt, &comment.UpdatedAt)
 }
 
-func (r *CommentRepositoryImpl) Update(ctx context.Context, comment *entities.Comment) error {
+// Update grava o conteúdo anterior como revisão e atualiza o comentário (na mesma transação)
+func (r *CommentRepositoryImpl) Update(ctx context.Context, comment *entities.Comment, editedBy int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	revisionQuery := `
+		INSERT INTO comment_revisions (comment_id, content, edited_by, created_at)
+		SELECT id, content, $2, NOW()
+		FROM initiative_comments
+		WHERE id = $1
+	`
+	if _, err := tx.ExecContext(ctx, revisionQuery, comment.ID, editedBy); err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE initiative_comments
 		SET content = $1, updated_at = NOW()
 		WHERE id = $2
 		RETURNING updated_at
 	`
+	if err := tx.QueryRowContext(ctx, query, comment.Content, comment.ID).Scan(&comment.UpdatedAt); err != nil {
+		return err
+	}
 
-	return r.db.QueryRowContext(ctx, query, comment.Content, comment.ID).Scan(&comment.UpdatedAt)
+	return tx.Commit()
 }
 
-func (r *CommentRepositoryImpl) Delete(ctx context.Context, commentID int64) error {
-	query := `DELETE FROM initiative_comments WHERE id = $1`
+// SoftDelete marca o comentário como removido, mantendo-o na árvore como tombstone
+func (r *CommentRepositoryImpl) SoftDelete(ctx context.Context, commentID, deletedBy int64) error {
+	query := `
+		UPDATE initiative_comments
+		SET deleted_at = NOW(), deleted_by = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, commentID, deletedBy)
+	return err
+}
+
+func (r *CommentRepositoryImpl) Restore(ctx context.Context, commentID int64) error {
+	query := `
+		UPDATE initiative_comments
+		SET deleted_at = NULL, deleted_by = NULL
+		WHERE id = $1
+	`
 	_, err := r.db.ExecContext(ctx, query, commentID)
 	return err
 }
 
+// Delete remove definitivamente o comentário; as respostas sobem para o comentário pai
+func (r *CommentRepositoryImpl) Delete(ctx context.Context, commentID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	reparentQuery := `
+		UPDATE initiative_comments
+		SET parent_id = (SELECT parent_id FROM initiative_comments WHERE id = $1)
+		WHERE parent_id = $1
+	`
+	if _, err := tx.ExecContext(ctx, reparentQuery, commentID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM initiative_comments WHERE id = $1`, commentID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *CommentRepositoryImpl) ListRevisions(ctx context.Context, commentID int64) ([]*entities.CommentRevision, error) {
+	query := `
+		SELECT cr.id, cr.comment_id, cr.content, cr.edited_by, u.name, cr.created_at
+		FROM comment_revisions cr
+		INNER JOIN users u ON u.id = cr.edited_by
+		WHERE cr.comment_id = $1
+		ORDER BY cr.created_at DESC, cr.id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*entities.CommentRevision
+	for rows.Next() {
+		revision := &entities.CommentRevision{}
+		err := rows.Scan(
+			&revision.ID,
+			&revision.CommentID,
+			&revision.Content,
+			&revision.EditedBy,
+			&revision.EditedByName,
+			&revision.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
 func (r *CommentRepositoryImpl) GetByID(ctx context.Context, commentID int64) (*entities.Comment, error) {
 	query := `
-		SELECT c.id, c.initiative_id, c.user_id, u.name as user_name, c.content, c.created_at, c.updated_at
+		SELECT c.id, c.initiative_id, c.parent_id, c.user_id, u.name as user_name, c.content, c.visibility, c.created_at, c.updated_at,
+		       (SELECT COUNT(*) FROM comment_revisions cr WHERE cr.comment_id = c.id) AS revision_count,
+		       c.deleted_at, c.deleted_by
 		FROM initiative_comments c
 		INNER JOIN users u ON u.id = c.user_id
 		WHERE c.id = $1
 	`
 
-	comment := &entities.Comment{}
-	err := r.db.QueryRowContext(ctx, query, commentID).Scan(
-		&comment.ID,
-		&comment.InitiativeID,
-		&comment.UserID,
-		&comment.UserName,
-		&comment.Content,
-		&comment.CreatedAt,
-		&comment.UpdatedAt,
-	)
-
+	comment, err := scanComment(r.db.QueryRowContext(ctx, query, commentID))
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +161,9 @@ func (r *CommentRepositoryImpl) GetByID(ctx context.Context, commentID int64) (*
 
 func (r *CommentRepositoryImpl) ListByInitiative(ctx context.Context, initiativeID int64) ([]*entities.Comment, error) {
 	query := `
-		SELECT c.id, c.initiative_id, c.user_id, u.name as user_name, c.content, c.created_at, c.updated_at
+		SELECT c.id, c.initiative_id, c.parent_id, c.user_id, u.name as user_name, c.content, c.visibility, c.created_at, c.updated_at,
+		       (SELECT COUNT(*) FROM comment_revisions cr WHERE cr.comment_id = c.id) AS revision_count,
+		       c.deleted_at, c.deleted_by
 		FROM initiative_comments c
 		INNER JOIN users u ON u.id = c.user_id
 		WHERE c.initiative_id = $1
@@ -88,16 +178,7 @@ func (r *CommentRepositoryImpl) ListByInitiative(ctx context.Context, initiative
 
 	var comments []*entities.Comment
 	for rows.Next() {
-		comment := &entities.Comment{}
-		err := rows.Scan(
-			&comment.ID,
-			&comment.InitiativeID,
-			&comment.UserID,
-			&comment.UserName,
-			&comment.Content,
-			&comment.CreatedAt,
-			&comment.UpdatedAt,
-		)
+		comment, err := scanComment(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -108,9 +189,165 @@ func (r *CommentRepositoryImpl) ListByInitiative(ctx context.Context, initiative
 }
 
 func (r *CommentRepositoryImpl) CountByInitiative(ctx context.Context, initiativeID int64) (int, error) {
-	query := `SELECT COUNT(*) FROM initiative_comments WHERE initiative_id = $1`
+	query := `SELECT COUNT(*) FROM initiative_comments WHERE initiative_id = $1 AND deleted_at IS NULL AND visibility = 'public'`
 
 	var count int
 	err := r.db.QueryRowContext(ctx, query, initiativeID).Scan(&count)
 	return count, err
 }
+
+// SetMentions substitui os usuários mencionados no comentário
+func (r *CommentRepositoryImpl) SetMentions(ctx context.Context, commentID int64, userIDs []int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM comment_mentions WHERE comment_id = $1`, commentID); err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		query := `
+			INSERT INTO comment_mentions (comment_id, user_id, created_at)
+			VALUES ($1, $2, NOW())
+			ON CONFLICT DO NOTHING
+		`
+		if _, err := tx.ExecContext(ctx, query, commentID, userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *CommentRepositoryImpl) ListMentions(ctx context.Context, commentID int64) ([]*entities.CommentMention, error) {
+	query := `
+		SELECT m.comment_id, m.user_id, u.name
+		FROM comment_mentions m
+		INNER JOIN users u ON u.id = m.user_id
+		WHERE m.comment_id = $1
+		ORDER BY u.name
+	`
+
+	return r.queryMentions(ctx, query, commentID)
+}
+
+func (r *CommentRepositoryImpl) ListMentionsByInitiative(ctx context.Context, initiativeID int64) ([]*entities.CommentMention, error) {
+	query := `
+		SELECT m.comment_id, m.user_id, u.name
+		FROM comment_mentions m
+		INNER JOIN users u ON u.id = m.user_id
+		INNER JOIN initiative_comments c ON c.id = m.comment_id
+		WHERE c.initiative_id = $1
+		ORDER BY u.name
+	`
+
+	return r.queryMentions(ctx, query, initiativeID)
+}
+
+func (r *CommentRepositoryImpl) queryMentions(ctx context.Context, query string, arg int64) ([]*entities.CommentMention, error) {
+	rows, err := r.db.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mentions []*entities.CommentMention
+	for rows.Next() {
+		mention := &entities.CommentMention{}
+		if err := rows.Scan(&mention.CommentID, &mention.UserID, &mention.UserName); err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, mention)
+	}
+
+	return mentions, nil
+}
+
+// AddReaction registra a reação (repetir a mesma reação não duplica)
+func (r *CommentRepositoryImpl) AddReaction(ctx context.Context, reaction *entities.CommentReaction) error {
+	query := `
+		INSERT INTO comment_reactions (comment_id, user_id, emoji, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (comment_id, user_id, emoji) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query, reaction.CommentID, reaction.UserID, reaction.Emoji)
+	return err
+}
+
+func (r *CommentRepositoryImpl) RemoveReaction(ctx context.Context, commentID, userID int64, emoji string) error {
+	query := `DELETE FROM comment_reactions WHERE comment_id = $1 AND user_id = $2 AND emoji = $3`
+	_, err := r.db.ExecContext(ctx, query, commentID, userID, emoji)
+	return err
+}
+
+func (r *CommentRepositoryImpl) ListReactionsByInitiative(ctx context.Context, initiativeID int64) ([]*entities.CommentReaction, error) {
+	query := `
+		SELECT cr.comment_id, cr.user_id, cr.emoji, cr.created_at
+		FROM comment_reactions cr
+		INNER JOIN initiative_comments c ON c.id = cr.comment_id
+		WHERE c.initiative_id = $1
+		ORDER BY cr.created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, initiativeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reactions []*entities.CommentReaction
+	for rows.Next() {
+		reaction := &entities.CommentReaction{}
+		if err := rows.Scan(&reaction.CommentID, &reaction.UserID, &reaction.Emoji, &reaction.CreatedAt); err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, reaction)
+	}
+
+	return reactions, nil
+}
+
+// rowScanner é implementado por *sql.Row e *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanComment(scanner rowScanner) (*entities.Comment, error) {
+	comment := &entities.Comment{}
+	var parentID, deletedBy sql.NullInt64
+	var deletedAt sql.NullTime
+
+	err := scanner.Scan(
+		&comment.ID,
+		&comment.InitiativeID,
+		&parentID,
+		&comment.UserID,
+		&comment.UserName,
+		&comment.Content,
+		&comment.Visibility,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+		&comment.RevisionCount,
+		&deletedAt,
+		&deletedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID.Valid {
+		comment.ParentID = &parentID.Int64
+	}
+	if deletedAt.Valid {
+		comment.DeletedAt = &deletedAt.Time
+	}
+	if deletedBy.Valid {
+		comment.DeletedBy = &deletedBy.Int64
+	}
+
+	return comment, nil
+}
@@ -0,0 +1,206 @@
+package repository_impl
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"hackathon-backend/domain/entities"
+)
+
+type ConsolidatedRankingRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewConsolidatedRankingRepositoryImpl(db *sql.DB) *ConsolidatedRankingRepositoryImpl {
+	return &ConsolidatedRankingRepositoryImpl{db: db}
+}
+
+// Save grava o ranking do ano como rascunho, substituindo o anterior se ele não estiver bloqueado
+func (r *ConsolidatedRankingRepositoryImpl) Save(ctx context.Context, ranking *entities.ConsolidatedRanking) error {
+	itemsJSON, err := json.Marshal(ranking.Items)
+	if err != nil {
+		return err
+	}
+
+	pinsJSON, err := json.Marshal(ranking.Pins)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO consolidated_rankings (year, strategy, status, is_locked, items, pins, generated_by_user_id, generated_at, created_at, updated_at)
+		VALUES ($1, $2, $3, false, $4, $5, $6, NOW(), NOW(), NOW())
+		ON CONFLICT (year) DO UPDATE
+		SET strategy = EXCLUDED.strategy, status = EXCLUDED.status, items = EXCLUDED.items, pins = EXCLUDED.pins,
+		    generated_by_user_id = EXCLUDED.generated_by_user_id, generated_at = NOW(),
+		    submitted_by_user_id = NULL, submitted_at = NULL,
+		    reviewed_by_user_id = NULL, review_reason = NULL, reviewed_at = NULL, updated_at = NOW()
+		WHERE consolidated_rankings.is_locked = false AND consolidated_rankings.status <> $7
+		RETURNING id
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		ranking.Year,
+		ranking.Strategy,
+		entities.ConsolidatedRankingDraft,
+		itemsJSON,
+		pinsJSON,
+		ranking.GeneratedByUserID,
+		entities.ConsolidatedRankingPending,
+	).Scan(&ranking.ID)
+}
+
+func (r *ConsolidatedRankingRepositoryImpl) GetByYear(ctx context.Context, year int) (*entities.ConsolidatedRanking, error) {
+	query := `
+		SELECT cr.id, cr.year, cr.strategy, cr.status, cr.is_locked, cr.items, cr.pins,
+		       cr.generated_by_user_id, u1.name, cr.generated_at, cr.submitted_by_user_id, cr.submitted_at,
+		       cr.reviewed_by_user_id, u2.name, cr.review_reason, cr.reviewed_at, cr.created_at, cr.updated_at
+		FROM consolidated_rankings cr
+		INNER JOIN users u1 ON u1.id = cr.generated_by_user_id
+		LEFT JOIN users u2 ON u2.id = cr.reviewed_by_user_id
+		WHERE cr.year = $1
+	`
+
+	return scanConsolidatedRanking(r.db.QueryRowContext(ctx, query, year))
+}
+
+func (r *ConsolidatedRankingRepositoryImpl) GetByID(ctx context.Context, rankingID int64) (*entities.ConsolidatedRanking, error) {
+	query := `
+		SELECT cr.id, cr.year, cr.strategy, cr.status, cr.is_locked, cr.items, cr.pins,
+		       cr.generated_by_user_id, u1.name, cr.generated_at, cr.submitted_by_user_id, cr.submitted_at,
+		       cr.reviewed_by_user_id, u2.name, cr.review_reason, cr.reviewed_at, cr.created_at, cr.updated_at
+		FROM consolidated_rankings cr
+		INNER JOIN users u1 ON u1.id = cr.generated_by_user_id
+		LEFT JOIN users u2 ON u2.id = cr.reviewed_by_user_id
+		WHERE cr.id = $1
+	`
+
+	return scanConsolidatedRanking(r.db.QueryRowContext(ctx, query, rankingID))
+}
+
+// Submit envia o rascunho (ou um ranking reprovado) para aprovação do comitê
+func (r *ConsolidatedRankingRepositoryImpl) Submit(ctx context.Context, rankingID int64, userID int64) error {
+	query := `
+		UPDATE consolidated_rankings
+		SET status = $1, submitted_by_user_id = $2, submitted_at = NOW(), updated_at = NOW()
+		WHERE id = $3 AND status IN ($4, $5)
+	`
+
+	return execAffectingOne(ctx, r.db, query,
+		entities.ConsolidatedRankingPending,
+		userID,
+		rankingID,
+		entities.ConsolidatedRankingDraft,
+		entities.ConsolidatedRankingRejected,
+	)
+}
+
+// Review aprova (bloqueando) ou reprova um ranking pendente
+func (r *ConsolidatedRankingRepositoryImpl) Review(ctx context.Context, rankingID int64, status string, userID int64, reason string) error {
+	query := `
+		UPDATE consolidated_rankings
+		SET status = $1, is_locked = $2, reviewed_by_user_id = $3, review_reason = $4, reviewed_at = NOW(), updated_at = NOW()
+		WHERE id = $5 AND status = $6
+	`
+
+	return execAffectingOne(ctx, r.db, query,
+		status,
+		status == entities.ConsolidatedRankingApproved,
+		userID,
+		reason,
+		rankingID,
+		entities.ConsolidatedRankingPending,
+	)
+}
+
+// Reopen desbloqueia um ranking aprovado, voltando-o para rascunho
+func (r *ConsolidatedRankingRepositoryImpl) Reopen(ctx context.Context, rankingID int64, userID int64, reason string) error {
+	query := `
+		UPDATE consolidated_rankings
+		SET status = $1, is_locked = false, reviewed_by_user_id = $2, review_reason = $3, reviewed_at = NOW(), updated_at = NOW()
+		WHERE id = $4 AND status = $5
+	`
+
+	return execAffectingOne(ctx, r.db, query,
+		entities.ConsolidatedRankingDraft,
+		userID,
+		reason,
+		rankingID,
+		entities.ConsolidatedRankingApproved,
+	)
+}
+
+// execAffectingOne executa o comando e retorna sql.ErrNoRows se nenhuma linha foi alterada
+func execAffectingOne(ctx context.Context, db *sql.DB, query string, args ...interface{}) error {
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanConsolidatedRanking(row rowScanner) (*entities.ConsolidatedRanking, error) {
+	ranking := &entities.ConsolidatedRanking{}
+	var itemsJSON, pinsJSON []byte
+	var submittedBy, reviewedBy sql.NullInt64
+	var reviewedByName, reviewReason sql.NullString
+	var submittedAt, reviewedAt sql.NullTime
+
+	err := row.Scan(
+		&ranking.ID,
+		&ranking.Year,
+		&ranking.Strategy,
+		&ranking.Status,
+		&ranking.IsLocked,
+		&itemsJSON,
+		&pinsJSON,
+		&ranking.GeneratedByUserID,
+		&ranking.GeneratedByName,
+		&ranking.GeneratedAt,
+		&submittedBy,
+		&submittedAt,
+		&reviewedBy,
+		&reviewedByName,
+		&reviewReason,
+		&reviewedAt,
+		&ranking.CreatedAt,
+		&ranking.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(itemsJSON, &ranking.Items); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(pinsJSON, &ranking.Pins); err != nil {
+		return nil, err
+	}
+
+	if submittedBy.Valid {
+		ranking.SubmittedByUserID = &submittedBy.Int64
+	}
+	if submittedAt.Valid {
+		ranking.SubmittedAt = &submittedAt.Time
+	}
+	if reviewedBy.Valid {
+		ranking.ReviewedByUserID = &reviewedBy.Int64
+		ranking.ReviewedByName = reviewedByName.String
+		ranking.ReviewReason = reviewReason.String
+	}
+	if reviewedAt.Valid {
+		ranking.ReviewedAt = &reviewedAt.Time
+	}
+
+	return ranking, nil
+}
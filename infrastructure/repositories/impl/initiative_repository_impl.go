@@ -22,8 +22,9 @@ func NewInitiativeRepositoryImpl(db *sql.DB) *InitiativeRepositoryImpl {
 
 func (r *InitiativeRepositoryImpl) Create(ctx context.Context, initiative *entities.Initiative) error {
 	query := `
-		INSERT INTO initiatives (title, description, benefits, status, type, priority, sector, owner_id, deadline, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+		INSERT INTO initiatives (title, description, benefits, status, type, priority, sector, owner_id, deadline,
+		                         description_text, benefits_text, effort_days, estimated_cost, required_skill, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW(), NOW())
 		RETURNING id, created_at, updated_at
 	`
 
@@ -37,6 +38,11 @@ func (r *InitiativeRepositoryImpl) Create(ctx context.Context, initiative *entit
 		initiative.Sector,
 		initiative.OwnerID,
 		initiative.Deadline,
+		initiative.DescriptionText,
+		initiative.BenefitsText,
+		initiative.EffortDays,
+		initiative.EstimatedCost,
+		initiative.RequiredSkill,
 	).Scan(&initiative.ID, &initiative.CreatedAt, &initiative.UpdatedAt)
 
 	if err != nil {
@@ -58,8 +64,10 @@ func (r *InitiativeRepositoryImpl) Create(ctx context.Context, initiative *entit
 func (r *InitiativeRepositoryImpl) Update(ctx context.Context, initiative *entities.Initiative) error {
 	query := `
 		UPDATE initiatives
-		SET title = $1, description = $2, benefits = $3, type = $4, priority = $5, sector = $6, deadline = $7, updated_at = NOW()
-		WHERE id = $8
+		SET title = $1, description = $2, benefits = $3, type = $4, priority = $5, sector = $6, deadline = $7,
+		    description_text = $8, benefits_text = $9, effort_days = $10, estimated_cost = $11, required_skill = $12,
+		    updated_at = NOW()
+		WHERE id = $13
 		RETURNING updated_at
 	`
 
@@ -71,6 +79,11 @@ func (r *InitiativeRepositoryImpl) Update(ctx context.Context, initiative *entit
 		initiative.Priority,
 		initiative.Sector,
 		initiative.Deadline,
+		initiative.DescriptionText,
+		initiative.BenefitsText,
+		initiative.EffortDays,
+		initiative.EstimatedCost,
+		initiative.RequiredSkill,
 		initiative.ID,
 	).Scan(&initiative.UpdatedAt)
 }
@@ -84,7 +97,7 @@ func (r *InitiativeRepositoryImpl) Delete(ctx context.Context, initiativeID int6
 func (r *InitiativeRepositoryImpl) GetByID(ctx context.Context, initiativeID int64) (*entities.Initiative, error) {
 	query := `
 		SELECT i.id, i.title, i.description, i.benefits, i.status, i.type, i.priority, i.sector, 
-		       i.owner_id, u.name as owner_name, i.deadline, i.created_at, i.updated_at
+		       i.owner_id, u.name as owner_name, i.deadline, i.created_at, i.updated_at, i.score, i.effort_days, i.estimated_cost, i.required_skill
 		FROM initiatives i
 		INNER JOIN users u ON u.id = i.owner_id
 		WHERE i.id = $1
@@ -105,6 +118,10 @@ func (r *InitiativeRepositoryImpl) GetByID(ctx context.Context, initiativeID int
 		&initiative.Deadline,
 		&initiative.CreatedAt,
 		&initiative.UpdatedAt,
+		&initiative.Score,
+		&initiative.EffortDays,
+		&initiative.EstimatedCost,
+		&initiative.RequiredSkill,
 	)
 
 	if err != nil {
@@ -118,7 +135,7 @@ func (r *InitiativeRepositoryImpl) GetByID(ctx context.Context, initiativeID int
 func (r *InitiativeRepositoryImpl) GetByIDWithCancellation(ctx context.Context, initiativeID int64) (*entities.Initiative, error) {
 	query := `
 		SELECT i.id, i. title, i.description, i. benefits, i.status, i. type, i.priority, i. sector, 
-		       i. owner_id, u.name as owner_name, i.deadline, i.created_at, i. updated_at,
+		       i. owner_id, u.name as owner_name, i.deadline, i.created_at, i. updated_at, i.score, i.effort_days, i.estimated_cost, i.required_skill,
 		       cr.id, cr.status, cr.requested_by_user_id, u2.name, cr.reason, 
 		       cr.reviewed_by_user_id, u3.name, cr.review_reason, cr.created_at, cr.reviewed_at
 		FROM initiatives i
@@ -155,6 +172,10 @@ func (r *InitiativeRepositoryImpl) GetByIDWithCancellation(ctx context.Context,
 		&initiative.Deadline,
 		&initiative.CreatedAt,
 		&initiative.UpdatedAt,
+		&initiative.Score,
+		&initiative.EffortDays,
+		&initiative.EstimatedCost,
+		&initiative.RequiredSkill,
 		&crID,
 		&crStatus,
 		&crRequestedByUserID,
@@ -201,7 +222,7 @@ func (r *InitiativeRepositoryImpl) GetByIDWithCancellation(ctx context.Context,
 func (r *InitiativeRepositoryImpl) ListAll(ctx context.Context, filter *entities.InitiativeFilter) ([]*entities.Initiative, error) {
 	query := `
 		SELECT i.id, i.title, i.description, i.benefits, i.status, i.type, i.priority, i.sector, 
-		       i.owner_id, u. name as owner_name, i. deadline, i.created_at, i.updated_at
+		       i.owner_id, u. name as owner_name, i. deadline, i.created_at, i.updated_at, i.score, i.effort_days, i.estimated_cost, i.required_skill
 		FROM initiatives i
 		INNER JOIN users u ON u.id = i. owner_id
 		WHERE 1=1
@@ -212,7 +233,7 @@ func (r *InitiativeRepositoryImpl) ListAll(ctx context.Context, filter *entities
 
 	if filter != nil {
 		if filter.Search != "" {
-			query += fmt.Sprintf(" AND (LOWER(i.title) LIKE $%d OR LOWER(i. description) LIKE $%d)", argCount, argCount)
+			query += fmt.Sprintf(" AND (LOWER(i.title) LIKE $%d OR LOWER(i.description_text) LIKE $%d OR LOWER(i.benefits_text) LIKE $%d)", argCount, argCount, argCount)
 			args = append(args, "%"+strings.ToLower(filter.Search)+"%")
 			argCount++
 		}
@@ -267,6 +288,10 @@ func (r *InitiativeRepositoryImpl) ListAll(ctx context.Context, filter *entities
 			&initiative.Deadline,
 			&initiative.CreatedAt,
 			&initiative.UpdatedAt,
+			&initiative.Score,
+			&initiative.EffortDays,
+			&initiative.EstimatedCost,
+			&initiative.RequiredSkill,
 		)
 		if err != nil {
 			return nil, err
@@ -282,7 +307,7 @@ func (r *InitiativeRepositoryImpl) ListAll(ctx context.Context, filter *entities
 func (r *InitiativeRepositoryImpl) ListAllWithCancellation(ctx context.Context, filter *entities.InitiativeFilter) ([]*entities.Initiative, error) {
 	query := `
 		SELECT i.id, i.title, i.description, i.benefits, i.status, i.type, i.priority, i.sector, 
-		       i.owner_id, u.name as owner_name, i.deadline, i.created_at, i.updated_at,
+		       i.owner_id, u.name as owner_name, i.deadline, i.created_at, i.updated_at, i.score, i.effort_days, i.estimated_cost, i.required_skill,
 		       cr.id, cr.status, cr.requested_by_user_id, u2.name, cr.reason, 
 		       cr.reviewed_by_user_id, u3.name, cr.review_reason, cr.created_at, cr.reviewed_at
 		FROM initiatives i
@@ -304,7 +329,7 @@ func (r *InitiativeRepositoryImpl) ListAllWithCancellation(ctx context.Context,
 
 	if filter != nil {
 		if filter.Search != "" {
-			query += fmt.Sprintf(" AND (LOWER(i.title) LIKE $%d OR LOWER(i. description) LIKE $%d)", argCount, argCount)
+			query += fmt.Sprintf(" AND (LOWER(i.title) LIKE $%d OR LOWER(i.description_text) LIKE $%d OR LOWER(i.benefits_text) LIKE $%d)", argCount, argCount, argCount)
 			args = append(args, "%"+strings.ToLower(filter.Search)+"%")
 			argCount++
 		}
@@ -374,6 +399,10 @@ func (r *InitiativeRepositoryImpl) ListAllWithCancellation(ctx context.Context,
 			&initiative.Deadline,
 			&initiative.CreatedAt,
 			&initiative.UpdatedAt,
+			&initiative.Score,
+			&initiative.EffortDays,
+			&initiative.EstimatedCost,
+			&initiative.RequiredSkill,
 			&crID,
 			&crStatus,
 			&crRequestedByUserID,
@@ -490,7 +519,7 @@ func (r *InitiativeRepositoryImpl) ChangeStatusWithUser(ctx context.Context, ini
 func (r *InitiativeRepositoryImpl) GetByOwner(ctx context.Context, ownerID int64) ([]*entities.Initiative, error) {
 	query := `
 		SELECT i.id, i.title, i.description, i.benefits, i.status, i.type, i.priority, i.sector, 
-		       i.owner_id, u.name as owner_name, i.deadline, i.created_at, i.updated_at
+		       i.owner_id, u.name as owner_name, i.deadline, i.created_at, i.updated_at, i.score, i.effort_days, i.estimated_cost, i.required_skill
 		FROM initiatives i
 		INNER JOIN users u ON u.id = i.owner_id
 		WHERE i.owner_id = $1
@@ -520,6 +549,10 @@ func (r *InitiativeRepositoryImpl) GetByOwner(ctx context.Context, ownerID int64
 			&initiative.Deadline,
 			&initiative.CreatedAt,
 			&initiative.UpdatedAt,
+			&initiative.Score,
+			&initiative.EffortDays,
+			&initiative.EstimatedCost,
+			&initiative.RequiredSkill,
 		)
 		if err != nil {
 			return nil, err
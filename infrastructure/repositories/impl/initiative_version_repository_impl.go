@@ -0,0 +1,109 @@
+package repository_impl
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"hackathon-backend/domain/entities"
+)
+
+type InitiativeVersionRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewInitiativeVersionRepositoryImpl(db *sql.DB) *InitiativeVersionRepositoryImpl {
+	return &InitiativeVersionRepositoryImpl{db: db}
+}
+
+// Create grava a próxima versão da iniciativa (o número é calculado no banco)
+func (r *InitiativeVersionRepositoryImpl) Create(ctx context.Context, version *entities.InitiativeVersion) error {
+	snapshotJSON, err := json.Marshal(version.Snapshot)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO initiative_versions (initiative_id, version, snapshot, edited_by_user_id, restored_from_version, created_at)
+		VALUES ($1, (SELECT COALESCE(MAX(version), 0) + 1 FROM initiative_versions WHERE initiative_id = $1),
+		        $2, $3, $4, NOW())
+		RETURNING id, version, created_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		version.InitiativeID,
+		snapshotJSON,
+		version.EditedByUserID,
+		version.RestoredFromVersion,
+	).Scan(&version.ID, &version.Version, &version.CreatedAt)
+}
+
+func (r *InitiativeVersionRepositoryImpl) ListByInitiative(ctx context.Context, initiativeID int64) ([]*entities.InitiativeVersion, error) {
+	query := `
+		SELECT v.id, v.initiative_id, v.version, v.snapshot, v.edited_by_user_id, u.name,
+		       v.restored_from_version, v.created_at
+		FROM initiative_versions v
+		INNER JOIN users u ON u.id = v.edited_by_user_id
+		WHERE v.initiative_id = $1
+		ORDER BY v.version ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, initiativeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []*entities.InitiativeVersion
+	for rows.Next() {
+		version, err := scanInitiativeVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+func (r *InitiativeVersionRepositoryImpl) GetByVersion(ctx context.Context, initiativeID int64, version int) (*entities.InitiativeVersion, error) {
+	query := `
+		SELECT v.id, v.initiative_id, v.version, v.snapshot, v.edited_by_user_id, u.name,
+		       v.restored_from_version, v.created_at
+		FROM initiative_versions v
+		INNER JOIN users u ON u.id = v.edited_by_user_id
+		WHERE v.initiative_id = $1 AND v.version = $2
+	`
+
+	return scanInitiativeVersion(r.db.QueryRowContext(ctx, query, initiativeID, version))
+}
+
+func scanInitiativeVersion(row rowScanner) (*entities.InitiativeVersion, error) {
+	version := &entities.InitiativeVersion{}
+	var snapshotJSON []byte
+	var restoredFrom sql.NullInt64
+
+	err := row.Scan(
+		&version.ID,
+		&version.InitiativeID,
+		&version.Version,
+		&snapshotJSON,
+		&version.EditedByUserID,
+		&version.EditedByName,
+		&restoredFrom,
+		&version.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(snapshotJSON, &version.Snapshot); err != nil {
+		return nil, err
+	}
+
+	if restoredFrom.Valid {
+		restored := int(restoredFrom.Int64)
+		version.RestoredFromVersion = &restored
+	}
+
+	return version, nil
+}
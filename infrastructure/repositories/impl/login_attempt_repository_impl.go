@@ -0,0 +1,173 @@
+package repository_impl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hackathon-backend/domain/entities"
+	"strings"
+	"time"
+)
+
+type LoginAttemptRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewLoginAttemptRepositoryImpl(db *sql.DB) *LoginAttemptRepositoryImpl {
+	return &LoginAttemptRepositoryImpl{db: db}
+}
+
+// RecordAttempt registra uma tentativa de login na auditoria
+func (r *LoginAttemptRepositoryImpl) RecordAttempt(ctx context.Context, attempt *entities.LoginAttempt) error {
+	query := `
+		INSERT INTO login_attempts (email, ip_address, success, failure_reason, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, created_at
+	`
+
+	var failureReason sql.NullString
+	if attempt.FailureReason != "" {
+		failureReason = sql.NullString{String: attempt.FailureReason, Valid: true}
+	}
+
+	return r.db.QueryRowContext(ctx, query,
+		attempt.Email,
+		attempt.IPAddress,
+		attempt.Success,
+		failureReason,
+	).Scan(&attempt.ID, &attempt.CreatedAt)
+}
+
+// ListAttempts lista as tentativas de login mais recentes
+func (r *LoginAttemptRepositoryImpl) ListAttempts(ctx context.Context, filter *entities.LoginAttemptFilter) ([]*entities.LoginAttempt, error) {
+	query := `
+		SELECT id, email, ip_address, success, failure_reason, created_at
+		FROM login_attempts
+		WHERE 1=1
+	`
+
+	var args []interface{}
+	argCount := 1
+	limit := 100
+
+	if filter != nil {
+		if filter.Email != "" {
+			query += fmt.Sprintf(" AND email = $%d", argCount)
+			args = append(args, strings.ToLower(strings.TrimSpace(filter.Email)))
+			argCount++
+		}
+
+		if filter.IPAddress != "" {
+			query += fmt.Sprintf(" AND ip_address = $%d", argCount)
+			args = append(args, filter.IPAddress)
+			argCount++
+		}
+
+		if filter.OnlyFailed {
+			query += " AND success = false"
+		}
+
+		if filter.Limit > 0 && filter.Limit <= 1000 {
+			limit = filter.Limit
+		}
+	}
+
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", argCount)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []*entities.LoginAttempt
+	for rows.Next() {
+		attempt := &entities.LoginAttempt{}
+		var failureReason sql.NullString
+
+		err := rows.Scan(
+			&attempt.ID,
+			&attempt.Email,
+			&attempt.IPAddress,
+			&attempt.Success,
+			&failureReason,
+			&attempt.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if failureReason.Valid {
+			attempt.FailureReason = failureReason.String
+		}
+
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, nil
+}
+
+// GetLockout busca o contador de falhas de uma conta ou IP
+func (r *LoginAttemptRepositoryImpl) GetLockout(ctx context.Context, scope, identifier string) (*entities.LoginLockout, error) {
+	query := `
+		SELECT scope, identifier, failed_count, locked_until, last_failed_at
+		FROM login_lockouts
+		WHERE scope = $1 AND identifier = $2
+	`
+
+	lockout := &entities.LoginLockout{}
+	var lockedUntil sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, scope, identifier).Scan(
+		&lockout.Scope,
+		&lockout.Identifier,
+		&lockout.FailedCount,
+		&lockedUntil,
+		&lockout.LastFailedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if lockedUntil.Valid {
+		lockout.LockedUntil = &lockedUntil.Time
+	}
+
+	return lockout, nil
+}
+
+// IncrementFailures incrementa atomicamente o contador de falhas.
+// Se a última falha for mais antiga que a janela, o contador recomeça em 1.
+func (r *LoginAttemptRepositoryImpl) IncrementFailures(ctx context.Context, scope, identifier string, window time.Duration) (int, error) {
+	query := `
+		INSERT INTO login_lockouts (scope, identifier, failed_count, last_failed_at)
+		VALUES ($1, $2, 1, NOW())
+		ON CONFLICT (scope, identifier) DO UPDATE
+		SET failed_count = CASE
+		        WHEN login_lockouts.last_failed_at < NOW() - make_interval(secs => $3) THEN 1
+		        ELSE login_lockouts.failed_count + 1
+		    END,
+		    last_failed_at = NOW()
+		RETURNING failed_count
+	`
+
+	var failedCount int
+	err := r.db.QueryRowContext(ctx, query, scope, identifier, window.Seconds()).Scan(&failedCount)
+	return failedCount, err
+}
+
+// SetLockedUntil define até quando a conta ou IP fica bloqueado
+func (r *LoginAttemptRepositoryImpl) SetLockedUntil(ctx context.Context, scope, identifier string, lockedUntil time.Time) error {
+	query := `UPDATE login_lockouts SET locked_until = $1 WHERE scope = $2 AND identifier = $3`
+	_, err := r.db.ExecContext(ctx, query, lockedUntil, scope, identifier)
+	return err
+}
+
+// ClearLockout zera o contador e remove o bloqueio
+func (r *LoginAttemptRepositoryImpl) ClearLockout(ctx context.Context, scope, identifier string) error {
+	query := `DELETE FROM login_lockouts WHERE scope = $1 AND identifier = $2`
+	_, err := r.db.ExecContext(ctx, query, scope, identifier)
+	return err
+}
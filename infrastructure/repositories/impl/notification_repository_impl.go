@@ -0,0 +1,137 @@
+package repository_impl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hackathon-backend/domain/entities"
+)
+
+type NotificationRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewNotificationRepositoryImpl(db *sql.DB) *NotificationRepositoryImpl {
+	return &NotificationRepositoryImpl{db: db}
+}
+
+func (r *NotificationRepositoryImpl) Create(ctx context.Context, notification *entities.Notification) error {
+	query := `
+		INSERT INTO notifications (user_id, type, title, message, initiative_id, comment_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING id, created_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		notification.UserID,
+		notification.Type,
+		notification.Title,
+		notification.Message,
+		notification.InitiativeID,
+		notification.CommentID,
+	).Scan(&notification.ID, &notification.CreatedAt)
+}
+
+func (r *NotificationRepositoryImpl) ListByUser(ctx context.Context, userID int64, filter *entities.NotificationFilter) ([]*entities.Notification, error) {
+	query := `
+		SELECT id, user_id, type, title, message, initiative_id, comment_id, read_at, created_at
+		FROM notifications
+		WHERE user_id = $1
+	`
+
+	args := []interface{}{userID}
+	argCount := 2
+	limit := 50
+
+	if filter != nil {
+		if filter.UnreadOnly {
+			query += " AND read_at IS NULL"
+		}
+		if filter.Limit > 0 && filter.Limit <= 200 {
+			limit = filter.Limit
+		}
+	}
+
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", argCount)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*entities.Notification
+	for rows.Next() {
+		notification := &entities.Notification{}
+		var initiativeID, commentID sql.NullInt64
+		var readAt sql.NullTime
+
+		err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Type,
+			&notification.Title,
+			&notification.Message,
+			&initiativeID,
+			&commentID,
+			&readAt,
+			&notification.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if initiativeID.Valid {
+			notification.InitiativeID = &initiativeID.Int64
+		}
+		if commentID.Valid {
+			notification.CommentID = &commentID.Int64
+		}
+		if readAt.Valid {
+			notification.ReadAt = &readAt.Time
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepositoryImpl) CountUnread(ctx context.Context, userID int64) (int, error) {
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+// MarkAsRead marca a notificação como lida (somente do próprio usuário)
+func (r *NotificationRepositoryImpl) MarkAsRead(ctx context.Context, notificationID, userID int64) error {
+	query := `
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, notificationID, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *NotificationRepositoryImpl) MarkAllAsRead(ctx context.Context, userID int64) error {
+	query := `UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,210 @@
+package repository_impl
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// Algoritmos aceitos no ID token ("none" e HMAC nunca são aceitos)
+var jwtAlgorithms = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+	"ES256": crypto.SHA256,
+	"ES384": crypto.SHA384,
+}
+
+// verifySignature confere a assinatura do JWT com as chaves do JWKS e retorna o payload
+func (r *OIDCRepositoryImpl) verifySignature(ctx context.Context, discovery *oidcDiscovery, rawToken string) ([]byte, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("id_token malformado")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errors.New("cabeçalho do id_token inválido")
+	}
+
+	var header jwtHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, errors.New("cabeçalho do id_token inválido")
+	}
+
+	hash, ok := jwtAlgorithms[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("algoritmo não suportado no id_token: %s", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("assinatura do id_token inválida")
+	}
+
+	key, err := r.getKey(ctx, discovery, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := hash.New()
+	hasher.Write([]byte(parts[0] + "." + parts[1]))
+	digest := hasher.Sum(nil)
+
+	switch publicKey := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(header.Alg, "RS") {
+			return nil, errors.New("algoritmo incompatível com a chave do provedor")
+		}
+		if err := rsa.VerifyPKCS1v15(publicKey, hash, digest, signature); err != nil {
+			return nil, errors.New("assinatura do id_token inválida")
+		}
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(header.Alg, "ES") {
+			return nil, errors.New("algoritmo incompatível com a chave do provedor")
+		}
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return nil, errors.New("assinatura do id_token inválida")
+		}
+		rInt := new(big.Int).SetBytes(signature[:size])
+		sInt := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(publicKey, digest, rInt, sInt) {
+			return nil, errors.New("assinatura do id_token inválida")
+		}
+	default:
+		return nil, errors.New("tipo de chave não suportado")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.New("payload do id_token inválido")
+	}
+
+	return payload, nil
+}
+
+// getKey busca a chave pelo kid; se não encontrar, recarrega o JWKS (rotação de chaves)
+func (r *OIDCRepositoryImpl) getKey(ctx context.Context, discovery *oidcDiscovery, kid string) (interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expired := time.Since(r.keysFetchedAt) > oidcCacheTTL
+	if key, ok := r.findKey(kid); ok && !expired {
+		return key, nil
+	}
+
+	if !expired && time.Since(r.keysFetchedAt) < jwksMinRefreshInterval {
+		return nil, fmt.Errorf("chave %q não encontrada no JWKS do provedor", kid)
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := r.getJSON(ctx, discovery.JWKSURI, &jwks); err != nil {
+		return nil, fmt.Errorf("erro ao buscar JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{})
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJSONWebKey(jwk)
+		if err != nil {
+			continue // Ignorar chaves de tipos não suportados
+		}
+		keys[jwk.Kid] = key
+	}
+
+	r.keys = keys
+	r.keysFetchedAt = time.Now()
+
+	if key, ok := r.findKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("chave %q não encontrada no JWKS do provedor", kid)
+}
+
+// findKey: sem kid no token, só aceita se o JWKS tiver uma única chave
+func (r *OIDCRepositoryImpl) findKey(kid string) (interface{}, bool) {
+	if kid == "" && len(r.keys) == 1 {
+		for _, key := range r.keys {
+			return key, true
+		}
+	}
+	key, ok := r.keys[kid]
+	return key, ok
+}
+
+func parseJSONWebKey(jwk jsonWebKey) (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("curva não suportada: %s", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("tipo de chave não suportado: %s", jwk.Kty)
+}
@@ -0,0 +1,302 @@
+package repository_impl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hackathon-backend/domain/entities"
+	"hackathon-backend/settings_loader"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tempo de cache do documento de discovery e das chaves públicas
+const oidcCacheTTL = time.Hour
+
+// Intervalo mínimo entre recargas do JWKS provocadas por kid desconhecido
+const jwksMinRefreshInterval = time.Minute
+
+// Tolerância de relógio na validação de exp/iat
+const idTokenClockSkew = time.Minute
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type OIDCRepositoryImpl struct {
+	settings *settings_loader.SettingsLoader
+	client   *http.Client
+
+	mu            sync.Mutex
+	discovery     *oidcDiscovery
+	discoveredAt  time.Time
+	keys          map[string]interface{} // kid → *rsa.PublicKey / *ecdsa.PublicKey
+	keysFetchedAt time.Time
+}
+
+func NewOIDCRepositoryImpl(settings *settings_loader.SettingsLoader) *OIDCRepositoryImpl {
+	return &OIDCRepositoryImpl{
+		settings: settings,
+		client: &http.Client{
+			Timeout: time.Duration(settings.OIDC.RequestTimeout) * time.Second,
+		},
+	}
+}
+
+// AuthorizationURL monta a URL de login no provedor (authorization code + PKCE S256)
+func (r *OIDCRepositoryImpl) AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	discovery, err := r.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", r.settings.OIDC.ClientID)
+	params.Set("redirect_uri", r.settings.OIDC.RedirectURL)
+	params.Set("scope", strings.Join(r.settings.OIDC.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return discovery.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// ExchangeCode troca o authorization code pelo ID token no token endpoint
+func (r *OIDCRepositoryImpl) ExchangeCode(ctx context.Context, code, codeVerifier string) (string, error) {
+	discovery, err := r.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", r.settings.OIDC.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+
+	// Cliente público (sem segredo) identifica-se apenas pelo client_id
+	clientSecret := r.settings.OIDC.ClientSecret
+	if clientSecret == "" {
+		form.Set("client_id", r.settings.OIDC.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("erro ao criar requisição de token: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	// Cliente confidencial usa client_secret_basic
+	if clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(r.settings.OIDC.ClientID), url.QueryEscape(clientSecret))
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("erro ao chamar token endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler resposta do token endpoint: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint retornou status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("erro ao decodificar resposta do token endpoint: %w", err)
+	}
+
+	if tokenResp.IDToken == "" {
+		return "", errors.New("provedor não retornou id_token")
+	}
+
+	return tokenResp.IDToken, nil
+}
+
+// VerifyIDToken valida assinatura (JWKS), emissor, audiência, validade e nonce do ID token
+func (r *OIDCRepositoryImpl) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*entities.OIDCClaims, error) {
+	discovery, err := r.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := r.verifySignature(ctx, discovery, rawIDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("claims inválidas no id_token: %w", err)
+	}
+
+	if iss, _ := claims["iss"].(string); iss != discovery.Issuer {
+		return nil, fmt.Errorf("emissor inválido no id_token: %q", iss)
+	}
+
+	if !audienceContains(claims["aud"], r.settings.OIDC.ClientID) {
+		return nil, errors.New("audiência inválida no id_token")
+	}
+	if azp, ok := claims["azp"].(string); ok && azp != r.settings.OIDC.ClientID {
+		return nil, errors.New("azp inválido no id_token")
+	}
+
+	now := time.Now()
+	exp, ok := numericClaim(claims["exp"])
+	if !ok || now.After(time.Unix(exp, 0).Add(idTokenClockSkew)) {
+		return nil, errors.New("id_token expirado")
+	}
+	if iat, ok := numericClaim(claims["iat"]); ok && time.Unix(iat, 0).After(now.Add(idTokenClockSkew)) {
+		return nil, errors.New("id_token emitido no futuro")
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce == "" || tokenNonce != nonce {
+		return nil, errors.New("nonce inválido no id_token")
+	}
+
+	result := &entities.OIDCClaims{
+		Issuer:        discovery.Issuer,
+		Subject:       stringClaim(claims["sub"]),
+		Email:         stringClaim(claims["email"]),
+		EmailVerified: boolClaim(claims["email_verified"]),
+		Name:          stringClaim(claims["name"]),
+		Groups:        stringListClaim(claims[r.settings.OIDC.GroupsClaim]),
+	}
+	if r.settings.OIDC.SectorClaim != "" {
+		result.Sector = stringClaim(claims[r.settings.OIDC.SectorClaim])
+	}
+
+	if result.Subject == "" {
+		return nil, errors.New("id_token sem sub")
+	}
+
+	return result, nil
+}
+
+// getDiscovery busca (com cache) o documento /.well-known/openid-configuration
+func (r *OIDCRepositoryImpl) getDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.discovery != nil && time.Since(r.discoveredAt) < oidcCacheTTL {
+		return r.discovery, nil
+	}
+
+	issuer := strings.TrimSuffix(r.settings.OIDC.IssuerURL, "/")
+
+	var discovery oidcDiscovery
+	if err := r.getJSON(ctx, issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, fmt.Errorf("erro no discovery do provedor: %w", err)
+	}
+
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("issuer do discovery (%s) difere do configurado (%s)", discovery.Issuer, issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("discovery do provedor incompleto")
+	}
+
+	r.discovery = &discovery
+	r.discoveredAt = time.Now()
+	return r.discovery, nil
+}
+
+func (r *OIDCRepositoryImpl) getJSON(ctx context.Context, endpoint string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s retornou status %d", endpoint, resp.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(target)
+}
+
+func audienceContains(aud interface{}, clientID string) bool {
+	switch value := aud.(type) {
+	case string:
+		return value == clientID
+	case []interface{}:
+		for _, item := range value {
+			if s, ok := item.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func numericClaim(value interface{}) (int64, bool) {
+	n, ok := value.(float64)
+	return int64(n), ok
+}
+
+func stringClaim(value interface{}) string {
+	s, _ := value.(string)
+	return strings.TrimSpace(s)
+}
+
+// boolClaim aceita true e "true" (alguns provedores enviam email_verified como string)
+func boolClaim(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
+}
+
+// stringListClaim aceita lista de strings ou string única / separada por vírgula
+func stringListClaim(value interface{}) []string {
+	var items []string
+
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+				items = append(items, strings.TrimSpace(s))
+			}
+		}
+	case string:
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items
+}
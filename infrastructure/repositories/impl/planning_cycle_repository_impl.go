@@ -0,0 +1,197 @@
+package repository_impl
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"hackathon-backend/domain/entities"
+	"time"
+)
+
+type PlanningCycleRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewPlanningCycleRepositoryImpl(db *sql.DB) *PlanningCycleRepositoryImpl {
+	return &PlanningCycleRepositoryImpl{db: db}
+}
+
+const planningCycleColumns = `
+		SELECT c.id, c.year, c.quarter, c.name, c.opens_at, c.closes_at, c.sector_ids, c.opened_at, c.closed_at,
+		       c.created_by_user_id, u.name, c.created_at, c.updated_at
+		FROM planning_cycles c
+		INNER JOIN users u ON u.id = c.created_by_user_id
+`
+
+func (r *PlanningCycleRepositoryImpl) Create(ctx context.Context, cycle *entities.PlanningCycle) error {
+	sectorsJSON, err := json.Marshal(nonNilIDs(cycle.SectorIDs))
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO planning_cycles (year, quarter, name, opens_at, closes_at, sector_ids, created_by_user_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		cycle.Year,
+		cycle.Quarter,
+		cycle.Name,
+		cycle.OpensAt,
+		cycle.ClosesAt,
+		sectorsJSON,
+		cycle.CreatedByUserID,
+	).Scan(&cycle.ID, &cycle.CreatedAt, &cycle.UpdatedAt)
+}
+
+// Update altera a janela e os participantes de um ciclo ainda não encerrado
+func (r *PlanningCycleRepositoryImpl) Update(ctx context.Context, cycle *entities.PlanningCycle) error {
+	sectorsJSON, err := json.Marshal(nonNilIDs(cycle.SectorIDs))
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE planning_cycles
+		SET name = $1, opens_at = $2, closes_at = $3, sector_ids = $4, updated_at = NOW()
+		WHERE id = $5 AND closed_at IS NULL
+		RETURNING updated_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		cycle.Name,
+		cycle.OpensAt,
+		cycle.ClosesAt,
+		sectorsJSON,
+		cycle.ID,
+	).Scan(&cycle.UpdatedAt)
+}
+
+func (r *PlanningCycleRepositoryImpl) GetByID(ctx context.Context, cycleID int64) (*entities.PlanningCycle, error) {
+	query := planningCycleColumns + ` WHERE c.id = $1`
+	return scanPlanningCycle(r.db.QueryRowContext(ctx, query, cycleID))
+}
+
+// List lista os ciclos do ano (0 = todos) em ordem de abertura
+func (r *PlanningCycleRepositoryImpl) List(ctx context.Context, year int) ([]*entities.PlanningCycle, error) {
+	query := planningCycleColumns
+	var args []interface{}
+	if year != 0 {
+		query += " WHERE c.year = $1"
+		args = append(args, year)
+	}
+	query += " ORDER BY c.opens_at ASC"
+
+	return r.queryCycles(ctx, query, args...)
+}
+
+// HasOverlap verifica se outro ciclo do mesmo ano tem janela sobreposta
+func (r *PlanningCycleRepositoryImpl) HasOverlap(ctx context.Context, year int, opensAt, closesAt time.Time, excludeID int64) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM planning_cycles
+			WHERE year = $1 AND id <> $2 AND opens_at < $4 AND closes_at > $3
+		)
+	`
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, year, excludeID, opensAt, closesAt).Scan(&exists)
+	return exists, err
+}
+
+// ListDueToOpen lista ciclos cuja janela começou e a abertura ainda não foi processada
+func (r *PlanningCycleRepositoryImpl) ListDueToOpen(ctx context.Context) ([]*entities.PlanningCycle, error) {
+	query := planningCycleColumns + `
+		WHERE c.opened_at IS NULL AND c.closed_at IS NULL AND c.opens_at <= NOW() AND c.closes_at > NOW()
+		ORDER BY c.opens_at ASC
+	`
+	return r.queryCycles(ctx, query)
+}
+
+// ListDueToClose lista ciclos cuja janela terminou e que ainda não foram encerrados
+func (r *PlanningCycleRepositoryImpl) ListDueToClose(ctx context.Context) ([]*entities.PlanningCycle, error) {
+	query := planningCycleColumns + `
+		WHERE c.closed_at IS NULL AND c.closes_at <= NOW()
+		ORDER BY c.closes_at ASC
+	`
+	return r.queryCycles(ctx, query)
+}
+
+func (r *PlanningCycleRepositoryImpl) MarkOpened(ctx context.Context, cycleID int64) error {
+	query := `UPDATE planning_cycles SET opened_at = NOW(), updated_at = NOW() WHERE id = $1 AND opened_at IS NULL`
+	return execAffectingOne(ctx, r.db, query, cycleID)
+}
+
+// MarkClosed encerra o ciclo; no encerramento manual a janela é antecipada para agora
+func (r *PlanningCycleRepositoryImpl) MarkClosed(ctx context.Context, cycleID int64) error {
+	query := `
+		UPDATE planning_cycles
+		SET closed_at = NOW(), closes_at = LEAST(closes_at, NOW()), updated_at = NOW()
+		WHERE id = $1 AND closed_at IS NULL
+	`
+	return execAffectingOne(ctx, r.db, query, cycleID)
+}
+
+func (r *PlanningCycleRepositoryImpl) queryCycles(ctx context.Context, query string, args ...interface{}) ([]*entities.PlanningCycle, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cycles []*entities.PlanningCycle
+	for rows.Next() {
+		cycle, err := scanPlanningCycle(rows)
+		if err != nil {
+			return nil, err
+		}
+		cycles = append(cycles, cycle)
+	}
+
+	return cycles, nil
+}
+
+func scanPlanningCycle(row rowScanner) (*entities.PlanningCycle, error) {
+	cycle := &entities.PlanningCycle{}
+	var quarter sql.NullInt64
+	var sectorsJSON []byte
+	var openedAt, closedAt sql.NullTime
+
+	err := row.Scan(
+		&cycle.ID,
+		&cycle.Year,
+		&quarter,
+		&cycle.Name,
+		&cycle.OpensAt,
+		&cycle.ClosesAt,
+		&sectorsJSON,
+		&openedAt,
+		&closedAt,
+		&cycle.CreatedByUserID,
+		&cycle.CreatedByName,
+		&cycle.CreatedAt,
+		&cycle.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(sectorsJSON, &cycle.SectorIDs); err != nil {
+		return nil, err
+	}
+
+	if quarter.Valid {
+		q := int(quarter.Int64)
+		cycle.Quarter = &q
+	}
+	if openedAt.Valid {
+		cycle.OpenedAt = &openedAt.Time
+	}
+	if closedAt.Valid {
+		cycle.ClosedAt = &closedAt.Time
+	}
+
+	return cycle, nil
+}
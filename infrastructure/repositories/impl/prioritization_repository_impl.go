@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"hackathon-backend/domain/entities"
+	"slices"
 )
 
 type PrioritizationRepositoryImpl struct {
@@ -22,9 +23,14 @@ func (r *PrioritizationRepositoryImpl) Create(ctx context.Context, prioritizatio
 		return err
 	}
 
+	unrankedJSON, err := json.Marshal(nonNilIDs(prioritization.UnrankedIDs))
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO initiative_prioritization (sector_id, year, priority_order, is_locked, created_by_user_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		INSERT INTO initiative_prioritization (sector_id, year, priority_order, unranked_ids, is_locked, status, created_by_user_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
 		RETURNING id, created_at, updated_at
 	`
 
@@ -32,7 +38,9 @@ func (r *PrioritizationRepositoryImpl) Create(ctx context.Context, prioritizatio
 		prioritization.SectorID,
 		prioritization.Year,
 		priorityOrderJSON,
+		unrankedJSON,
 		prioritization.IsLocked,
+		prioritization.Status,
 		prioritization.CreatedByUserID,
 	).Scan(&prioritization.ID, &prioritization.CreatedAt, &prioritization.UpdatedAt)
 }
@@ -44,15 +52,21 @@ func (r *PrioritizationRepositoryImpl) Update(ctx context.Context, prioritizatio
 		return err
 	}
 
+	unrankedJSON, err := json.Marshal(nonNilIDs(prioritization.UnrankedIDs))
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE initiative_prioritization
-		SET priority_order = $1, is_locked = $2, updated_at = NOW()
-		WHERE id = $3
+		SET priority_order = $1, unranked_ids = $2, is_locked = $3, updated_at = NOW()
+		WHERE id = $4
 		RETURNING updated_at
 	`
 
 	return r.db.QueryRowContext(ctx, query,
 		priorityOrderJSON,
+		unrankedJSON,
 		prioritization.IsLocked,
 		prioritization.ID,
 	).Scan(&prioritization.UpdatedAt)
@@ -61,46 +75,41 @@ func (r *PrioritizationRepositoryImpl) Update(ctx context.Context, prioritizatio
 // GetBySectorAndYear busca priorização por setor e ano
 func (r *PrioritizationRepositoryImpl) GetBySectorAndYear(ctx context.Context, sectorID int64, year int) (*entities.InitiativePrioritization, error) {
 	query := `
-		SELECT p.id, p.sector_id, s.name as sector_name, p.year, p.priority_order, p. is_locked, 
-		       p.created_by_user_id, u.name as created_by_name, p.created_at, p.updated_at
+		SELECT p.id, p.sector_id, s.name as sector_name, p.year, p.priority_order, p.unranked_ids, p.is_locked, p.status,
+		       p.created_by_user_id, u.name as created_by_name, p.submitted_by_user_id, p.submitted_at,
+		       p.reopened_by_user_id, p.reopen_reason, p.reopened_at, p.created_at, p.updated_at
 		FROM initiative_prioritization p
 		INNER JOIN sectors s ON s.id = p.sector_id
 		INNER JOIN users u ON u.id = p.created_by_user_id
 		WHERE p.sector_id = $1 AND p.year = $2
 	`
 
-	prioritization := &entities.InitiativePrioritization{}
-	var priorityOrderJSON []byte
-
-	err := r.db.QueryRowContext(ctx, query, sectorID, year).Scan(
-		&prioritization.ID,
-		&prioritization.SectorID,
-		&prioritization.SectorName,
-		&prioritization.Year,
-		&priorityOrderJSON,
-		&prioritization.IsLocked,
-		&prioritization.CreatedByUserID,
-		&prioritization.CreatedByName,
-		&prioritization.CreatedAt,
-		&prioritization.UpdatedAt,
-	)
-
-	if err != nil {
-		return nil, err
-	}
+	return scanPrioritization(r.db.QueryRowContext(ctx, query, sectorID, year))
+}
 
-	if err := json.Unmarshal(priorityOrderJSON, &prioritization.PriorityOrder); err != nil {
-		return nil, err
-	}
+// GetLatestBySectorBefore busca a priorização mais recente do setor em um ano anterior ao informado
+func (r *PrioritizationRepositoryImpl) GetLatestBySectorBefore(ctx context.Context, sectorID int64, year int) (*entities.InitiativePrioritization, error) {
+	query := `
+		SELECT p.id, p.sector_id, s.name as sector_name, p.year, p.priority_order, p.unranked_ids, p.is_locked, p.status,
+		       p.created_by_user_id, u.name as created_by_name, p.submitted_by_user_id, p.submitted_at,
+		       p.reopened_by_user_id, p.reopen_reason, p.reopened_at, p.created_at, p.updated_at
+		FROM initiative_prioritization p
+		INNER JOIN sectors s ON s.id = p.sector_id
+		INNER JOIN users u ON u.id = p.created_by_user_id
+		WHERE p.sector_id = $1 AND p.year < $2
+		ORDER BY p.year DESC
+		LIMIT 1
+	`
 
-	return prioritization, nil
+	return scanPrioritization(r.db.QueryRowContext(ctx, query, sectorID, year))
 }
 
 // GetAllByYear busca todas as priorizações de um ano
 func (r *PrioritizationRepositoryImpl) GetAllByYear(ctx context.Context, year int) ([]*entities.InitiativePrioritization, error) {
 	query := `
-		SELECT p.id, p.sector_id, s.name as sector_name, p.year, p.priority_order, p.is_locked, 
-		       p.created_by_user_id, u. name as created_by_name, p.created_at, p. updated_at
+		SELECT p.id, p.sector_id, s.name as sector_name, p.year, p.priority_order, p.unranked_ids, p.is_locked, p.status,
+		       p.created_by_user_id, u.name as created_by_name, p.submitted_by_user_id, p.submitted_at,
+		       p.reopened_by_user_id, p.reopen_reason, p.reopened_at, p.created_at, p.updated_at
 		FROM initiative_prioritization p
 		INNER JOIN sectors s ON s.id = p.sector_id
 		INNER JOIN users u ON u.id = p.created_by_user_id
@@ -116,29 +125,11 @@ func (r *PrioritizationRepositoryImpl) GetAllByYear(ctx context.Context, year in
 
 	var prioritizations []*entities.InitiativePrioritization
 	for rows.Next() {
-		prioritization := &entities.InitiativePrioritization{}
-		var priorityOrderJSON []byte
-
-		err := rows.Scan(
-			&prioritization.ID,
-			&prioritization.SectorID,
-			&prioritization.SectorName,
-			&prioritization.Year,
-			&priorityOrderJSON,
-			&prioritization.IsLocked,
-			&prioritization.CreatedByUserID,
-			&prioritization.CreatedByName,
-			&prioritization.CreatedAt,
-			&prioritization.UpdatedAt,
-		)
+		prioritization, err := scanPrioritization(rows)
 		if err != nil {
 			return nil, err
 		}
 
-		if err := json.Unmarshal(priorityOrderJSON, &prioritization.PriorityOrder); err != nil {
-			return nil, err
-		}
-
 		prioritizations = append(prioritizations, prioritization)
 	}
 
@@ -159,6 +150,39 @@ func (r *PrioritizationRepositoryImpl) UnlockPrioritization(ctx context.Context,
 	return err
 }
 
+// Submit envia um rascunho, bloqueando-o. userID nil = envio automático no encerramento do ciclo
+func (r *PrioritizationRepositoryImpl) Submit(ctx context.Context, prioritizationID int64, userID *int64) error {
+	query := `
+		UPDATE initiative_prioritization
+		SET status = $1, is_locked = true, submitted_by_user_id = $2, submitted_at = NOW(), updated_at = NOW()
+		WHERE id = $3 AND status = $4
+	`
+
+	return execAffectingOne(ctx, r.db, query,
+		entities.PrioritizationStatusSubmitted,
+		userID,
+		prioritizationID,
+		entities.PrioritizationStatusDraft,
+	)
+}
+
+// Reopen volta uma priorização enviada para rascunho. userID nil = reabertura pelo ciclo de planejamento
+func (r *PrioritizationRepositoryImpl) Reopen(ctx context.Context, prioritizationID int64, userID *int64, reason string) error {
+	query := `
+		UPDATE initiative_prioritization
+		SET status = $1, is_locked = false, reopened_by_user_id = $2, reopen_reason = $3, reopened_at = NOW(), updated_at = NOW()
+		WHERE id = $4 AND status = $5
+	`
+
+	return execAffectingOne(ctx, r.db, query,
+		entities.PrioritizationStatusDraft,
+		userID,
+		reason,
+		prioritizationID,
+		entities.PrioritizationStatusSubmitted,
+	)
+}
+
 // CreateChangeRequest cria uma solicitação de mudança
 func (r *PrioritizationRepositoryImpl) CreateChangeRequest(ctx context.Context, request *entities.PrioritizationChangeRequest) error {
 	newOrderJSON, err := json.Marshal(request.NewPriorityOrder)
@@ -166,9 +190,19 @@ func (r *PrioritizationRepositoryImpl) CreateChangeRequest(ctx context.Context,
 		return err
 	}
 
+	baseOrderJSON, err := json.Marshal(request.BaseOrder)
+	if err != nil {
+		return err
+	}
+
+	unrankedJSON, err := json.Marshal(nonNilIDs(request.NewUnrankedIDs))
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO prioritization_change_requests (prioritization_id, requested_by_user_id, new_priority_order, reason, status, created_at)
-		VALUES ($1, $2, $3, $4, $5, NOW())
+		INSERT INTO prioritization_change_requests (prioritization_id, requested_by_user_id, new_priority_order, base_priority_order, new_unranked_ids, reason, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
 		RETURNING id, created_at
 	`
 
@@ -176,6 +210,8 @@ func (r *PrioritizationRepositoryImpl) CreateChangeRequest(ctx context.Context,
 		request.PrioritizationID,
 		request.RequestedByUserID,
 		newOrderJSON,
+		baseOrderJSON,
+		unrankedJSON,
 		request.Reason,
 		entities.PrioritizationChangeStatusPending,
 	).Scan(&request.ID, &request.CreatedAt)
@@ -186,18 +222,21 @@ func (r *PrioritizationRepositoryImpl) GetChangeRequestByID(ctx context.Context,
 	query := `
 		SELECT cr.id, cr.prioritization_id, cr.requested_by_user_id, u1.name as requested_by_name,
 		       cr.new_priority_order, cr.reason, cr.status, cr.reviewed_by_user_id, u2.name as reviewed_by_name,
-		       cr.review_reason, cr.created_at, cr.reviewed_at
+		       cr.review_reason, cr.created_at, cr.reviewed_at,
+		       cr.base_priority_order, cr.replaced_priority_order, cr.applied_by_user_id, u3.name as applied_by_name, cr.applied_at,
+		       cr.new_unranked_ids
 		FROM prioritization_change_requests cr
 		INNER JOIN users u1 ON u1.id = cr.requested_by_user_id
 		LEFT JOIN users u2 ON u2.id = cr.reviewed_by_user_id
+		LEFT JOIN users u3 ON u3.id = cr.applied_by_user_id
 		WHERE cr.id = $1
 	`
 
 	request := &entities.PrioritizationChangeRequest{}
-	var newOrderJSON []byte
-	var reviewedByUserID sql.NullInt64
-	var reviewedByName, reviewReason sql.NullString
-	var reviewedAt sql.NullTime
+	var newOrderJSON, baseOrderJSON, replacedOrderJSON, unrankedJSON []byte
+	var reviewedByUserID, appliedByUserID sql.NullInt64
+	var reviewedByName, reviewReason, appliedByName sql.NullString
+	var reviewedAt, appliedAt sql.NullTime
 
 	err := r.db.QueryRowContext(ctx, query, requestID).Scan(
 		&request.ID,
@@ -212,6 +251,12 @@ func (r *PrioritizationRepositoryImpl) GetChangeRequestByID(ctx context.Context,
 		&reviewReason,
 		&request.CreatedAt,
 		&reviewedAt,
+		&baseOrderJSON,
+		&replacedOrderJSON,
+		&appliedByUserID,
+		&appliedByName,
+		&appliedAt,
+		&unrankedJSON,
 	)
 
 	if err != nil {
@@ -222,6 +267,33 @@ func (r *PrioritizationRepositoryImpl) GetChangeRequestByID(ctx context.Context,
 		return nil, err
 	}
 
+	if baseOrderJSON != nil {
+		if err := json.Unmarshal(baseOrderJSON, &request.BaseOrder); err != nil {
+			return nil, err
+		}
+	}
+
+	if unrankedJSON != nil {
+		if err := json.Unmarshal(unrankedJSON, &request.NewUnrankedIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if replacedOrderJSON != nil {
+		if err := json.Unmarshal(replacedOrderJSON, &request.ReplacedOrder); err != nil {
+			return nil, err
+		}
+	}
+
+	if appliedByUserID.Valid {
+		request.AppliedByUserID = &appliedByUserID.Int64
+		request.AppliedByName = appliedByName.String
+	}
+
+	if appliedAt.Valid {
+		request.AppliedAt = &appliedAt.Time
+	}
+
 	if reviewedByUserID.Valid {
 		request.ReviewedByUserID = &reviewedByUserID.Int64
 		request.ReviewedByName = reviewedByName.String
@@ -293,6 +365,377 @@ func (r *PrioritizationRepositoryImpl) UpdateChangeRequestStatus(ctx context.Con
 	return err
 }
 
+// ApplyChangeRequest aprova a solicitação e aplica a nova ordem na mesma transação, bloqueando a priorização.
+// Se a ordem salva mudou desde a solicitação (e force = false), nada é alterado e o conflito é retornado;
+// IDs que deixaram de ser elegíveis retornam *entities.PriorityOrderValidationError.
+func (r *PrioritizationRepositoryImpl) ApplyChangeRequest(ctx context.Context, requestID int64, reviewedByUserID int64, reviewReason string, force bool) (*entities.PrioritizationConflict, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var prioritizationID, requestedByUserID int64
+	var requestReason string
+	var newOrderJSON, baseOrderJSON []byte
+	requestQuery := `
+		SELECT prioritization_id, requested_by_user_id, reason, new_priority_order, base_priority_order
+		FROM prioritization_change_requests
+		WHERE id = $1 AND status = $2
+		FOR UPDATE
+	`
+	err = tx.QueryRowContext(ctx, requestQuery, requestID, entities.PrioritizationChangeStatusPending).
+		Scan(&prioritizationID, &requestedByUserID, &requestReason, &newOrderJSON, &baseOrderJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	var currentOrderJSON, currentUnrankedJSON, newUnrankedJSON []byte
+	var sectorName string
+	currentQuery := `
+		SELECT p.priority_order, p.unranked_ids, cr.new_unranked_ids, s.name
+		FROM initiative_prioritization p
+		INNER JOIN sectors s ON s.id = p.sector_id
+		INNER JOIN prioritization_change_requests cr ON cr.id = $2
+		WHERE p.id = $1
+		FOR UPDATE OF p
+	`
+	err = tx.QueryRowContext(ctx, currentQuery, prioritizationID, requestID).
+		Scan(&currentOrderJSON, &currentUnrankedJSON, &newUnrankedJSON, &sectorName)
+	if err != nil {
+		return nil, err
+	}
+
+	conflict := &entities.PrioritizationConflict{RequestID: requestID, PrioritizationID: prioritizationID}
+	if err := json.Unmarshal(newOrderJSON, &conflict.ProposedOrder); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(currentOrderJSON, &conflict.CurrentOrder); err != nil {
+		return nil, err
+	}
+	// Solicitações sem ordem base (anteriores ao controle) não têm como detectar conflito
+	if baseOrderJSON != nil {
+		if err := json.Unmarshal(baseOrderJSON, &conflict.BaseOrder); err != nil {
+			return nil, err
+		}
+		if !force && !slices.Equal(conflict.BaseOrder, conflict.CurrentOrder) {
+			return conflict, nil
+		}
+	}
+
+	// Solicitações antigas (sem new_unranked_ids) mantêm as não ranqueadas atuais
+	if newUnrankedJSON == nil {
+		newUnrankedJSON = currentUnrankedJSON
+	}
+	var unranked []int64
+	if err := json.Unmarshal(newUnrankedJSON, &unranked); err != nil {
+		return nil, err
+	}
+	if err := checkChangeRequestEligibility(ctx, tx, sectorName, conflict.ProposedOrder, unranked); err != nil {
+		return nil, err
+	}
+
+	updatePrioritization := `
+		UPDATE initiative_prioritization
+		SET priority_order = $1, unranked_ids = $2, is_locked = true, updated_at = NOW()
+		WHERE id = $3
+	`
+	if _, err := tx.ExecContext(ctx, updatePrioritization, newOrderJSON, newUnrankedJSON, prioritizationID); err != nil {
+		return nil, err
+	}
+
+	updateRequest := `
+		UPDATE prioritization_change_requests
+		SET status = $1, reviewed_by_user_id = $2, review_reason = $3, reviewed_at = NOW(),
+		    replaced_priority_order = $4, applied_by_user_id = $2, applied_at = NOW()
+		WHERE id = $5
+	`
+	_, err = tx.ExecContext(ctx, updateRequest,
+		entities.PrioritizationChangeStatusApproved,
+		reviewedByUserID,
+		reviewReason,
+		currentOrderJSON,
+		requestID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// A versão aplicada é atribuída ao solicitante, com a justificativa da solicitação
+	_, err = tx.ExecContext(ctx, insertPrioritizationVersionQuery,
+		prioritizationID,
+		newOrderJSON,
+		newUnrankedJSON,
+		entities.PrioritizationVersionSourceChangeRequest,
+		requestID,
+		nil,
+		requestReason,
+		requestedByUserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, tx.Commit()
+}
+
+// checkChangeRequestEligibility confere, com as iniciativas travadas na transação, se cada ID proposto
+// continua elegível e no setor (cancelamentos ou mudanças de setor após a solicitação são rejeitados)
+func checkChangeRequestEligibility(ctx context.Context, tx *sql.Tx, sectorName string, order, unranked []int64) error {
+	ids := append(append([]int64{}, order...), unranked...)
+	idsJSON, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		SELECT id, status, sector
+		FROM initiatives
+		WHERE id IN (SELECT value::bigint FROM jsonb_array_elements_text($1::jsonb))
+		FOR SHARE
+	`
+	rows, err := tx.QueryContext(ctx, query, idsJSON)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type initiativeState struct{ status, sector string }
+	found := make(map[int64]initiativeState)
+	for rows.Next() {
+		var id int64
+		var state initiativeState
+		if err := rows.Scan(&id, &state.status, &state.sector); err != nil {
+			return err
+		}
+		found[id] = state
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var issues []*entities.PriorityOrderIssue
+	for _, id := range ids {
+		state, ok := found[id]
+		switch {
+		case !ok:
+			issues = append(issues, &entities.PriorityOrderIssue{InitiativeID: id, Reason: entities.PriorityIssueNotFound, Message: "iniciativa não encontrada"})
+		case state.sector != sectorName:
+			issues = append(issues, &entities.PriorityOrderIssue{InitiativeID: id, Reason: entities.PriorityIssueOtherSector, Message: "iniciativa pertence ao setor " + state.sector})
+		case !entities.IsPrioritizationEligible(state.status):
+			issues = append(issues, &entities.PriorityOrderIssue{InitiativeID: id, Reason: entities.PriorityIssueIneligibleStatus, Message: "status " + state.status + " não pode ser priorizado"})
+		}
+	}
+
+	if len(issues) > 0 {
+		return &entities.PriorityOrderValidationError{Issues: issues}
+	}
+	return nil
+}
+
+// GetByID busca priorização por ID
+func (r *PrioritizationRepositoryImpl) GetByID(ctx context.Context, prioritizationID int64) (*entities.InitiativePrioritization, error) {
+	query := `
+		SELECT p.id, p.sector_id, s.name as sector_name, p.year, p.priority_order, p.unranked_ids, p.is_locked, p.status,
+		       p.created_by_user_id, u.name as created_by_name, p.submitted_by_user_id, p.submitted_at,
+		       p.reopened_by_user_id, p.reopen_reason, p.reopened_at, p.created_at, p.updated_at
+		FROM initiative_prioritization p
+		INNER JOIN sectors s ON s.id = p.sector_id
+		INNER JOIN users u ON u.id = p.created_by_user_id
+		WHERE p.id = $1
+	`
+
+	return scanPrioritization(r.db.QueryRowContext(ctx, query, prioritizationID))
+}
+
+func scanPrioritization(row rowScanner) (*entities.InitiativePrioritization, error) {
+	prioritization := &entities.InitiativePrioritization{}
+	var priorityOrderJSON, unrankedJSON []byte
+	var submittedBy, reopenedBy sql.NullInt64
+	var reopenReason sql.NullString
+	var submittedAt, reopenedAt sql.NullTime
+
+	err := row.Scan(
+		&prioritization.ID,
+		&prioritization.SectorID,
+		&prioritization.SectorName,
+		&prioritization.Year,
+		&priorityOrderJSON,
+		&unrankedJSON,
+		&prioritization.IsLocked,
+		&prioritization.Status,
+		&prioritization.CreatedByUserID,
+		&prioritization.CreatedByName,
+		&submittedBy,
+		&submittedAt,
+		&reopenedBy,
+		&reopenReason,
+		&reopenedAt,
+		&prioritization.CreatedAt,
+		&prioritization.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(priorityOrderJSON, &prioritization.PriorityOrder); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(unrankedJSON, &prioritization.UnrankedIDs); err != nil {
+		return nil, err
+	}
+
+	if submittedBy.Valid {
+		prioritization.SubmittedByUserID = &submittedBy.Int64
+	}
+	if submittedAt.Valid {
+		prioritization.SubmittedAt = &submittedAt.Time
+	}
+	if reopenedBy.Valid {
+		prioritization.ReopenedByUserID = &reopenedBy.Int64
+	}
+	if reopenedAt.Valid {
+		prioritization.ReopenedAt = &reopenedAt.Time
+		prioritization.ReopenReason = reopenReason.String
+	}
+
+	return prioritization, nil
+}
+
+// nonNilIDs evita gravar null nas colunas JSONB de listas
+func nonNilIDs(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+	return ids
+}
+
+// O número da versão é calculado no banco
+const insertPrioritizationVersionQuery = `
+	INSERT INTO prioritization_versions (prioritization_id, version, priority_order, unranked_ids, source, change_request_id,
+	                                     restored_from_version, reason, created_by_user_id, created_at)
+	VALUES ($1, (SELECT COALESCE(MAX(version), 0) + 1 FROM prioritization_versions WHERE prioritization_id = $1),
+	        $2, $3, $4, $5, $6, $7, $8, NOW())
+	RETURNING id, version, created_at
+`
+
+// CreateVersion grava a próxima versão da priorização
+func (r *PrioritizationRepositoryImpl) CreateVersion(ctx context.Context, version *entities.PrioritizationVersion) error {
+	priorityOrderJSON, err := json.Marshal(version.PriorityOrder)
+	if err != nil {
+		return err
+	}
+	unranked := version.UnrankedIDs
+	if unranked == nil {
+		unranked = []int64{}
+	}
+	unrankedJSON, err := json.Marshal(unranked)
+	if err != nil {
+		return err
+	}
+
+	return r.db.QueryRowContext(ctx, insertPrioritizationVersionQuery,
+		version.PrioritizationID,
+		priorityOrderJSON,
+		unrankedJSON,
+		version.Source,
+		version.ChangeRequestID,
+		version.RestoredFromVersion,
+		version.Reason,
+		version.CreatedByUserID,
+	).Scan(&version.ID, &version.Version, &version.CreatedAt)
+}
+
+// ListVersions lista as versões da priorização (mais antiga primeiro)
+func (r *PrioritizationRepositoryImpl) ListVersions(ctx context.Context, prioritizationID int64) ([]*entities.PrioritizationVersion, error) {
+	query := `
+		SELECT v.id, v.prioritization_id, v.version, v.priority_order, v.unranked_ids, v.source, v.change_request_id,
+		       v.restored_from_version, v.reason, v.created_by_user_id, u.name, v.created_at
+		FROM prioritization_versions v
+		INNER JOIN users u ON u.id = v.created_by_user_id
+		WHERE v.prioritization_id = $1
+		ORDER BY v.version ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, prioritizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []*entities.PrioritizationVersion
+	for rows.Next() {
+		version, err := scanPrioritizationVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+// GetVersion busca uma versão específica da priorização
+func (r *PrioritizationRepositoryImpl) GetVersion(ctx context.Context, prioritizationID int64, version int) (*entities.PrioritizationVersion, error) {
+	query := `
+		SELECT v.id, v.prioritization_id, v.version, v.priority_order, v.unranked_ids, v.source, v.change_request_id,
+		       v.restored_from_version, v.reason, v.created_by_user_id, u.name, v.created_at
+		FROM prioritization_versions v
+		INNER JOIN users u ON u.id = v.created_by_user_id
+		WHERE v.prioritization_id = $1 AND v.version = $2
+	`
+
+	return scanPrioritizationVersion(r.db.QueryRowContext(ctx, query, prioritizationID, version))
+}
+
+func scanPrioritizationVersion(row rowScanner) (*entities.PrioritizationVersion, error) {
+	version := &entities.PrioritizationVersion{}
+	var priorityOrderJSON, unrankedJSON []byte
+	var changeRequestID, restoredFrom sql.NullInt64
+	var reason sql.NullString
+
+	err := row.Scan(
+		&version.ID,
+		&version.PrioritizationID,
+		&version.Version,
+		&priorityOrderJSON,
+		&unrankedJSON,
+		&version.Source,
+		&changeRequestID,
+		&restoredFrom,
+		&reason,
+		&version.CreatedByUserID,
+		&version.CreatedByName,
+		&version.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(priorityOrderJSON, &version.PriorityOrder); err != nil {
+		return nil, err
+	}
+	if unrankedJSON != nil {
+		if err := json.Unmarshal(unrankedJSON, &version.UnrankedIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if changeRequestID.Valid {
+		version.ChangeRequestID = &changeRequestID.Int64
+	}
+
+	if restoredFrom.Valid {
+		restored := int(restoredFrom.Int64)
+		version.RestoredFromVersion = &restored
+	}
+
+	version.Reason = reason.String
+
+	return version, nil
+}
+
 // HasPendingChangeRequest verifica se existe solicitação pendente
 func (r *PrioritizationRepositoryImpl) HasPendingChangeRequest(ctx context.Context, prioritizationID int64) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM prioritization_change_requests WHERE prioritization_id = $1 AND status = $2)`
@@ -301,3 +744,113 @@ func (r *PrioritizationRepositoryImpl) HasPendingChangeRequest(ctx context.Conte
 	err := r.db.QueryRowContext(ctx, query, prioritizationID, entities.PrioritizationChangeStatusPending).Scan(&exists)
 	return exists, err
 }
+
+// ExpireChangeRequest encerra uma solicitação pendente que ficou sem decisão (sem revisor)
+func (r *PrioritizationRepositoryImpl) ExpireChangeRequest(ctx context.Context, requestID int64, reason string) error {
+	query := `
+		UPDATE prioritization_change_requests
+		SET status = $1, review_reason = $2, reviewed_at = NOW()
+		WHERE id = $3 AND status = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, entities.PrioritizationChangeStatusExpired, reason, requestID, entities.PrioritizationChangeStatusPending)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// UpsertCapacity define (ou substitui) a capacidade do setor no ano
+func (r *PrioritizationRepositoryImpl) UpsertCapacity(ctx context.Context, capacity *entities.SectorCapacity) error {
+	query := `
+		INSERT INTO sector_capacity (sector_id, year, capacity_days, budget, updated_by_user_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		ON CONFLICT (sector_id, year)
+		DO UPDATE SET capacity_days = EXCLUDED.capacity_days, budget = EXCLUDED.budget,
+		              updated_by_user_id = EXCLUDED.updated_by_user_id, updated_at = NOW()
+		RETURNING updated_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		capacity.SectorID,
+		capacity.Year,
+		capacity.CapacityDays,
+		capacity.Budget,
+		capacity.UpdatedByUserID,
+	).Scan(&capacity.UpdatedAt)
+}
+
+// GetCapacity busca a capacidade do setor no ano; sql.ErrNoRows se não definida
+func (r *PrioritizationRepositoryImpl) GetCapacity(ctx context.Context, sectorID int64, year int) (*entities.SectorCapacity, error) {
+	query := `
+		SELECT c.sector_id, s.name, c.year, c.capacity_days, c.budget, c.updated_by_user_id, u.name, c.updated_at
+		FROM sector_capacity c
+		INNER JOIN sectors s ON s.id = c.sector_id
+		INNER JOIN users u ON u.id = c.updated_by_user_id
+		WHERE c.sector_id = $1 AND c.year = $2
+	`
+
+	return scanSectorCapacity(r.db.QueryRowContext(ctx, query, sectorID, year))
+}
+
+func (r *PrioritizationRepositoryImpl) ListCapacitiesByYear(ctx context.Context, year int) ([]*entities.SectorCapacity, error) {
+	query := `
+		SELECT c.sector_id, s.name, c.year, c.capacity_days, c.budget, c.updated_by_user_id, u.name, c.updated_at
+		FROM sector_capacity c
+		INNER JOIN sectors s ON s.id = c.sector_id
+		INNER JOIN users u ON u.id = c.updated_by_user_id
+		WHERE c.year = $1
+		ORDER BY s.name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, year)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var capacities []*entities.SectorCapacity
+	for rows.Next() {
+		capacity, err := scanSectorCapacity(rows)
+		if err != nil {
+			return nil, err
+		}
+		capacities = append(capacities, capacity)
+	}
+
+	return capacities, nil
+}
+
+func scanSectorCapacity(row rowScanner) (*entities.SectorCapacity, error) {
+	capacity := &entities.SectorCapacity{}
+	var budget sql.NullFloat64
+
+	err := row.Scan(
+		&capacity.SectorID,
+		&capacity.SectorName,
+		&capacity.Year,
+		&capacity.CapacityDays,
+		&budget,
+		&capacity.UpdatedByUserID,
+		&capacity.UpdatedByName,
+		&capacity.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if budget.Valid {
+		capacity.Budget = &budget.Float64
+	}
+
+	return capacity, nil
+}
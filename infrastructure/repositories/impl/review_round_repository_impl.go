@@ -0,0 +1,158 @@
+package repository_impl
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"hackathon-backend/domain/entities"
+)
+
+type ReviewRoundRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewReviewRoundRepositoryImpl(db *sql.DB) *ReviewRoundRepositoryImpl {
+	return &ReviewRoundRepositoryImpl{db: db}
+}
+
+// Create abre a próxima rodada de revisão da iniciativa (o número é calculado no banco)
+func (r *ReviewRoundRepositoryImpl) Create(ctx context.Context, round *entities.InitiativeReviewRound) error {
+	snapshotJSON, err := json.Marshal(round.Snapshot)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO initiative_review_rounds (initiative_id, round, snapshot, submitted_by_user_id, submit_comment, submitted_at)
+		VALUES ($1, (SELECT COALESCE(MAX(round), 0) + 1 FROM initiative_review_rounds WHERE initiative_id = $1),
+		        $2, $3, $4, NOW())
+		RETURNING id, round, submitted_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		round.InitiativeID,
+		snapshotJSON,
+		round.SubmittedByUserID,
+		round.SubmitComment,
+	).Scan(&round.ID, &round.Round, &round.SubmittedAt)
+}
+
+func (r *ReviewRoundRepositoryImpl) GetLatest(ctx context.Context, initiativeID int64) (*entities.InitiativeReviewRound, error) {
+	query := `
+		SELECT rr.id, rr.initiative_id, rr.round, rr.snapshot, rr.submitted_by_user_id, u1.name,
+		       rr.submit_comment, rr.submitted_at, rr.returned_by_user_id, u2.name,
+		       rr.return_reason, rr.change_requests, rr.returned_at
+		FROM initiative_review_rounds rr
+		INNER JOIN users u1 ON u1.id = rr.submitted_by_user_id
+		LEFT JOIN users u2 ON u2.id = rr.returned_by_user_id
+		WHERE rr.initiative_id = $1
+		ORDER BY rr.round DESC
+		LIMIT 1
+	`
+
+	return scanReviewRound(r.db.QueryRowContext(ctx, query, initiativeID))
+}
+
+func (r *ReviewRoundRepositoryImpl) ListByInitiative(ctx context.Context, initiativeID int64) ([]*entities.InitiativeReviewRound, error) {
+	query := `
+		SELECT rr.id, rr.initiative_id, rr.round, rr.snapshot, rr.submitted_by_user_id, u1.name,
+		       rr.submit_comment, rr.submitted_at, rr.returned_by_user_id, u2.name,
+		       rr.return_reason, rr.change_requests, rr.returned_at
+		FROM initiative_review_rounds rr
+		INNER JOIN users u1 ON u1.id = rr.submitted_by_user_id
+		LEFT JOIN users u2 ON u2.id = rr.returned_by_user_id
+		WHERE rr.initiative_id = $1
+		ORDER BY rr.round ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, initiativeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rounds []*entities.InitiativeReviewRound
+	for rows.Next() {
+		round, err := scanReviewRound(rows)
+		if err != nil {
+			return nil, err
+		}
+		rounds = append(rounds, round)
+	}
+
+	return rounds, nil
+}
+
+// MarkReturned encerra a rodada com a devolução; retorna sql.ErrNoRows se ela já foi encerrada
+func (r *ReviewRoundRepositoryImpl) MarkReturned(ctx context.Context, roundID, returnedBy int64, reason string, changeRequests []entities.ReviewChangeRequest) error {
+	changeRequestsJSON, err := json.Marshal(changeRequests)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE initiative_review_rounds
+		SET returned_by_user_id = $1, return_reason = $2, change_requests = $3, returned_at = NOW()
+		WHERE id = $4 AND returned_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, returnedBy, reason, changeRequestsJSON, roundID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanReviewRound(row rowScanner) (*entities.InitiativeReviewRound, error) {
+	round := &entities.InitiativeReviewRound{}
+	var snapshotJSON, changeRequestsJSON []byte
+	var returnedBy sql.NullInt64
+	var returnedByName, returnReason sql.NullString
+	var returnedAt sql.NullTime
+
+	err := row.Scan(
+		&round.ID,
+		&round.InitiativeID,
+		&round.Round,
+		&snapshotJSON,
+		&round.SubmittedByUserID,
+		&round.SubmittedByName,
+		&round.SubmitComment,
+		&round.SubmittedAt,
+		&returnedBy,
+		&returnedByName,
+		&returnReason,
+		&changeRequestsJSON,
+		&returnedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(snapshotJSON, &round.Snapshot); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(changeRequestsJSON, &round.ChangeRequests); err != nil {
+		return nil, err
+	}
+
+	if returnedBy.Valid {
+		round.ReturnedByUserID = &returnedBy.Int64
+	}
+	round.ReturnedByName = returnedByName.String
+	round.ReturnReason = returnReason.String
+	if returnedAt.Valid {
+		round.ReturnedAt = &returnedAt.Time
+	}
+
+	return round, nil
+}
@@ -0,0 +1,130 @@
+package repository_impl
+
+import (
+	"context"
+	"database/sql"
+	"hackathon-backend/domain/entities"
+)
+
+type ReviewSLARepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewReviewSLARepositoryImpl(db *sql.DB) *ReviewSLARepositoryImpl {
+	return &ReviewSLARepositoryImpl{db: db}
+}
+
+// ListPendingItems lista tudo que aguarda decisão: iniciativas submetidas, cancelamentos
+// e mudanças de priorização pendentes (mais antigos primeiro)
+func (r *ReviewSLARepositoryImpl) ListPendingItems(ctx context.Context) ([]*entities.PendingReviewItem, error) {
+	query := `
+		SELECT 'initiative' AS item_type, i.id, i.title, i.id AS initiative_id, i.type, u.sector_id,
+		       i.owner_id, u.name, '' AS reason, COALESCE(rr.submitted_at, i.created_at) AS pending_since,
+		       COALESCE(rr.round, 1), CASE WHEN e.round = COALESCE(rr.round, 1) THEN e.level ELSE 0 END
+		FROM initiatives i
+		INNER JOIN users u ON u.id = i.owner_id
+		LEFT JOIN LATERAL (
+			SELECT round, submitted_at
+			FROM initiative_review_rounds
+			WHERE initiative_id = i.id
+			ORDER BY round DESC
+			LIMIT 1
+		) rr ON true
+		LEFT JOIN review_escalations e ON e.item_type = 'initiative' AND e.item_id = i.id
+		WHERE i.status = $1
+
+		UNION ALL
+
+		SELECT 'cancellation', cr.id, i.title, cr.initiative_id, i.type, owner.sector_id,
+		       cr.requested_by_user_id, u.name, cr.reason, cr.created_at, 1, COALESCE(e.level, 0)
+		FROM initiative_cancellation_requests cr
+		INNER JOIN initiatives i ON i.id = cr.initiative_id
+		INNER JOIN users owner ON owner.id = i.owner_id
+		INNER JOIN users u ON u.id = cr.requested_by_user_id
+		LEFT JOIN review_escalations e ON e.item_type = 'cancellation' AND e.item_id = cr.id
+		WHERE cr.status = $2
+
+		UNION ALL
+
+		SELECT 'prioritization_change', pcr.id, 'Priorização ' || s.name || ' ' || p.year, NULL, '', p.sector_id,
+		       pcr.requested_by_user_id, u.name, pcr.reason, pcr.created_at, 1, COALESCE(e.level, 0)
+		FROM prioritization_change_requests pcr
+		INNER JOIN initiative_prioritization p ON p.id = pcr.prioritization_id
+		INNER JOIN sectors s ON s.id = p.sector_id
+		INNER JOIN users u ON u.id = pcr.requested_by_user_id
+		LEFT JOIN review_escalations e ON e.item_type = 'prioritization_change' AND e.item_id = pcr.id
+		WHERE pcr.status = $3
+
+		ORDER BY pending_since
+	`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		entities.StatusSubmitted,
+		entities.CancellationStatusPending,
+		entities.PrioritizationChangeStatusPending,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*entities.PendingReviewItem
+	for rows.Next() {
+		item := &entities.PendingReviewItem{}
+		var initiativeID, sectorID sql.NullInt64
+
+		err := rows.Scan(
+			&item.Type,
+			&item.ID,
+			&item.Subject,
+			&initiativeID,
+			&item.InitiativeType,
+			&sectorID,
+			&item.RequestedByUserID,
+			&item.RequestedByName,
+			&item.Reason,
+			&item.PendingSince,
+			&item.Round,
+			&item.EscalationLevel,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if initiativeID.Valid {
+			item.InitiativeID = &initiativeID.Int64
+		}
+		if sectorID.Valid {
+			item.SectorID = &sectorID.Int64
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// AdvanceEscalation só avança o nível (nunca volta), o que evita notificações duplicadas
+// quando mais de uma instância do servidor executa a verificação. Uma rodada nova recomeça do zero
+func (r *ReviewSLARepositoryImpl) AdvanceEscalation(ctx context.Context, itemType string, itemID int64, round int, level int) (bool, error) {
+	query := `
+		INSERT INTO review_escalations (item_type, item_id, round, level, escalated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (item_type, item_id) DO UPDATE
+		SET round = EXCLUDED.round, level = EXCLUDED.level, escalated_at = NOW()
+		WHERE review_escalations.round < EXCLUDED.round
+		   OR (review_escalations.round = EXCLUDED.round AND review_escalations.level < EXCLUDED.level)
+		RETURNING item_id
+	`
+
+	var id int64
+	err := r.db.QueryRowContext(ctx, query, itemType, itemID, round, level).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,196 @@
+package repository_impl
+
+import (
+	"context"
+	"database/sql"
+	"hackathon-backend/domain/entities"
+)
+
+type ScoringRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewScoringRepositoryImpl(db *sql.DB) *ScoringRepositoryImpl {
+	return &ScoringRepositoryImpl{db: db}
+}
+
+func (r *ScoringRepositoryImpl) CreateCriterion(ctx context.Context, criterion *entities.ScoringCriterion) error {
+	query := `
+		INSERT INTO scoring_criteria (name, description, weight, inverted, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		criterion.Name,
+		criterion.Description,
+		criterion.Weight,
+		criterion.Inverted,
+		criterion.Active,
+	).Scan(&criterion.ID, &criterion.CreatedAt, &criterion.UpdatedAt)
+}
+
+func (r *ScoringRepositoryImpl) UpdateCriterion(ctx context.Context, criterion *entities.ScoringCriterion) error {
+	query := `
+		UPDATE scoring_criteria
+		SET name = $1, description = $2, weight = $3, inverted = $4, active = $5, updated_at = NOW()
+		WHERE id = $6
+		RETURNING updated_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		criterion.Name,
+		criterion.Description,
+		criterion.Weight,
+		criterion.Inverted,
+		criterion.Active,
+		criterion.ID,
+	).Scan(&criterion.UpdatedAt)
+}
+
+func (r *ScoringRepositoryImpl) GetCriterionByID(ctx context.Context, criterionID int64) (*entities.ScoringCriterion, error) {
+	query := `
+		SELECT id, name, description, weight, inverted, active, created_at, updated_at
+		FROM scoring_criteria
+		WHERE id = $1
+	`
+
+	return scanScoringCriterion(r.db.QueryRowContext(ctx, query, criterionID))
+}
+
+func (r *ScoringRepositoryImpl) ListCriteria(ctx context.Context, activeOnly bool) ([]*entities.ScoringCriterion, error) {
+	query := `
+		SELECT id, name, description, weight, inverted, active, created_at, updated_at
+		FROM scoring_criteria
+	`
+	if activeOnly {
+		query += " WHERE active = true"
+	}
+	query += " ORDER BY name ASC"
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var criteria []*entities.ScoringCriterion
+	for rows.Next() {
+		criterion, err := scanScoringCriterion(rows)
+		if err != nil {
+			return nil, err
+		}
+		criteria = append(criteria, criterion)
+	}
+
+	return criteria, nil
+}
+
+// UpsertScore grava (ou substitui) a nota do revisor para o critério
+func (r *ScoringRepositoryImpl) UpsertScore(ctx context.Context, score *entities.InitiativeCriterionScore) error {
+	query := `
+		INSERT INTO initiative_scores (initiative_id, criterion_id, reviewer_id, score, comment, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		ON CONFLICT (initiative_id, criterion_id, reviewer_id)
+		DO UPDATE SET score = EXCLUDED.score, comment = EXCLUDED.comment, updated_at = NOW()
+		RETURNING updated_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		score.InitiativeID,
+		score.CriterionID,
+		score.ReviewerID,
+		score.Score,
+		score.Comment,
+	).Scan(&score.UpdatedAt)
+}
+
+func (r *ScoringRepositoryImpl) ListScoresByInitiative(ctx context.Context, initiativeID int64) ([]*entities.InitiativeCriterionScore, error) {
+	query := `
+		SELECT s.initiative_id, s.criterion_id, c.name, s.reviewer_id, u.name, s.score, s.comment, s.updated_at
+		FROM initiative_scores s
+		INNER JOIN scoring_criteria c ON c.id = s.criterion_id
+		INNER JOIN users u ON u.id = s.reviewer_id
+		WHERE s.initiative_id = $1
+		ORDER BY c.name ASC, u.name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, initiativeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []*entities.InitiativeCriterionScore
+	for rows.Next() {
+		score := &entities.InitiativeCriterionScore{}
+		var comment sql.NullString
+
+		err := rows.Scan(
+			&score.InitiativeID,
+			&score.CriterionID,
+			&score.CriterionName,
+			&score.ReviewerID,
+			&score.ReviewerName,
+			&score.Score,
+			&comment,
+			&score.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		score.Comment = comment.String
+		scores = append(scores, score)
+	}
+
+	return scores, nil
+}
+
+// ListScoredInitiativeIDs lista as iniciativas que já receberam alguma nota
+func (r *ScoringRepositoryImpl) ListScoredInitiativeIDs(ctx context.Context) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT initiative_id FROM initiative_scores`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// UpdateInitiativeScore grava a pontuação calculada na iniciativa (nil = sem notas)
+func (r *ScoringRepositoryImpl) UpdateInitiativeScore(ctx context.Context, initiativeID int64, score *float64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE initiatives SET score = $1 WHERE id = $2`, score, initiativeID)
+	return err
+}
+
+func scanScoringCriterion(row rowScanner) (*entities.ScoringCriterion, error) {
+	criterion := &entities.ScoringCriterion{}
+	var description sql.NullString
+
+	err := row.Scan(
+		&criterion.ID,
+		&criterion.Name,
+		&description,
+		&criterion.Weight,
+		&criterion.Inverted,
+		&criterion.Active,
+		&criterion.CreatedAt,
+		&criterion.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	criterion.Description = description.String
+	return criterion, nil
+}
@@ -0,0 +1,164 @@
+package repository_impl
+
+import (
+	"context"
+	"database/sql"
+	"hackathon-backend/domain/entities"
+)
+
+type TwoFactorRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewTwoFactorRepositoryImpl(db *sql.DB) *TwoFactorRepositoryImpl {
+	return &TwoFactorRepositoryImpl{db: db}
+}
+
+func (r *TwoFactorRepositoryImpl) Get(ctx context.Context, userID int64) (*entities.TwoFactor, error) {
+	query := `
+		SELECT user_id, secret, enabled, last_used_step, confirmed_at, created_at, updated_at
+		FROM user_two_factor
+		WHERE user_id = $1
+	`
+
+	tf := &entities.TwoFactor{}
+	var confirmedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&tf.UserID,
+		&tf.Secret,
+		&tf.Enabled,
+		&tf.LastUsedStep,
+		&confirmedAt,
+		&tf.CreatedAt,
+		&tf.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if confirmedAt.Valid {
+		tf.ConfirmedAt = &confirmedAt.Time
+	}
+
+	return tf, nil
+}
+
+// SaveSecret grava um novo segredo pendente de confirmação
+func (r *TwoFactorRepositoryImpl) SaveSecret(ctx context.Context, userID int64, secret string) error {
+	query := `
+		INSERT INTO user_two_factor (user_id, secret, enabled, last_used_step, created_at, updated_at)
+		VALUES ($1, $2, false, 0, NOW(), NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret,
+		    enabled = false,
+		    last_used_step = 0,
+		    confirmed_at = NULL,
+		    updated_at = NOW()
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, secret)
+	return err
+}
+
+func (r *TwoFactorRepositoryImpl) Enable(ctx context.Context, userID int64) error {
+	query := `
+		UPDATE user_two_factor
+		SET enabled = true, confirmed_at = NOW(), updated_at = NOW()
+		WHERE user_id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+// Disable remove o segredo e os códigos de recuperação do usuário
+func (r *TwoFactorRepositoryImpl) Disable(ctx context.Context, userID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_two_factor WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// MarkStepUsed avança atomicamente o último contador aceito
+func (r *TwoFactorRepositoryImpl) MarkStepUsed(ctx context.Context, userID int64, step int64) (bool, error) {
+	query := `
+		UPDATE user_two_factor
+		SET last_used_step = $1, updated_at = NOW()
+		WHERE user_id = $2 AND last_used_step < $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, step, userID)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// ReplaceRecoveryCodes invalida os códigos anteriores e grava os novos
+func (r *TwoFactorRepositoryImpl) ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	for _, hash := range codeHashes {
+		query := `INSERT INTO user_recovery_codes (user_id, code_hash, created_at) VALUES ($1, $2, NOW())`
+		if _, err := tx.ExecContext(ctx, query, userID, hash); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UseRecoveryCode marca o código como usado; retorna false se não existir ou já tiver sido usado
+func (r *TwoFactorRepositoryImpl) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	query := `
+		UPDATE user_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *TwoFactorRepositoryImpl) CountRecoveryCodes(ctx context.Context, userID int64) (int, error) {
+	query := `SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	return count, err
+}
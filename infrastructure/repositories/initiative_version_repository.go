@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type InitiativeVersionRepository interface {
+	Create(ctx context.Context, version *entities.InitiativeVersion) error
+	ListByInitiative(ctx context.Context, initiativeID int64) ([]*entities.InitiativeVersion, error)
+	GetByVersion(ctx context.Context, initiativeID int64, version int) (*entities.InitiativeVersion, error)
+}
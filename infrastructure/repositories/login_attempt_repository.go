@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+	"time"
+)
+
+type LoginAttemptRepository interface {
+	RecordAttempt(ctx context.Context, attempt *entities.LoginAttempt) error
+	ListAttempts(ctx context.Context, filter *entities.LoginAttemptFilter) ([]*entities.LoginAttempt, error)
+	GetLockout(ctx context.Context, scope, identifier string) (*entities.LoginLockout, error)
+	IncrementFailures(ctx context.Context, scope, identifier string, window time.Duration) (int, error)
+	SetLockedUntil(ctx context.Context, scope, identifier string, lockedUntil time.Time) error
+	ClearLockout(ctx context.Context, scope, identifier string) error
+}
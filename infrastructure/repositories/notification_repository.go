@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *entities.Notification) error
+	ListByUser(ctx context.Context, userID int64, filter *entities.NotificationFilter) ([]*entities.Notification, error)
+	CountUnread(ctx context.Context, userID int64) (int, error)
+	MarkAsRead(ctx context.Context, notificationID, userID int64) error
+	MarkAllAsRead(ctx context.Context, userID int64) error
+}
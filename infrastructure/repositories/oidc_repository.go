@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+// OIDCRepository conversa com o provedor de identidade (discovery, token e JWKS)
+type OIDCRepository interface {
+	AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	ExchangeCode(ctx context.Context, code, codeVerifier string) (string, error) // Retorna o ID token
+	VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*entities.OIDCClaims, error)
+}
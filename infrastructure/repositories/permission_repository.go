@@ -11,4 +11,6 @@ type PermissionRepository interface {
 	AssignUserType(ctx context.Context, userID, userTypeID int64) error
 	RemoveUserType(ctx context.Context, userID, userTypeID int64) error
 	GetAllUserTypes(ctx context.Context) ([]*entities.UserType, error)
+	SetTwoFactorRequired(ctx context.Context, userTypeID int64, required bool) error
+	ListUserIDsByType(ctx context.Context, userTypeName string) ([]int64, error)
 }
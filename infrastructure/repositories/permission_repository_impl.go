@@ -18,7 +18,7 @@ func NewPermissionRepositoryImpl(db *sql.DB) *PermissionRepositoryImpl {
 
 func (r *PermissionRepositoryImpl) GetUserTypes(ctx context.Context, userID int64) ([]*entities.UserType, error) {
 	query := `
-		SELECT ut.id, ut.name, ut.description, ut.created_at, ut.updated_at
+		SELECT ut.id, ut.name, ut.description, ut.require_two_factor, ut.created_at, ut.updated_at
 		FROM user_type ut
 		INNER JOIN type_user tu ON tu.user_type_id = ut.id
 		WHERE tu.user_id = $1
@@ -34,7 +34,7 @@ func (r *PermissionRepositoryImpl) GetUserTypes(ctx context.Context, userID int6
 	var userTypes []*entities.UserType
 	for rows.Next() {
 		ut := &entities.UserType{}
-		err := rows.Scan(&ut.ID, &ut.Name, &ut.Description, &ut.CreatedAt, &ut.UpdatedAt)
+		err := rows.Scan(&ut.ID, &ut.Name, &ut.Description, &ut.RequireTwoFactor, &ut.CreatedAt, &ut.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -98,7 +98,7 @@ func (r *PermissionRepositoryImpl) RemoveUserType(ctx context.Context, userID, u
 
 func (r *PermissionRepositoryImpl) GetAllUserTypes(ctx context.Context) ([]*entities.UserType, error) {
 	query := `
-		SELECT id, name, description, created_at, updated_at
+		SELECT id, name, description, require_two_factor, created_at, updated_at
 		FROM user_type
 		ORDER BY name
 	`
@@ -112,7 +112,7 @@ func (r *PermissionRepositoryImpl) GetAllUserTypes(ctx context.Context) ([]*enti
 	var userTypes []*entities.UserType
 	for rows.Next() {
 		ut := &entities.UserType{}
-		err := rows.Scan(&ut.ID, &ut.Name, &ut.Description, &ut.CreatedAt, &ut.UpdatedAt)
+		err := rows.Scan(&ut.ID, &ut.Name, &ut.Description, &ut.RequireTwoFactor, &ut.CreatedAt, &ut.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -122,6 +122,26 @@ func (r *PermissionRepositoryImpl) GetAllUserTypes(ctx context.Context) ([]*enti
 	return userTypes, nil
 }
 
+// SetTwoFactorRequired define se o tipo de usuário exige autenticação em dois fatores
+func (r *PermissionRepositoryImpl) SetTwoFactorRequired(ctx context.Context, userTypeID int64, required bool) error {
+	query := `UPDATE user_type SET require_two_factor = $1, updated_at = NOW() WHERE id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, required, userTypeID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
 // Helper: Verificar se uma rota real bate com um padrão
 func matchesPattern(actualPath, pattern string) bool {
 	// Converter o padrão para regex
@@ -135,3 +155,31 @@ func matchesPattern(actualPath, pattern string) bool {
 	regex := regexp.MustCompile(regexPattern)
 	return regex.MatchString(actualPath)
 }
+
+// ListUserIDsByType lista os usuários que possuem o tipo informado
+func (r *PermissionRepositoryImpl) ListUserIDsByType(ctx context.Context, userTypeName string) ([]int64, error) {
+	query := `
+		SELECT tu.user_id
+		FROM type_user tu
+		INNER JOIN user_type ut ON ut.id = tu.user_type_id
+		WHERE ut.name = $1
+		ORDER BY tu.user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userTypeName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+	"time"
+)
+
+type PlanningCycleRepository interface {
+	Create(ctx context.Context, cycle *entities.PlanningCycle) error
+	Update(ctx context.Context, cycle *entities.PlanningCycle) error
+	GetByID(ctx context.Context, cycleID int64) (*entities.PlanningCycle, error)
+	List(ctx context.Context, year int) ([]*entities.PlanningCycle, error)
+	HasOverlap(ctx context.Context, year int, opensAt, closesAt time.Time, excludeID int64) (bool, error)
+
+	// Transições processadas pela tarefa periódica
+	ListDueToOpen(ctx context.Context) ([]*entities.PlanningCycle, error)
+	ListDueToClose(ctx context.Context) ([]*entities.PlanningCycle, error)
+	MarkOpened(ctx context.Context, cycleID int64) error
+	MarkClosed(ctx context.Context, cycleID int64) error
+}
@@ -9,15 +9,31 @@ type PrioritizationRepository interface {
 	// Priorização
 	Create(ctx context.Context, prioritization *entities.InitiativePrioritization) error
 	Update(ctx context.Context, prioritization *entities.InitiativePrioritization) error
+	GetByID(ctx context.Context, prioritizationID int64) (*entities.InitiativePrioritization, error)
 	GetBySectorAndYear(ctx context.Context, sectorID int64, year int) (*entities.InitiativePrioritization, error)
 	GetAllByYear(ctx context.Context, year int) ([]*entities.InitiativePrioritization, error)
+	GetLatestBySectorBefore(ctx context.Context, sectorID int64, year int) (*entities.InitiativePrioritization, error)
 	LockPrioritization(ctx context.Context, prioritizationID int64) error
 	UnlockPrioritization(ctx context.Context, prioritizationID int64) error
+	Submit(ctx context.Context, prioritizationID int64, userID *int64) error
+	Reopen(ctx context.Context, prioritizationID int64, userID *int64, reason string) error
 
 	// Solicitações de mudança
 	CreateChangeRequest(ctx context.Context, request *entities.PrioritizationChangeRequest) error
 	GetChangeRequestByID(ctx context.Context, requestID int64) (*entities.PrioritizationChangeRequest, error)
 	ListPendingChangeRequests(ctx context.Context) ([]*entities.PrioritizationChangeRequest, error)
 	UpdateChangeRequestStatus(ctx context.Context, requestID int64, status string, reviewedByUserID int64, reviewReason string) error
+	ApplyChangeRequest(ctx context.Context, requestID int64, reviewedByUserID int64, reviewReason string, force bool) (*entities.PrioritizationConflict, error)
 	HasPendingChangeRequest(ctx context.Context, prioritizationID int64) (bool, error)
+	ExpireChangeRequest(ctx context.Context, requestID int64, reason string) error
+
+	// Versões
+	CreateVersion(ctx context.Context, version *entities.PrioritizationVersion) error
+	ListVersions(ctx context.Context, prioritizationID int64) ([]*entities.PrioritizationVersion, error)
+	GetVersion(ctx context.Context, prioritizationID int64, version int) (*entities.PrioritizationVersion, error)
+
+	// Capacidade dos setores
+	UpsertCapacity(ctx context.Context, capacity *entities.SectorCapacity) error
+	GetCapacity(ctx context.Context, sectorID int64, year int) (*entities.SectorCapacity, error)
+	ListCapacitiesByYear(ctx context.Context, year int) ([]*entities.SectorCapacity, error)
 }
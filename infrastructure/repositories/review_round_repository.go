@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type ReviewRoundRepository interface {
+	Create(ctx context.Context, round *entities.InitiativeReviewRound) error
+	GetLatest(ctx context.Context, initiativeID int64) (*entities.InitiativeReviewRound, error)
+	ListByInitiative(ctx context.Context, initiativeID int64) ([]*entities.InitiativeReviewRound, error)
+	MarkReturned(ctx context.Context, roundID, returnedBy int64, reason string, changeRequests []entities.ReviewChangeRequest) error
+}
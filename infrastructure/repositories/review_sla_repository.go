@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type ReviewSLARepository interface {
+	ListPendingItems(ctx context.Context) ([]*entities.PendingReviewItem, error)
+	// AdvanceEscalation grava o novo nível da rodada; retorna false se o item já estava nesse nível ou acima
+	AdvanceEscalation(ctx context.Context, itemType string, itemID int64, round int, level int) (bool, error)
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type ScoringRepository interface {
+	// Critérios
+	CreateCriterion(ctx context.Context, criterion *entities.ScoringCriterion) error
+	UpdateCriterion(ctx context.Context, criterion *entities.ScoringCriterion) error
+	GetCriterionByID(ctx context.Context, criterionID int64) (*entities.ScoringCriterion, error)
+	ListCriteria(ctx context.Context, activeOnly bool) ([]*entities.ScoringCriterion, error)
+
+	// Notas
+	UpsertScore(ctx context.Context, score *entities.InitiativeCriterionScore) error
+	ListScoresByInitiative(ctx context.Context, initiativeID int64) ([]*entities.InitiativeCriterionScore, error)
+	ListScoredInitiativeIDs(ctx context.Context) ([]int64, error)
+	UpdateInitiativeScore(ctx context.Context, initiativeID int64, score *float64) error
+}
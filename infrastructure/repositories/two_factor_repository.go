@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"hackathon-backend/domain/entities"
+)
+
+type TwoFactorRepository interface {
+	Get(ctx context.Context, userID int64) (*entities.TwoFactor, error)
+	SaveSecret(ctx context.Context, userID int64, secret string) error
+	Enable(ctx context.Context, userID int64) error
+	Disable(ctx context.Context, userID int64) error
+	// MarkStepUsed retorna false se o contador já foi usado (código reaproveitado)
+	MarkStepUsed(ctx context.Context, userID int64, step int64) (bool, error)
+
+	// Códigos de recuperação (armazenados como hash)
+	ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+	CountRecoveryCodes(ctx context.Context, userID int64) (int, error)
+}
@@ -0,0 +1,59 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// StartBackgroundJobs inicia as tarefas periódicas habilitadas nas configurações
+func (s *SetupConfig) StartBackgroundJobs(ctx context.Context) {
+	if s.Settings.SLA.SchedulerEnabled {
+		interval := time.Duration(s.Settings.SLA.IntervalMinutes) * time.Minute
+		go runPeriodically(ctx, "SLA de revisões", interval, func(ctx context.Context) error {
+			result, err := s.ReviewSLAUseCase.RunOnce(ctx)
+			if err != nil {
+				return err
+			}
+			if result.Escalated > 0 || result.Expired > 0 {
+				log.Printf("⏱️  SLA: %d pendentes, %d escalonados, %d expirados", result.Checked, result.Escalated, result.Expired)
+			}
+			return nil
+		})
+	}
+
+	if s.Settings.Prioritization.CycleSchedulerEnabled {
+		interval := time.Duration(s.Settings.Prioritization.CycleIntervalMinutes) * time.Minute
+		go runPeriodically(ctx, "Ciclos de planejamento", interval, func(ctx context.Context) error {
+			result, err := s.PlanningCycleUseCase.RunOnce(ctx)
+			if err != nil {
+				return err
+			}
+			if result.Opened > 0 || result.Closed > 0 {
+				log.Printf("🗓️  Ciclos: %d abertos (%d rascunhos com carry-forward), %d encerrados (%d priorizações bloqueadas)", result.Opened, result.CarriedOver, result.Closed, result.Locked)
+			}
+			return nil
+		})
+	}
+}
+
+// runPeriodically executa a tarefa imediatamente e depois a cada intervalo, até o contexto ser cancelado
+func runPeriodically(ctx context.Context, name string, interval time.Duration, job func(ctx context.Context) error) {
+	log.Printf("🕒 Tarefa \"%s\" agendada a cada %s", name, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil {
+			log.Printf("Erro na tarefa \"%s\": %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Printf("Tarefa \"%s\" encerrada", name)
+			return
+		case <-ticker.C:
+		}
+	}
+}
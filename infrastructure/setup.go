@@ -15,27 +15,47 @@ import (
 )
 
 type SetupConfig struct {
-	DB                          *sql.DB
-	Settings                    *settings_loader.SettingsLoader
-	AuthRepository              *repository_impl.AuthRepositoryImpl
-	PermRepository              *repositories.PermissionRepositoryImpl
-	InitiativeRepository        *repository_impl.InitiativeRepositoryImpl
-	CommentRepository           *repository_impl.CommentRepositoryImpl
-	InitiativeHistoryRepository *repository_impl.InitiativeHistoryRepositoryImpl
-	CancellationRepository      *repository_impl.CancellationRepositoryImpl
-	AIRepository                *repository_impl.AIRepositoryImpl
-	SectorRepository            *repository_impl.SectorRepositoryImpl
-	PrioritizationRepository    *repository_impl.PrioritizationRepositoryImpl // NOVO
-	AuthUseCase                 *usecase_impl.AuthUseCaseImpl
-	PermissionUseCase           *usecase_impl.PermissionUseCaseImpl
-	UserCrudUseCase             *usecase_impl.UserCrudUseCaseImpl
-	InitiativeUseCase           *usecase_impl.InitiativeUseCaseImpl
-	CommentUseCase              *usecase_impl.CommentUseCaseImpl
-	InitiativeHistoryUseCase    *usecase_impl.InitiativeHistoryUseCaseImpl
-	CancellationUseCase         *usecase_impl.CancellationUseCaseImpl
-	AIUseCase                   *usecase_impl.AIUseCaseImpl
-	SectorUseCase               *usecase_impl.SectorUseCaseImpl
-	PrioritizationUseCase       *usecase_impl.PrioritizationUseCaseImpl // NOVO
+	DB                            *sql.DB
+	Settings                      *settings_loader.SettingsLoader
+	AuthRepository                *repository_impl.AuthRepositoryImpl
+	LoginAttemptRepository        *repository_impl.LoginAttemptRepositoryImpl
+	TwoFactorRepository           *repository_impl.TwoFactorRepositoryImpl
+	OIDCRepository                *repository_impl.OIDCRepositoryImpl
+	PermRepository                *repositories.PermissionRepositoryImpl
+	InitiativeRepository          *repository_impl.InitiativeRepositoryImpl
+	CommentRepository             *repository_impl.CommentRepositoryImpl
+	NotificationRepository        *repository_impl.NotificationRepositoryImpl
+	InitiativeHistoryRepository   *repository_impl.InitiativeHistoryRepositoryImpl
+	CancellationRepository        *repository_impl.CancellationRepositoryImpl
+	AIRepository                  *repository_impl.AIRepositoryImpl
+	SectorRepository              *repository_impl.SectorRepositoryImpl
+	PrioritizationRepository      *repository_impl.PrioritizationRepositoryImpl // NOVO
+	ReviewSLARepository           *repository_impl.ReviewSLARepositoryImpl
+	ApprovalDecisionRepository    *repository_impl.ApprovalDecisionRepositoryImpl
+	ReviewRoundRepository         *repository_impl.ReviewRoundRepositoryImpl
+	InitiativeVersionRepository   *repository_impl.InitiativeVersionRepositoryImpl
+	ConsolidatedRankingRepository *repository_impl.ConsolidatedRankingRepositoryImpl
+	ScoringRepository             *repository_impl.ScoringRepositoryImpl
+	PlanningCycleRepository       *repository_impl.PlanningCycleRepositoryImpl
+	AIPromptTemplateRepository    *repository_impl.AIPromptTemplateRepositoryImpl
+	AuthUseCase                   *usecase_impl.AuthUseCaseImpl
+	TwoFactorUseCase              *usecase_impl.TwoFactorUseCaseImpl
+	SSOUseCase                    *usecase_impl.SSOUseCaseImpl
+	PermissionUseCase             *usecase_impl.PermissionUseCaseImpl
+	UserCrudUseCase               *usecase_impl.UserCrudUseCaseImpl
+	InitiativeUseCase             *usecase_impl.InitiativeUseCaseImpl
+	CommentUseCase                *usecase_impl.CommentUseCaseImpl
+	NotificationUseCase           *usecase_impl.NotificationUseCaseImpl
+	InitiativeHistoryUseCase      *usecase_impl.InitiativeHistoryUseCaseImpl
+	CancellationUseCase           *usecase_impl.CancellationUseCaseImpl
+	AIUseCase                     *usecase_impl.AIUseCaseImpl
+	SectorUseCase                 *usecase_impl.SectorUseCaseImpl
+	PrioritizationUseCase         *usecase_impl.PrioritizationUseCaseImpl // NOVO
+	ReviewSLAUseCase              *usecase_impl.ReviewSLAUseCaseImpl
+	ApprovalUseCase               *usecase_impl.ApprovalUseCaseImpl
+	ConsolidationUseCase          *usecase_impl.ConsolidationUseCaseImpl
+	ScoringUseCase                *usecase_impl.ScoringUseCaseImpl
+	PlanningCycleUseCase          *usecase_impl.PlanningCycleUseCaseImpl
 }
 
 func Setup(router *mux.Router, settings *settings_loader.SettingsLoader) (*SetupConfig, error) {
@@ -50,37 +70,72 @@ func Setup(router *mux.Router, settings *settings_loader.SettingsLoader) (*Setup
 	// 2. Inicializar Repositories
 	log.Println("📦 Inicializando repositories...")
 	authRepository := repository_impl.NewAuthRepositoryImpl(db)
+	loginAttemptRepository := repository_impl.NewLoginAttemptRepositoryImpl(db)
+	twoFactorRepository := repository_impl.NewTwoFactorRepositoryImpl(db)
+	oidcRepository := repository_impl.NewOIDCRepositoryImpl(settings)
 	permRepository := repositories.NewPermissionRepositoryImpl(db)
 	initiativeRepository := repository_impl.NewInitiativeRepositoryImpl(db)
 	commentRepository := repository_impl.NewCommentRepositoryImpl(db)
+	notificationRepository := repository_impl.NewNotificationRepositoryImpl(db)
 	initiativeHistoryRepository := repository_impl.NewInitiativeHistoryRepositoryImpl(db)
 	cancellationRepository := repository_impl.NewCancellationRepositoryImpl(db)
 	aiRepository := repository_impl.NewAIRepositoryImpl(settings)
 	sectorRepository := repository_impl.NewSectorRepositoryImpl(db)
 	prioritizationRepository := repository_impl.NewPrioritizationRepositoryImpl(db) // NOVO
+	reviewSLARepository := repository_impl.NewReviewSLARepositoryImpl(db)
+	approvalDecisionRepository := repository_impl.NewApprovalDecisionRepositoryImpl(db)
+	reviewRoundRepository := repository_impl.NewReviewRoundRepositoryImpl(db)
+	initiativeVersionRepository := repository_impl.NewInitiativeVersionRepositoryImpl(db)
+	consolidatedRankingRepository := repository_impl.NewConsolidatedRankingRepositoryImpl(db)
+	scoringRepository := repository_impl.NewScoringRepositoryImpl(db)
+	planningCycleRepository := repository_impl.NewPlanningCycleRepositoryImpl(db)
+	aiPromptTemplateRepository := repository_impl.NewAIPromptTemplateRepositoryImpl(db)
 
 	// 3. Inicializar UseCases
 	log.Println("⚙️  Inicializando use cases...")
-	authUseCase := usecase_impl.NewAuthUseCaseImpl(authRepository, settings)
+	authUseCase := usecase_impl.NewAuthUseCaseImpl(authRepository, loginAttemptRepository, twoFactorRepository, settings)
+	twoFactorUseCase := usecase_impl.NewTwoFactorUseCaseImpl(twoFactorRepository, authRepository, settings)
 	permUseCase := usecase_impl.NewPermissionUseCaseImpl(permRepository, authRepository)
-	userCrudUseCase := usecase_impl.NewUserCrudUseCaseImpl(authRepository, permRepository)
+	ssoUseCase := usecase_impl.NewSSOUseCaseImpl(
+		oidcRepository,
+		authRepository,
+		permRepository,
+		sectorRepository,
+		loginAttemptRepository,
+		settings,
+	)
+	userCrudUseCase := usecase_impl.NewUserCrudUseCaseImpl(authRepository, permRepository, loginAttemptRepository, twoFactorRepository, settings)
 	initiativeUseCase := usecase_impl.NewInitiativeUseCaseImpl(
 		initiativeRepository,
 		initiativeHistoryRepository,
 		permRepository,
 		authRepository,
+		notificationRepository,
+		approvalDecisionRepository,
+		reviewRoundRepository,
+		initiativeVersionRepository,
+		settings,
 	)
-	commentUseCase := usecase_impl.NewCommentUseCaseImpl(commentRepository, initiativeRepository, permRepository)
-	initiativeHistoryUseCase := usecase_impl.NewInitiativeHistoryUseCaseImpl(initiativeHistoryRepository)
+	commentUseCase := usecase_impl.NewCommentUseCaseImpl(
+		commentRepository,
+		initiativeRepository,
+		permRepository,
+		authRepository,
+		notificationRepository,
+	)
+	notificationUseCase := usecase_impl.NewNotificationUseCaseImpl(notificationRepository)
+	initiativeHistoryUseCase := usecase_impl.NewInitiativeHistoryUseCaseImpl(initiativeHistoryRepository, initiativeVersionRepository)
 
 	cancellationUseCase := usecase_impl.NewCancellationUseCaseImpl(
 		cancellationRepository,
 		initiativeRepository,
 		initiativeHistoryRepository,
 		permRepository,
+		authRepository,
+		settings,
 	)
 
-	aiUseCase := usecase_impl.NewAIUseCaseImpl(aiRepository)
+	aiUseCase := usecase_impl.NewAIUseCaseImpl(aiRepository, aiPromptTemplateRepository, permRepository)
 	sectorUseCase := usecase_impl.NewSectorUseCaseImpl(sectorRepository)
 
 	// NOVO: PrioritizationUseCase
@@ -90,19 +145,75 @@ func Setup(router *mux.Router, settings *settings_loader.SettingsLoader) (*Setup
 		authRepository,
 		permRepository,
 		sectorRepository, // ADICIONAR
+		planningCycleRepository,
+		notificationRepository,
+		settings,
+	)
+
+	reviewSLAUseCase := usecase_impl.NewReviewSLAUseCaseImpl(
+		reviewSLARepository,
+		cancellationRepository,
+		initiativeRepository,
+		prioritizationRepository,
+		initiativeHistoryRepository,
+		permRepository,
+		notificationRepository,
+		settings,
+	)
+
+	approvalUseCase := usecase_impl.NewApprovalUseCaseImpl(
+		reviewSLARepository,
+		initiativeRepository,
+		approvalDecisionRepository,
+		permRepository,
+		authRepository,
+		initiativeUseCase,
+		cancellationUseCase,
+		prioritizationUseCase,
+		settings,
+	)
+
+	consolidationUseCase := usecase_impl.NewConsolidationUseCaseImpl(
+		consolidatedRankingRepository,
+		prioritizationRepository,
+		initiativeRepository,
+		permRepository,
+		notificationRepository,
+		settings,
+	)
+
+	scoringUseCase := usecase_impl.NewScoringUseCaseImpl(
+		scoringRepository,
+		initiativeRepository,
+		permRepository,
+		settings,
+	)
+
+	planningCycleUseCase := usecase_impl.NewPlanningCycleUseCaseImpl(
+		planningCycleRepository,
+		prioritizationRepository,
+		initiativeRepository,
+		sectorRepository,
+		permRepository,
 	)
 
 	// 4. Inicializar Módulos HTTP
 	log.Println("🌐 Inicializando módulos HTTP...")
-	authModule := module_impl.NewAuthModule(authUseCase, settings)
+	authModule := module_impl.NewAuthModule(authUseCase, twoFactorUseCase, ssoUseCase, settings)
 	permModule := module_impl.NewPermissionModule(permUseCase)
 	userCrudModule := module_impl.NewUserCrudModule(userCrudUseCase)
 	initiativeModule := module_impl.NewInitiativeModule(initiativeUseCase, initiativeHistoryUseCase, cancellationUseCase)
 	commentModule := module_impl.NewCommentModule(commentUseCase)
+	notificationModule := module_impl.NewNotificationModule(notificationUseCase)
 	aiModule := module_impl.NewAIModule(aiUseCase)
 	sectorModule := module_impl.NewSectorModule(sectorUseCase)
 	prioritizationModule := module_impl.NewPrioritizationModule(prioritizationUseCase) // NOVO
+	approvalModule := module_impl.NewApprovalModule(approvalUseCase)
+	consolidationModule := module_impl.NewConsolidationModule(consolidationUseCase)
+	scoringModule := module_impl.NewScoringModule(scoringUseCase)
+	planningCycleModule := module_impl.NewPlanningCycleModule(planningCycleUseCase)
 	healthModule := module_impl.NewHealthModule()
+	settingsModule := module_impl.NewSettingsModule(settings)
 
 	// 5. Registrar Rotas Públicas
 	log.Println("🔓 Registrando rotas públicas...")
@@ -121,34 +232,60 @@ func Setup(router *mux.Router, settings *settings_loader.SettingsLoader) (*Setup
 	userCrudModule.RegisterRoutes(privateRouter)
 	initiativeModule.RegisterRoutes(privateRouter)
 	commentModule.RegisterRoutes(privateRouter)
+	notificationModule.RegisterRoutes(privateRouter)
 	aiModule.RegisterRoutes(privateRouter)
 	sectorModule.RegisterRoutes(privateRouter)
 	prioritizationModule.RegisterRoutes(privateRouter) // NOVO
+	approvalModule.RegisterRoutes(privateRouter)
+	consolidationModule.RegisterRoutes(privateRouter)
+	scoringModule.RegisterRoutes(privateRouter)
+	planningCycleModule.RegisterRoutes(privateRouter)
+	settingsModule.RegisterRoutes(privateRouter)
 
 	log.Println("✅ Setup concluído com sucesso!")
 
 	return &SetupConfig{
-		DB:                          db,
-		Settings:                    settings,
-		AuthRepository:              authRepository,
-		PermRepository:              permRepository,
-		InitiativeRepository:        initiativeRepository,
-		CommentRepository:           commentRepository,
-		InitiativeHistoryRepository: initiativeHistoryRepository,
-		CancellationRepository:      cancellationRepository,
-		AIRepository:                aiRepository,
-		SectorRepository:            sectorRepository,
-		PrioritizationRepository:    prioritizationRepository,
-		AuthUseCase:                 authUseCase,
-		PermissionUseCase:           permUseCase,
-		UserCrudUseCase:             userCrudUseCase,
-		InitiativeUseCase:           initiativeUseCase,
-		CommentUseCase:              commentUseCase,
-		InitiativeHistoryUseCase:    initiativeHistoryUseCase,
-		CancellationUseCase:         cancellationUseCase,
-		AIUseCase:                   aiUseCase,
-		SectorUseCase:               sectorUseCase,
-		PrioritizationUseCase:       prioritizationUseCase,
+		DB:                            db,
+		Settings:                      settings,
+		AuthRepository:                authRepository,
+		LoginAttemptRepository:        loginAttemptRepository,
+		TwoFactorRepository:           twoFactorRepository,
+		OIDCRepository:                oidcRepository,
+		PermRepository:                permRepository,
+		InitiativeRepository:          initiativeRepository,
+		CommentRepository:             commentRepository,
+		NotificationRepository:        notificationRepository,
+		InitiativeHistoryRepository:   initiativeHistoryRepository,
+		CancellationRepository:        cancellationRepository,
+		AIRepository:                  aiRepository,
+		SectorRepository:              sectorRepository,
+		PrioritizationRepository:      prioritizationRepository,
+		ReviewSLARepository:           reviewSLARepository,
+		ApprovalDecisionRepository:    approvalDecisionRepository,
+		ReviewRoundRepository:         reviewRoundRepository,
+		InitiativeVersionRepository:   initiativeVersionRepository,
+		ConsolidatedRankingRepository: consolidatedRankingRepository,
+		ScoringRepository:             scoringRepository,
+		PlanningCycleRepository:       planningCycleRepository,
+		AIPromptTemplateRepository:    aiPromptTemplateRepository,
+		AuthUseCase:                   authUseCase,
+		TwoFactorUseCase:              twoFactorUseCase,
+		SSOUseCase:                    ssoUseCase,
+		PermissionUseCase:             permUseCase,
+		UserCrudUseCase:               userCrudUseCase,
+		InitiativeUseCase:             initiativeUseCase,
+		CommentUseCase:                commentUseCase,
+		NotificationUseCase:           notificationUseCase,
+		InitiativeHistoryUseCase:      initiativeHistoryUseCase,
+		CancellationUseCase:           cancellationUseCase,
+		AIUseCase:                     aiUseCase,
+		SectorUseCase:                 sectorUseCase,
+		PrioritizationUseCase:         prioritizationUseCase,
+		ReviewSLAUseCase:              reviewSLAUseCase,
+		ApprovalUseCase:               approvalUseCase,
+		ConsolidationUseCase:          consolidationUseCase,
+		ScoringUseCase:                scoringUseCase,
+		PlanningCycleUseCase:          planningCycleUseCase,
 	}, nil
 }
 
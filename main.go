@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	setup "hackathon-backend/infrastructure"
 	"hackathon-backend/settings_loader"
 	"log"
@@ -71,6 +72,11 @@ func main() {
 	}
 	defer setupConfig.CloseDB()
 
+	// Tarefas em segundo plano (SLA de revisões, ciclos de planejamento), encerradas junto com o servidor
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	setupConfig.StartBackgroundJobs(jobsCtx)
+
 	// Graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
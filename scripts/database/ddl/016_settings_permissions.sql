@@ -0,0 +1,6 @@
+-- Apenas admin pode visualizar a configuração efetiva (com segredos mascarados)
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, '/api/private/admin/settings', 'GET'
+FROM user_type ut
+WHERE ut.name = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,37 @@
+-- Auditoria de tentativas de login
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(64) NOT NULL,
+    success BOOLEAN NOT NULL,
+    failure_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_email ON login_attempts(email, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_ip ON login_attempts(ip_address, created_at DESC);
+
+-- Contadores de falhas e bloqueios por conta (email) e por IP
+CREATE TABLE IF NOT EXISTS login_lockouts (
+    scope VARCHAR(20) NOT NULL, -- account, ip
+    identifier VARCHAR(255) NOT NULL,
+    failed_count INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP,
+    last_failed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (scope, identifier)
+);
+
+COMMENT ON TABLE login_attempts IS 'Auditoria de tentativas de login (sucesso e falha)';
+COMMENT ON TABLE login_lockouts IS 'Contadores de falhas de login e bloqueios temporários';
+
+-- Apenas admin pode desbloquear usuários e consultar a auditoria
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/users/{id}/unlock', 'POST'),
+        ('/api/private/login-attempts', 'GET')
+) AS perms(endpoint, method)
+WHERE ut.name = 'admin'
+ON CONFLICT DO NOTHING;
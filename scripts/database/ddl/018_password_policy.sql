@@ -0,0 +1,26 @@
+-- Controle de expiração de senha
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE users ADD COLUMN IF NOT EXISTS must_change_password BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN users.password_changed_at IS 'Data da última troca de senha (usada para expiração)';
+COMMENT ON COLUMN users.must_change_password IS 'Se o usuário precisa trocar a senha antes de usar o sistema';
+
+-- Histórico de senhas (impede reutilização das últimas N senhas)
+CREATE TABLE IF NOT EXISTS password_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_history_user ON password_history(user_id, created_at DESC);
+
+-- Registrar a senha atual dos usuários existentes no histórico
+INSERT INTO password_history (user_id, password_hash, created_at)
+SELECT u.id, u.password, u.updated_at
+FROM users u
+WHERE NOT EXISTS (
+    SELECT 1 FROM password_history ph WHERE ph.user_id = u.id
+);
+
+COMMENT ON TABLE password_history IS 'Hashes das senhas anteriores de cada usuário';
@@ -0,0 +1,56 @@
+-- Autenticação em dois fatores (TOTP - RFC 6238)
+CREATE TABLE IF NOT EXISTS user_two_factor (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_step BIGINT NOT NULL DEFAULT 0, -- impede reutilizar o mesmo código
+    confirmed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Códigos de recuperação (SHA-256), cada um pode ser usado uma única vez
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_recovery_codes_user ON user_recovery_codes(user_id);
+
+COMMENT ON TABLE user_two_factor IS 'Segredo TOTP e estado do 2FA por usuário';
+COMMENT ON TABLE user_recovery_codes IS 'Códigos de recuperação do 2FA (hash)';
+
+-- Política: tipos de usuário que exigem 2FA
+ALTER TABLE user_type ADD COLUMN IF NOT EXISTS require_two_factor BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE user_type SET require_two_factor = TRUE WHERE name IN ('admin', 'manager');
+
+-- Cadastro do 2FA: todos os usuários
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/two-factor', 'GET'),
+        ('/api/private/two-factor', 'DELETE'),
+        ('/api/private/two-factor/setup', 'POST'),
+        ('/api/private/two-factor/confirm', 'POST'),
+        ('/api/private/two-factor/recovery-codes', 'POST')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('admin', 'manager', 'user')
+ON CONFLICT DO NOTHING;
+
+-- Apenas admin define a política e redefine o 2FA de outros usuários
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/admin/user-types/{id}/two-factor', 'PUT'),
+        ('/api/private/users/{id}/two-factor', 'DELETE')
+) AS perms(endpoint, method)
+WHERE ut.name = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,11 @@
+-- Vínculo dos usuários com a identidade do provedor OpenID Connect (SSO)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS oidc_issuer VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS oidc_subject VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_oidc_identity
+    ON users(oidc_issuer, oidc_subject)
+    WHERE oidc_subject IS NOT NULL;
+
+COMMENT ON COLUMN users.oidc_subject IS 'Claim sub do provedor OIDC; preenchido no primeiro login corporativo';
+
+-- As rotas /api/auth/oidc/* são públicas e não precisam de permissões
@@ -0,0 +1,62 @@
+-- Respostas em comentários (árvore)
+ALTER TABLE initiative_comments
+    ADD COLUMN IF NOT EXISTS parent_id BIGINT REFERENCES initiative_comments (id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_comments_parent ON initiative_comments (parent_id);
+
+-- Usuários mencionados com @ nos comentários
+CREATE TABLE IF NOT EXISTS comment_mentions
+(
+    comment_id BIGINT    NOT NULL REFERENCES initiative_comments (id) ON DELETE CASCADE,
+    user_id    BIGINT    NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (comment_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_mentions_user ON comment_mentions (user_id);
+
+-- Reações com emoji (uma de cada tipo por usuário)
+CREATE TABLE IF NOT EXISTS comment_reactions
+(
+    comment_id BIGINT      NOT NULL REFERENCES initiative_comments (id) ON DELETE CASCADE,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    emoji      VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP   NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (comment_id, user_id, emoji)
+);
+
+-- Notificações dos usuários (menções, respostas, ...)
+CREATE TABLE IF NOT EXISTS notifications
+(
+    id            BIGSERIAL PRIMARY KEY,
+    user_id       BIGINT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    type          VARCHAR(50)  NOT NULL,
+    title         VARCHAR(255) NOT NULL,
+    message       TEXT         NOT NULL,
+    initiative_id BIGINT REFERENCES initiatives (id) ON DELETE CASCADE,
+    comment_id    BIGINT REFERENCES initiative_comments (id) ON DELETE SET NULL,
+    read_at       TIMESTAMP,
+    created_at    TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications (user_id) WHERE read_at IS NULL;
+
+COMMENT ON TABLE comment_mentions IS 'Usuários mencionados (@) em comentários';
+COMMENT ON TABLE comment_reactions IS 'Reações com emoji em comentários';
+COMMENT ON TABLE notifications IS 'Notificações para os usuários';
+
+-- Reações e notificações: todos os usuários autenticados
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/comments/{id}/reactions', 'POST'),
+        ('/api/private/comments/{id}/reactions', 'DELETE'),
+        ('/api/private/notifications', 'GET'),
+        ('/api/private/notifications/read-all', 'POST'),
+        ('/api/private/notifications/{id}/read', 'POST')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('admin', 'manager', 'user')
+ON CONFLICT DO NOTHING;
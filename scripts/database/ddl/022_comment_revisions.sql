@@ -0,0 +1,41 @@
+-- Histórico de edições dos comentários (conteúdo anterior a cada edição)
+CREATE TABLE IF NOT EXISTS comment_revisions
+(
+    id         BIGSERIAL PRIMARY KEY,
+    comment_id BIGINT    NOT NULL REFERENCES initiative_comments (id) ON DELETE CASCADE,
+    content    TEXT      NOT NULL,
+    edited_by  BIGINT    NOT NULL REFERENCES users (id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_revisions_comment ON comment_revisions (comment_id, created_at DESC);
+
+-- Soft delete: o comentário removido continua na árvore como "comentário removido"
+ALTER TABLE initiative_comments
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_by BIGINT REFERENCES users (id);
+
+COMMENT ON TABLE comment_revisions IS 'Versões anteriores dos comentários editados';
+
+-- Revisões: todos os usuários autenticados
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/comments/{id}/revisions', 'GET')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('admin', 'manager', 'user')
+ON CONFLICT DO NOTHING;
+
+-- Restaurar e excluir definitivamente: apenas admin
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/comments/{id}/restore', 'POST'),
+        ('/api/private/comments/{id}/purge', 'DELETE')
+) AS perms(endpoint, method)
+WHERE ut.name = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,13 @@
+-- Texto puro (sem marcação Markdown) da descrição e dos benefícios, usado na busca
+ALTER TABLE initiatives
+    ADD COLUMN IF NOT EXISTS description_text TEXT,
+    ADD COLUMN IF NOT EXISTS benefits_text    TEXT;
+
+-- Iniciativas existentes foram cadastradas como texto simples
+UPDATE initiatives
+SET description_text = description,
+    benefits_text    = benefits
+WHERE description_text IS NULL;
+
+COMMENT ON COLUMN initiatives.description_text IS 'Descrição sem marcação Markdown (busca e listagens)';
+COMMENT ON COLUMN initiatives.benefits_text IS 'Benefícios sem marcação Markdown (busca)';
@@ -0,0 +1,18 @@
+-- Notas internas: comentários visíveis apenas para revisores (nunca para o dono da iniciativa)
+ALTER TABLE initiative_comments
+    ADD COLUMN IF NOT EXISTS visibility VARCHAR(20) NOT NULL DEFAULT 'public'
+        CHECK (visibility IN ('public', 'internal'));
+
+CREATE INDEX IF NOT EXISTS idx_comments_visibility ON initiative_comments (initiative_id, visibility);
+
+-- Permissão para publicar notas internas (não é uma rota HTTP; verificada no caso de uso).
+-- Para liberar a outros tipos de usuário, inserir a mesma linha para o tipo desejado.
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/comments/internal', 'POST')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('admin', 'manager')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,27 @@
+-- Retirada da solicitação pelo próprio solicitante
+ALTER TABLE initiative_cancellation_requests
+    ADD COLUMN IF NOT EXISTS withdrawn_at    TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS withdraw_reason TEXT;
+
+COMMENT ON COLUMN initiative_cancellation_requests.status IS 'Status da solicitação: Pendente, Aprovada, Reprovada, Retirada';
+
+-- A restrição (initiative_id, status) impedia uma segunda reprovação/retirada na mesma iniciativa.
+-- Apenas uma solicitação pendente por iniciativa continua sendo garantida.
+ALTER TABLE initiative_cancellation_requests
+    DROP CONSTRAINT IF EXISTS unique_pending_cancellation;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cancellation_one_pending
+    ON initiative_cancellation_requests (initiative_id)
+    WHERE status = 'Pendente';
+
+-- Retirar e listar as próprias solicitações: todos os usuários autenticados
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/cancellation-requests/{id}/withdraw', 'POST'),
+        ('/api/private/my-cancellation-requests', 'GET')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('admin', 'manager', 'user')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,14 @@
+-- Controle de escalonamento de itens pendentes de revisão (SLA)
+CREATE TABLE IF NOT EXISTS review_escalations (
+    item_type VARCHAR(30) NOT NULL, -- initiative, cancellation, prioritization_change
+    item_id BIGINT NOT NULL,
+    level INT NOT NULL DEFAULT 0, -- Último nível de revisores notificado (settings sla.escalation_tiers)
+    escalated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_type, item_id)
+    );
+
+COMMENT ON TABLE review_escalations IS 'Escalonamentos de decisões pendentes além do SLA';
+
+-- Solicitações podem expirar sem decisão (sla.auto_expire)
+COMMENT ON COLUMN initiative_cancellation_requests.status IS 'Status da solicitação: Pendente, Aprovada, Reprovada, Retirada, Expirada';
+COMMENT ON COLUMN prioritization_change_requests.status IS 'Status da solicitação: Pendente, Aprovada, Reprovada, Expirada';
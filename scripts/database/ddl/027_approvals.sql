@@ -0,0 +1,12 @@
+-- Caixa de aprovações unificada: admin e gerentes
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/approvals', 'GET'),
+        ('/api/private/approvals/counts', 'GET'),
+        ('/api/private/approvals/{type}/{id}/decision', 'POST')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('admin', 'manager')
+ON CONFLICT DO NOTHING;
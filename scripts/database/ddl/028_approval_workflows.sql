@@ -0,0 +1,78 @@
+-- Decisões por etapa dos fluxos de aprovação (settings approval.workflows)
+CREATE TABLE IF NOT EXISTS initiative_approval_decisions (
+    id BIGSERIAL PRIMARY KEY,
+    initiative_id BIGINT NOT NULL REFERENCES initiatives(id) ON DELETE CASCADE,
+    step_index INT NOT NULL, -- Posição da etapa no fluxo (0 = primeira)
+    step_name VARCHAR(100) NOT NULL,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    approved BOOLEAN NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT approval_decisions_step_user_key UNIQUE (initiative_id, step_index, user_id)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_approval_decisions_initiative ON initiative_approval_decisions(initiative_id);
+
+COMMENT ON TABLE initiative_approval_decisions IS 'Decisões de cada etapa do fluxo de aprovação de iniciativas';
+
+-- Tipos de usuário usados no fluxo padrão de "Novo Projeto"
+INSERT INTO user_type (name, description) VALUES
+    ('architect', 'Arquitetura de TI (aprovação de novos projetos)'),
+    ('director', 'Diretoria (aprovação final de novos projetos)')
+ON CONFLICT (name) DO NOTHING;
+
+-- Acesso básico dos novos tipos (mesmos endpoints concedidos ao usuário comum até aqui)
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/me', 'GET'),
+        ('/api/private/personal-information', 'GET'),
+        ('/api/private/change-password', 'POST'),
+        ('/api/private/initiatives', 'GET'),
+        ('/api/private/initiatives', 'POST'),
+        ('/api/private/initiatives/{id}', 'GET'),
+        ('/api/private/my-initiatives', 'GET'),
+        ('/api/private/initiatives/{id}/history', 'GET'),
+        ('/api/private/initiatives/{id}/request-cancellation', 'POST'),
+        ('/api/private/initiatives/{initiativeId}/comments', 'GET'),
+        ('/api/private/initiatives/{initiativeId}/comments', 'POST'),
+        ('/api/private/comments/{id}', 'PUT'),
+        ('/api/private/comments/{id}', 'DELETE'),
+        ('/api/private/comments/{id}/revisions', 'GET'),
+        ('/api/private/comments/{id}/reactions', 'POST'),
+        ('/api/private/comments/{id}/reactions', 'DELETE'),
+        ('/api/private/notifications', 'GET'),
+        ('/api/private/notifications/read-all', 'POST'),
+        ('/api/private/notifications/{id}/read', 'POST'),
+        ('/api/private/sectors', 'GET'),
+        ('/api/private/sectors/{id}', 'GET'),
+        ('/api/private/prioritization', 'GET'),
+        ('/api/private/prioritization', 'POST'),
+        ('/api/private/prioritization/request-change', 'POST'),
+        ('/api/private/two-factor', 'GET'),
+        ('/api/private/two-factor', 'DELETE'),
+        ('/api/private/two-factor/setup', 'POST'),
+        ('/api/private/two-factor/confirm', 'POST'),
+        ('/api/private/two-factor/recovery-codes', 'POST'),
+        ('/api/private/cancellation-requests/{id}/withdraw', 'POST'),
+        ('/api/private/my-cancellation-requests', 'GET')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('architect', 'director')
+ON CONFLICT DO NOTHING;
+
+-- Aprovadores de etapas podem não ser admin/gerente: a verificação fica no use case
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/initiatives/{id}/review', 'POST'),
+        ('/api/private/initiatives/{id}/approval-chain', 'GET'),
+        ('/api/private/approvals', 'GET'),
+        ('/api/private/approvals/counts', 'GET'),
+        ('/api/private/approvals/{type}/{id}/decision', 'POST')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('admin', 'manager', 'user', 'architect', 'director')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,54 @@
+-- Rodadas de revisão: cada submissão/reenvio abre uma rodada; a devolução ("Devolvida") a encerra
+CREATE TABLE IF NOT EXISTS initiative_review_rounds (
+    id BIGSERIAL PRIMARY KEY,
+    initiative_id BIGINT NOT NULL REFERENCES initiatives(id) ON DELETE CASCADE,
+    round INT NOT NULL,
+    snapshot JSONB NOT NULL, -- Conteúdo submetido (título, descrição, benefícios, tipo, prioridade, setor, prazo)
+    submitted_by_user_id BIGINT NOT NULL REFERENCES users(id),
+    submit_comment TEXT NOT NULL DEFAULT '',
+    submitted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    returned_by_user_id BIGINT REFERENCES users(id),
+    return_reason TEXT,
+    change_requests JSONB NOT NULL DEFAULT '[]', -- [{field, comment}]
+    returned_at TIMESTAMP,
+    UNIQUE (initiative_id, round)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_review_rounds_initiative ON initiative_review_rounds(initiative_id);
+
+COMMENT ON TABLE initiative_review_rounds IS 'Rodadas de revisão de iniciativas (submissão, devolução e reenvio)';
+
+-- Decisões do fluxo de aprovação valem apenas para a rodada em que foram registradas
+ALTER TABLE initiative_approval_decisions ADD COLUMN IF NOT EXISTS round INT NOT NULL DEFAULT 1;
+-- Remove a unicidade antiga (sem rodada), pelo nome explícito ou pelo nome gerado (truncado em 63 caracteres)
+DO $$
+DECLARE
+    constraint_name TEXT;
+BEGIN
+    FOR constraint_name IN
+        SELECT c.conname
+        FROM pg_constraint c
+        WHERE c.conrelid = 'initiative_approval_decisions'::regclass
+          AND c.contype = 'u'
+          AND (SELECT array_agg(a.attname::TEXT ORDER BY a.attname)
+               FROM unnest(c.conkey) AS k(attnum)
+               INNER JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum)
+              = ARRAY['initiative_id', 'step_index', 'user_id']
+    LOOP
+        EXECUTE format('ALTER TABLE initiative_approval_decisions DROP CONSTRAINT %I', constraint_name);
+    END LOOP;
+END $$;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_approval_decisions_round_step_user
+    ON initiative_approval_decisions (initiative_id, round, step_index, user_id);
+
+-- Reenvio e consulta das rodadas
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/initiatives/{id}/resubmit', 'POST'),
+        ('/api/private/initiatives/{id}/review-rounds', 'GET')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('admin', 'manager', 'user', 'architect', 'director')
+ON CONFLICT DO NOTHING;
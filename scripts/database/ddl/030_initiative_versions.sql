@@ -0,0 +1,49 @@
+-- Versões do conteúdo das iniciativas (criação, cada edição e restaurações)
+CREATE TABLE IF NOT EXISTS initiative_versions (
+    id BIGSERIAL PRIMARY KEY,
+    initiative_id BIGINT NOT NULL REFERENCES initiatives(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    snapshot JSONB NOT NULL, -- Título, descrição, benefícios, tipo, prioridade, setor e prazo
+    edited_by_user_id BIGINT NOT NULL REFERENCES users(id),
+    restored_from_version INT, -- Preenchido quando a versão é a restauração de uma anterior
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (initiative_id, version)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_initiative_versions_initiative ON initiative_versions(initiative_id);
+
+COMMENT ON TABLE initiative_versions IS 'Histórico de versões (conteúdo) das iniciativas';
+
+-- Versão 1 das iniciativas existentes, com o conteúdo atual
+INSERT INTO initiative_versions (initiative_id, version, snapshot, edited_by_user_id, created_at)
+SELECT i.id, 1,
+       jsonb_strip_nulls(jsonb_build_object(
+           'title', i.title,
+           'description', i.description,
+           'benefits', i.benefits,
+           'type', i.type,
+           'priority', i.priority,
+           'sector', i.sector,
+           'deadline', to_char(i.deadline, 'YYYY-MM-DD')
+       )),
+       i.owner_id, i.created_at
+FROM initiatives i
+ON CONFLICT (initiative_id, version) DO NOTHING;
+
+-- Consulta de versões: todos; restauração: apenas admin
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/initiatives/{id}/versions', 'GET'),
+        ('/api/private/initiatives/{id}/versions/diff', 'GET')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('admin', 'manager', 'user', 'architect', 'director')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, '/api/private/initiatives/{id}/versions/{version}/restore', 'POST'
+FROM user_type ut
+WHERE ut.name = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,13 @@
+-- Aplicação atômica das solicitações de mudança de priorização aprovadas
+ALTER TABLE prioritization_change_requests ADD COLUMN IF NOT EXISTS base_priority_order JSONB; -- Ordem vigente no momento da solicitação
+ALTER TABLE prioritization_change_requests ADD COLUMN IF NOT EXISTS replaced_priority_order JSONB; -- Ordem substituída na aplicação
+ALTER TABLE prioritization_change_requests ADD COLUMN IF NOT EXISTS applied_by_user_id BIGINT REFERENCES users(id);
+ALTER TABLE prioritization_change_requests ADD COLUMN IF NOT EXISTS applied_at TIMESTAMP;
+
+-- Solicitações pendentes anteriores usam a ordem atual como base
+UPDATE prioritization_change_requests cr
+SET base_priority_order = p.priority_order
+FROM initiative_prioritization p
+WHERE p.id = cr.prioritization_id
+  AND cr.status = 'Pendente'
+  AND cr.base_priority_order IS NULL;
@@ -0,0 +1,42 @@
+-- Versões da ordem de prioridade (salvamentos, mudanças aplicadas e rollbacks)
+CREATE TABLE IF NOT EXISTS prioritization_versions (
+    id BIGSERIAL PRIMARY KEY,
+    prioritization_id BIGINT NOT NULL REFERENCES initiative_prioritization(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    priority_order JSONB NOT NULL,
+    source VARCHAR(20) NOT NULL, -- save, change_request, rollback
+    change_request_id BIGINT REFERENCES prioritization_change_requests(id) ON DELETE SET NULL,
+    restored_from_version INT, -- Preenchido no rollback
+    reason TEXT,
+    created_by_user_id BIGINT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (prioritization_id, version)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_prioritization_versions_prioritization ON prioritization_versions(prioritization_id);
+
+COMMENT ON TABLE prioritization_versions IS 'Histórico de versões da ordem de prioridade por setor/ano';
+
+-- Versão 1 das priorizações existentes, com a ordem atual
+INSERT INTO prioritization_versions (prioritization_id, version, priority_order, source, created_by_user_id, created_at)
+SELECT p.id, 1, p.priority_order, 'save', p.created_by_user_id, p.updated_at
+FROM initiative_prioritization p
+ON CONFLICT (prioritization_id, version) DO NOTHING;
+
+-- Consulta de versões: todos (restrito ao próprio setor no caso de usuários); rollback: apenas admin
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/prioritization/{id}/versions', 'GET'),
+        ('/api/private/prioritization/{id}/versions/diff', 'GET')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('admin', 'manager', 'user', 'architect', 'director')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, '/api/private/prioritization/{id}/versions/{version}/rollback', 'POST'
+FROM user_type ut
+WHERE ut.name = 'admin'
+ON CONFLICT DO NOTHING;
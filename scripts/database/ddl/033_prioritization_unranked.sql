@@ -0,0 +1,3 @@
+-- Iniciativas elegíveis marcadas explicitamente como fora do ranking
+ALTER TABLE initiative_prioritization ADD COLUMN IF NOT EXISTS unranked_ids JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE prioritization_change_requests ADD COLUMN IF NOT EXISTS new_unranked_ids JSONB; -- NULL: mantém as atuais ao aplicar
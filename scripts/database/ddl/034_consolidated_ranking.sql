@@ -0,0 +1,46 @@
+-- Ranking consolidado entre setores (um por ano), com ciclo próprio de aprovação e bloqueio
+CREATE TABLE IF NOT EXISTS consolidated_rankings (
+    id BIGSERIAL PRIMARY KEY,
+    year INT NOT NULL UNIQUE,
+    strategy VARCHAR(30) NOT NULL, -- round_robin, borda, weighted_score
+    status VARCHAR(20) NOT NULL DEFAULT 'Rascunho', -- Rascunho, Pendente, Aprovada, Reprovada
+    is_locked BOOLEAN NOT NULL DEFAULT FALSE,
+    items JSONB NOT NULL, -- Posição, iniciativa, setor, pontuação e explicação
+    pins JSONB NOT NULL DEFAULT '[]', -- Posições fixadas por administradores
+    generated_by_user_id BIGINT NOT NULL REFERENCES users(id),
+    generated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    submitted_by_user_id BIGINT REFERENCES users(id),
+    submitted_at TIMESTAMP,
+    reviewed_by_user_id BIGINT REFERENCES users(id),
+    review_reason TEXT,
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+    );
+
+COMMENT ON TABLE consolidated_rankings IS 'Ranking único da empresa a partir das priorizações dos setores';
+
+-- Consulta: admin, gerentes e diretoria; geração, envio e reabertura: admin; revisão: diretoria e admin
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, '/api/private/prioritization/consolidated', 'GET'
+FROM user_type ut
+WHERE ut.name IN ('admin', 'manager', 'director')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/prioritization/consolidated/generate', 'POST'),
+        ('/api/private/prioritization/consolidated/{id}/submit', 'POST'),
+        ('/api/private/prioritization/consolidated/{id}/reopen', 'POST')
+) AS perms(endpoint, method)
+WHERE ut.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, '/api/private/prioritization/consolidated/{id}/review', 'POST'
+FROM user_type ut
+WHERE ut.name IN ('director', 'admin')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,68 @@
+-- Pontuação ponderada das iniciativas
+CREATE TABLE IF NOT EXISTS scoring_criteria (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    weight NUMERIC(6, 2) NOT NULL CHECK (weight > 0),
+    inverted BOOLEAN NOT NULL DEFAULT FALSE, -- Nota alta reduz a pontuação (risco, esforço)
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+    );
+
+CREATE TABLE IF NOT EXISTS initiative_scores (
+    id BIGSERIAL PRIMARY KEY,
+    initiative_id BIGINT NOT NULL REFERENCES initiatives(id) ON DELETE CASCADE,
+    criterion_id BIGINT NOT NULL REFERENCES scoring_criteria(id) ON DELETE CASCADE,
+    reviewer_id BIGINT NOT NULL REFERENCES users(id),
+    score INT NOT NULL CHECK (score BETWEEN 1 AND 5),
+    comment TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (initiative_id, criterion_id, reviewer_id)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_initiative_scores_initiative ON initiative_scores(initiative_id);
+
+-- Pontuação calculada (0 a 100); NULL enquanto não houver notas
+ALTER TABLE initiatives ADD COLUMN IF NOT EXISTS score NUMERIC(5, 1);
+
+COMMENT ON TABLE scoring_criteria IS 'Critérios ponderados para pontuação das iniciativas';
+COMMENT ON TABLE initiative_scores IS 'Notas dos revisores por critério';
+
+INSERT INTO scoring_criteria (name, description, weight, inverted)
+VALUES
+    ('Alinhamento estratégico', 'Aderência aos objetivos estratégicos da empresa', 3, FALSE),
+    ('Redução de custos', 'Economia esperada após a entrega', 2, FALSE),
+    ('Risco', 'Risco técnico e de negócio da execução', 1, TRUE),
+    ('Esforço', 'Esforço estimado para a entrega', 1, TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+-- Consulta: todos; critérios: admin; notas: revisores (scoring.reviewers)
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/scoring/criteria', 'GET'),
+        ('/api/private/initiatives/{id}/scores', 'GET')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('admin', 'manager', 'user', 'architect', 'director')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/scoring/criteria', 'POST'),
+        ('/api/private/scoring/criteria/{id}', 'PUT')
+) AS perms(endpoint, method)
+WHERE ut.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, '/api/private/initiatives/{id}/scores', 'PUT'
+FROM user_type ut
+WHERE ut.name IN ('admin', 'manager', 'architect', 'director')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,46 @@
+-- Ciclos de planejamento: janelas em que os setores podem salvar a priorização do ano
+CREATE TABLE IF NOT EXISTS planning_cycles (
+    id BIGSERIAL PRIMARY KEY,
+    year INT NOT NULL,
+    quarter INT CHECK (quarter BETWEEN 1 AND 4), -- NULL = ano todo
+    name VARCHAR(100) NOT NULL,
+    opens_at TIMESTAMP NOT NULL,
+    closes_at TIMESTAMP NOT NULL,
+    sector_ids JSONB NOT NULL DEFAULT '[]', -- Setores participantes; vazio = todos os ativos
+    opened_at TIMESTAMP, -- Abertura processada (desbloqueio e carry-forward)
+    closed_at TIMESTAMP, -- Encerramento processado (priorizações bloqueadas)
+    created_by_user_id BIGINT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (closes_at > opens_at)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_planning_cycles_year ON planning_cycles(year);
+CREATE INDEX IF NOT EXISTS idx_planning_cycles_pending ON planning_cycles(closes_at) WHERE closed_at IS NULL;
+
+COMMENT ON TABLE planning_cycles IS 'Janelas de priorização por ano/trimestre; fora delas a priorização não pode ser salva';
+
+-- Consulta: todos; cadastro, situação e encerramento: admin (situação também para manager)
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, '/api/private/prioritization/cycles', 'GET'
+FROM user_type ut
+WHERE ut.name IN ('admin', 'manager', 'user', 'architect', 'director')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, '/api/private/prioritization/cycles/{id}/status', 'GET'
+FROM user_type ut
+WHERE ut.name IN ('admin', 'manager')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/prioritization/cycles', 'POST'),
+        ('/api/private/prioritization/cycles/{id}', 'PUT'),
+        ('/api/private/prioritization/cycles/{id}/close', 'POST')
+) AS perms(endpoint, method)
+WHERE ut.name = 'admin'
+ON CONFLICT DO NOTHING;
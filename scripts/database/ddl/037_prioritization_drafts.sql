@@ -0,0 +1,29 @@
+-- Rascunho x envio da priorização: rascunhos ficam desbloqueados e visíveis só para o setor
+ALTER TABLE initiative_prioritization ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'Rascunho'; -- Rascunho, Enviada
+ALTER TABLE initiative_prioritization ADD COLUMN IF NOT EXISTS submitted_by_user_id BIGINT REFERENCES users(id); -- NULL no envio automático do ciclo
+ALTER TABLE initiative_prioritization ADD COLUMN IF NOT EXISTS submitted_at TIMESTAMP;
+ALTER TABLE initiative_prioritization ADD COLUMN IF NOT EXISTS reopened_by_user_id BIGINT REFERENCES users(id);
+ALTER TABLE initiative_prioritization ADD COLUMN IF NOT EXISTS reopen_reason TEXT;
+ALTER TABLE initiative_prioritization ADD COLUMN IF NOT EXISTS reopened_at TIMESTAMP;
+
+-- Priorizações bloqueadas já haviam sido enviadas no salvamento
+UPDATE initiative_prioritization
+SET status = 'Enviada', submitted_by_user_id = created_by_user_id, submitted_at = updated_at
+WHERE is_locked = true AND submitted_at IS NULL;
+
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/prioritization/draft', 'POST'),
+        ('/api/private/prioritization/submit', 'POST')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('admin', 'manager', 'user', 'architect', 'director')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, '/api/private/prioritization/{id}/reopen', 'POST'
+FROM user_type ut
+WHERE ut.name IN ('admin', 'manager')
+ON CONFLICT DO NOTHING;
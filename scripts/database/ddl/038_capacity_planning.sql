@@ -0,0 +1,31 @@
+-- Estimativas das iniciativas (opcionais)
+ALTER TABLE initiatives ADD COLUMN IF NOT EXISTS effort_days NUMERIC(10, 1) CHECK (effort_days > 0); -- Pessoas-dia
+ALTER TABLE initiatives ADD COLUMN IF NOT EXISTS estimated_cost NUMERIC(14, 2) CHECK (estimated_cost > 0);
+ALTER TABLE initiatives ADD COLUMN IF NOT EXISTS required_skill VARCHAR(100) NOT NULL DEFAULT '';
+
+-- Capacidade de entrega dos setores por ano de planejamento
+CREATE TABLE IF NOT EXISTS sector_capacity (
+    sector_id BIGINT NOT NULL REFERENCES sectors(id) ON DELETE CASCADE,
+    year INT NOT NULL,
+    capacity_days NUMERIC(10, 1) NOT NULL CHECK (capacity_days >= 0),
+    budget NUMERIC(14, 2) CHECK (budget >= 0), -- NULL = sem limite de custo
+    updated_by_user_id BIGINT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (sector_id, year)
+    );
+
+COMMENT ON TABLE sector_capacity IS 'Pessoas-dia e orçamento do setor no ano; define a linha de corte da priorização';
+
+-- Consulta: todos (usuários veem o próprio setor); definição: admin/manager
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, '/api/private/prioritization/capacity', 'GET'
+FROM user_type ut
+WHERE ut.name IN ('admin', 'manager', 'user', 'architect', 'director')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, '/api/private/prioritization/capacity', 'PUT'
+FROM user_type ut
+WHERE ut.name IN ('admin', 'manager')
+ON CONFLICT DO NOTHING;
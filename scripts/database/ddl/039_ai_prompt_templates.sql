@@ -0,0 +1,82 @@
+-- Templates de prompt gerenciados pelos administradores; variáveis no formato {{nome}}
+CREATE TABLE IF NOT EXISTS ai_prompt_templates (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    template TEXT NOT NULL,
+    variables JSONB NOT NULL DEFAULT '[]',
+    version INT NOT NULL DEFAULT 1,
+    temperature NUMERIC(3, 2) CHECK (temperature BETWEEN 0 AND 2), -- NULL = padrão da seção [ai]
+    max_tokens INT CHECK (max_tokens > 0),
+    model VARCHAR(100) NOT NULL DEFAULT '', -- Vazio = modelo configurado no provedor
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by_user_id BIGINT REFERENCES users(id), -- NULL para os templates iniciais
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ai_prompt_templates_name ON ai_prompt_templates(LOWER(name));
+
+CREATE TABLE IF NOT EXISTS ai_prompt_template_versions (
+    id BIGSERIAL PRIMARY KEY,
+    template_id BIGINT NOT NULL REFERENCES ai_prompt_templates(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    template TEXT NOT NULL,
+    variables JSONB NOT NULL DEFAULT '[]',
+    temperature NUMERIC(3, 2),
+    max_tokens INT,
+    model VARCHAR(100) NOT NULL DEFAULT '',
+    created_by_user_id BIGINT REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (template_id, version)
+    );
+
+COMMENT ON TABLE ai_prompt_templates IS 'Prompts nomeados usados pelas funcionalidades de IA; usuários só chamam templates ativos';
+
+-- Templates iniciais
+INSERT INTO ai_prompt_templates (name, description, template, variables, temperature, max_tokens)
+VALUES
+    ('melhorar descrição', 'Reescreve a descrição da iniciativa de forma clara e objetiva',
+     E'Reescreva a descrição da iniciativa abaixo de forma clara e objetiva, em português, mantendo todas as informações. Responda apenas com o novo texto.\n\nTítulo: {{title}}\n\nDescrição:\n{{description}}',
+     '["title", "description"]', NULL, NULL),
+    ('resumir benefícios', 'Resume os benefícios da iniciativa em tópicos curtos',
+     E'Resuma em até três tópicos curtos, em português, os benefícios da iniciativa abaixo. Responda apenas com os tópicos.\n\nTítulo: {{title}}\n\nBenefícios:\n{{benefits}}',
+     '["title", "benefits"]', NULL, NULL),
+    ('sugerir tipo', 'Sugere o tipo da iniciativa (Automação, Integração, Melhoria ou Novo Projeto)',
+     E'Classifique a iniciativa abaixo em exatamente um dos tipos: Automação, Integração, Melhoria ou Novo Projeto. Responda apenas com o nome do tipo.\n\nTítulo: {{title}}\n\nDescrição:\n{{description}}',
+     '["title", "description"]', 0, 20)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO ai_prompt_template_versions (template_id, version, template, variables, temperature, max_tokens, model)
+SELECT id, version, template, variables, temperature, max_tokens, model
+FROM ai_prompt_templates
+ON CONFLICT DO NOTHING;
+
+-- Prompt livre passa a ser exclusivo do admin
+DELETE FROM user_type_permissions
+WHERE endpoint = '/api/private/ai/process-text'
+  AND method = 'POST'
+  AND user_type_id IN (SELECT id FROM user_type WHERE name <> 'admin');
+
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/ai/templates', 'GET'),
+        ('/api/private/ai/templates/run', 'POST')
+) AS perms(endpoint, method)
+WHERE ut.name IN ('admin', 'manager', 'user', 'architect', 'director')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_type_permissions (user_type_id, endpoint, method)
+SELECT ut.id, endpoint, method
+FROM user_type ut
+         CROSS JOIN (
+    VALUES
+        ('/api/private/ai/templates', 'POST'),
+        ('/api/private/ai/templates/{id}', 'PUT'),
+        ('/api/private/ai/templates/{id}/versions', 'GET')
+) AS perms(endpoint, method)
+WHERE ut.name = 'admin'
+ON CONFLICT DO NOTHING;
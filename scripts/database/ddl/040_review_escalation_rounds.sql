@@ -0,0 +1,4 @@
+-- Escalonamentos passam a valer por rodada de revisão: o reenvio de uma iniciativa recomeça o SLA
+ALTER TABLE review_escalations ADD COLUMN IF NOT EXISTS round INT NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN review_escalations.round IS 'Rodada de revisão a que o nível se refere (sempre 1 para solicitações)';
@@ -0,0 +1,4 @@
+-- Versões passam a guardar também as iniciativas marcadas como fora do ranking (restauradas no rollback)
+ALTER TABLE prioritization_versions ADD COLUMN IF NOT EXISTS unranked_ids JSONB; -- NULL em versões anteriores
+
+COMMENT ON COLUMN prioritization_versions.unranked_ids IS 'Elegíveis fora do ranking na versão; NULL quando não registrado';
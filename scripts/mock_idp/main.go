@@ -0,0 +1,317 @@
+// Provedor OpenID Connect falso para testar o login corporativo localmente.
+//
+//	go run ./scripts/mock_idp -issuer http://localhost:9000 -client-id hackathon
+//
+// Configure em settings.toml:
+//
+//	[oidc]
+//	enabled = true
+//	issuer_url = "http://localhost:9000"
+//	client_id = "hackathon"
+//	redirect_url = "http://localhost:8080/api/auth/oidc/callback"
+//
+// Com -auto o login é aprovado sem formulário usando -email, -name, -groups e -department.
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"html/template"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const keyID = "mock-key-1"
+
+type authorization struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	claims        map[string]interface{}
+	expiresAt     time.Time
+}
+
+type mockProvider struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	auto         bool
+	defaults     map[string]string
+	key          *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]*authorization
+}
+
+var loginForm = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Mock IdP</title></head>
+<body>
+<h2>Mock IdP - login</h2>
+<form method="POST" action="/authorize">
+  {{range $name, $value := .Params}}<input type="hidden" name="{{$name}}" value="{{$value}}">{{end}}
+  <p><label>Email <input name="email" value="{{.Defaults.email}}"></label></p>
+  <p><label>Nome <input name="name" value="{{.Defaults.name}}"></label></p>
+  <p><label>Grupos (separados por vírgula) <input name="groups" value="{{.Defaults.groups}}"></label></p>
+  <p><label>Setor <input name="department" value="{{.Defaults.department}}"></label></p>
+  <p><label><input type="checkbox" name="email_verified" value="true" checked> Email verificado</label></p>
+  <button type="submit">Entrar</button>
+</form>
+</body></html>`))
+
+func main() {
+	addr := flag.String("addr", ":9000", "endereço de escuta")
+	issuer := flag.String("issuer", "http://localhost:9000", "issuer anunciado no discovery")
+	clientID := flag.String("client-id", "hackathon", "client_id aceito")
+	clientSecret := flag.String("client-secret", "", "client_secret exigido (vazio = cliente público)")
+	auto := flag.Bool("auto", false, "aprovar o login sem formulário")
+	email := flag.String("email", "sso.user@hackathon.com", "email padrão")
+	name := flag.String("name", "Usuário SSO", "nome padrão")
+	groups := flag.String("groups", "", "grupos padrão (separados por vírgula)")
+	department := flag.String("department", "", "setor padrão")
+	flag.Parse()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatalf("Erro ao gerar chave: %v", err)
+	}
+
+	provider := &mockProvider{
+		issuer:       strings.TrimSuffix(*issuer, "/"),
+		clientID:     *clientID,
+		clientSecret: *clientSecret,
+		auto:         *auto,
+		defaults: map[string]string{
+			"email":      *email,
+			"name":       *name,
+			"groups":     *groups,
+			"department": *department,
+		},
+		key:   key,
+		codes: make(map[string]*authorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", provider.discovery)
+	mux.HandleFunc("/jwks", provider.jwks)
+	mux.HandleFunc("/authorize", provider.authorize)
+	mux.HandleFunc("/token", provider.token)
+
+	log.Printf("Mock IdP em %s (issuer %s, client_id %s)", *addr, provider.issuer, provider.clientID)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+func (p *mockProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *mockProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	publicKey := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	})
+}
+
+// authorize exibe o formulário (GET) e emite o código de autorização (POST ou -auto)
+func (p *mockProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "requisição inválida", http.StatusBadRequest)
+		return
+	}
+
+	params := map[string]string{}
+	for _, name := range []string{"response_type", "client_id", "redirect_uri", "scope", "state", "nonce", "code_challenge", "code_challenge_method"} {
+		params[name] = r.Form.Get(name)
+	}
+
+	if params["response_type"] != "code" || params["client_id"] != p.clientID || params["redirect_uri"] == "" {
+		http.Error(w, "response_type, client_id ou redirect_uri inválidos", http.StatusBadRequest)
+		return
+	}
+	if params["code_challenge"] == "" || params["code_challenge_method"] != "S256" {
+		http.Error(w, "PKCE S256 é obrigatório", http.StatusBadRequest)
+		return
+	}
+
+	values := p.defaults
+	if r.Method == http.MethodGet && !p.auto {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		loginForm.Execute(w, map[string]interface{}{"Params": params, "Defaults": p.defaults})
+		return
+	}
+	if r.Method == http.MethodPost {
+		values = map[string]string{
+			"email":          r.Form.Get("email"),
+			"name":           r.Form.Get("name"),
+			"groups":         r.Form.Get("groups"),
+			"department":     r.Form.Get("department"),
+			"email_verified": r.Form.Get("email_verified"),
+		}
+	}
+
+	claims := map[string]interface{}{
+		"sub":            "mock|" + strings.ToLower(values["email"]),
+		"email":          values["email"],
+		"email_verified": values["email_verified"] == "true" || r.Method == http.MethodGet,
+		"name":           values["name"],
+	}
+	if groups := splitList(values["groups"]); len(groups) > 0 {
+		claims["groups"] = groups
+	}
+	if values["department"] != "" {
+		claims["department"] = values["department"]
+	}
+
+	code := randomToken()
+	p.mu.Lock()
+	p.codes[code] = &authorization{
+		clientID:      params["client_id"],
+		redirectURI:   params["redirect_uri"],
+		nonce:         params["nonce"],
+		codeChallenge: params["code_challenge"],
+		claims:        claims,
+		expiresAt:     time.Now().Add(time.Minute),
+	}
+	p.mu.Unlock()
+
+	redirect, err := url.Parse(params["redirect_uri"])
+	if err != nil {
+		http.Error(w, "redirect_uri inválido", http.StatusBadRequest)
+		return
+	}
+	query := redirect.Query()
+	query.Set("code", code)
+	query.Set("state", params["state"])
+	redirect.RawQuery = query.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// token troca o código pelo id_token, conferindo cliente, redirect_uri e PKCE
+func (p *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	clientID, clientSecret, hasBasic := r.BasicAuth()
+	if hasBasic {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID = r.Form.Get("client_id")
+	}
+	if clientID != p.clientID || (p.clientSecret != "" && subtle.ConstantTimeCompare([]byte(clientSecret), []byte(p.clientSecret)) != 1) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mu.Lock()
+	auth, ok := p.codes[r.Form.Get("code")]
+	delete(p.codes, r.Form.Get("code")) // Código de uso único
+	p.mu.Unlock()
+
+	if r.Form.Get("grant_type") != "authorization_code" || !ok || time.Now().After(auth.expiresAt) ||
+		auth.clientID != clientID || auth.redirectURI != r.Form.Get("redirect_uri") {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	challenge := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(challenge[:]) != auth.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "PKCE inválido"})
+		return
+	}
+
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss":   p.issuer,
+		"aud":   clientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(5 * time.Minute).Unix(),
+		"nonce": auth.nonce,
+	}
+	for name, value := range auth.claims {
+		claims[name] = value
+	}
+
+	idToken, err := p.sign(claims)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomToken(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func (p *mockProvider) sign(claims map[string]interface{}) (string, error) {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": keyID})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func randomToken() string {
+	raw := make([]byte, 24)
+	rand.Read(raw)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
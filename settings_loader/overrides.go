@@ -0,0 +1,243 @@
+package settings_loader
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Prefixo das variáveis de ambiente (ex: HACKATHON_DATABASE_PASSWORD)
+const envPrefix = "HACKATHON_"
+
+// Valor exibido no lugar de segredos no dump de configuração
+const redactedValue = "********"
+
+// Valores que nunca devem ser aceitos como segredo em produção
+var insecureSecretValues = []string{
+	"changeme",
+	"change-me",
+	"secret",
+	"password",
+	"senha",
+	"123456",
+	"admin123",
+}
+
+// envKey monta o nome da variável de ambiente para uma chave de configuração
+func envKey(section, key string) string {
+	return envPrefix + strings.ToUpper(section) + "_" + strings.ToUpper(key)
+}
+
+// lookupEnv busca a variável de ambiente e, se não existir, a variante _FILE
+// (que aponta para um arquivo com o segredo, ex: Docker/Kubernetes secrets)
+func lookupEnv(name string) (string, bool, error) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true, nil
+	}
+
+	path, ok := os.LookupEnv(name + "_FILE")
+	if !ok || path == "" {
+		return "", false, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("erro ao ler %s_FILE (%s): %w", name, path, err)
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), true, nil
+}
+
+// walkFields percorre todas as chaves de configuração (seção + tag toml)
+func (s *SettingsLoader) walkFields(fn func(section string, field reflect.StructField, value reflect.Value) error) error {
+	root := reflect.ValueOf(s).Elem()
+	rootType := root.Type()
+
+	for i := 0; i < rootType.NumField(); i++ {
+		sectionField := rootType.Field(i)
+		if !sectionField.IsExported() || sectionField.Type.Kind() != reflect.Struct {
+			continue
+		}
+
+		section := root.Field(i)
+		for j := 0; j < sectionField.Type.NumField(); j++ {
+			field := sectionField.Type.Field(j)
+			if field.Tag.Get("toml") == "" {
+				continue
+			}
+			if err := fn(sectionField.Name, field, section.Field(j)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyEnvOverrides sobrescreve as configurações com as variáveis de ambiente definidas
+func (s *SettingsLoader) applyEnvOverrides() error {
+	return s.walkFields(func(section string, field reflect.StructField, value reflect.Value) error {
+		name := envKey(section, field.Tag.Get("toml"))
+
+		raw, ok, err := lookupEnv(name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+
+		if err := setFromString(value, raw); err != nil {
+			return fmt.Errorf("valor inválido em %s: %w", name, err)
+		}
+
+		s.envOverrides = append(s.envOverrides, name)
+		return nil
+	})
+}
+
+func setFromString(value reflect.Value, raw string) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("tipo não suportado: %s", value.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("tipo não suportado: %s", value.Type())
+	}
+
+	return nil
+}
+
+// secretValues retorna os valores atuais dos campos marcados com secret:"true"
+func (s *SettingsLoader) secretValues() map[string]string {
+	values := make(map[string]string)
+
+	s.walkFields(func(section string, field reflect.StructField, value reflect.Value) error {
+		if field.Tag.Get("secret") == "true" && value.Kind() == reflect.String {
+			values[strings.ToLower(section)+"."+field.Tag.Get("toml")] = value.String()
+		}
+		return nil
+	})
+
+	return values
+}
+
+// validateProduction recusa a inicialização em produção com segredos vazios ou padrão
+func (s *SettingsLoader) validateProduction() error {
+	required := []string{
+		"database.password",
+		"security.cookie_encryption_key",
+		"security.jwt_secret",
+	}
+
+	secrets := s.secretValues()
+
+	for _, key := range required {
+		if strings.TrimSpace(secrets[key]) == "" {
+			return fmt.Errorf("%s é obrigatório em produção", key)
+		}
+	}
+
+	keys := make([]string, 0, len(secrets))
+	for key := range secrets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := secrets[key]
+		if value == "" {
+			continue
+		}
+
+		for _, insecure := range insecureSecretValues {
+			if strings.EqualFold(strings.TrimSpace(value), insecure) {
+				return fmt.Errorf("%s usa um valor inseguro em produção", key)
+			}
+		}
+
+		if base, ok := s.baseSecretVals[key]; ok && base != "" && base == value {
+			section, name, _ := strings.Cut(key, ".")
+			return fmt.Errorf("%s ainda usa o valor padrão de settings.toml; defina %s ou %s_FILE",
+				key, envKey(section, name), envKey(section, name))
+		}
+	}
+
+	if len(s.Security.CookieEncryptionKey) < 32 {
+		return fmt.Errorf("security.cookie_encryption_key deve ter no mínimo 32 caracteres em produção")
+	}
+
+	if !s.Security.CookieSecure {
+		return fmt.Errorf("security.cookie_secure deve ser true em produção")
+	}
+
+	return nil
+}
+
+// Redacted retorna um dump da configuração efetiva com os segredos mascarados
+func (s *SettingsLoader) Redacted() map[string]interface{} {
+	sections := make(map[string]map[string]interface{})
+
+	s.walkFields(func(section string, field reflect.StructField, value reflect.Value) error {
+		name := strings.ToLower(section)
+		if sections[name] == nil {
+			sections[name] = make(map[string]interface{})
+		}
+
+		key := field.Tag.Get("toml")
+		if field.Tag.Get("secret") == "true" {
+			if value.IsZero() {
+				sections[name][key] = ""
+			} else {
+				sections[name][key] = redactedValue
+			}
+			return nil
+		}
+
+		sections[name][key] = value.Interface()
+		return nil
+	})
+
+	envOverrides := s.envOverrides
+	if envOverrides == nil {
+		envOverrides = []string{}
+	}
+
+	return map[string]interface{}{
+		"environment":   s.App.Environment,
+		"loaded_files":  s.loadedFiles,
+		"env_overrides": envOverrides,
+		"settings":      sections,
+	}
+}
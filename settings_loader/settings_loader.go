@@ -3,8 +3,11 @@ package settings_loader
 import (
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 )
@@ -16,6 +19,20 @@ type SettingsLoader struct {
 	SMTP     SMTPConfig
 	Storage  StorageConfig
 	AI       AIConfig // NOVO
+	Password PasswordConfig
+	OIDC     OIDCConfig
+
+	Cancellation CancellationConfig
+	SLA          SLAConfig
+	Approval     ApprovalConfig
+
+	Prioritization PrioritizationConfig
+	Scoring        ScoringConfig
+
+	// Metadados de carregamento (não vêm do TOML)
+	loadedFiles    []string
+	envOverrides   []string
+	baseSecretVals map[string]string
 }
 
 type AppConfig struct {
@@ -27,7 +44,7 @@ type DatabaseConfig struct {
 	Host            string `toml:"host"`
 	Port            string `toml:"port"`
 	User            string `toml:"user"`
-	Password        string `toml:"password"`
+	Password        string `toml:"password" secret:"true"`
 	DBName          string `toml:"dbname"`
 	SSLMode         string `toml:"sslmode"`
 	MaxOpenConns    int    `toml:"max_open_conns"`
@@ -36,18 +53,31 @@ type DatabaseConfig struct {
 }
 
 type SecurityConfig struct {
-	CookieEncryptionKey string `toml:"cookie_encryption_key"`
-	JWTSecret           string `toml:"jwt_secret"`
+	CookieEncryptionKey string `toml:"cookie_encryption_key" secret:"true"`
+	JWTSecret           string `toml:"jwt_secret" secret:"true"`
 	CookieDomain        string `toml:"cookie_domain"`
 	CookieSecure        bool   `toml:"cookie_secure"`
 	CookieHTTPOnly      bool   `toml:"cookie_http_only"`
+	TrustProxyHeaders   bool   `toml:"trust_proxy_headers"` // Usar X-Forwarded-For para o IP do cliente
+
+	// Proteção contra força bruta no login
+	LoginMaxAttempts          int `toml:"login_max_attempts"`           // Falhas por conta até o bloqueio
+	LoginIPMaxAttempts        int `toml:"login_ip_max_attempts"`        // Falhas por IP até o bloqueio
+	LoginBackoffBaseSeconds   int `toml:"login_backoff_base_seconds"`   // Espera após a 1ª falha (dobra a cada falha)
+	LoginLockoutSeconds       int `toml:"login_lockout_seconds"`        // Duração do 1º bloqueio (dobra a cada falha)
+	LoginMaxLockoutSeconds    int `toml:"login_max_lockout_seconds"`    // Duração máxima do bloqueio
+	LoginAttemptWindowSeconds int `toml:"login_attempt_window_seconds"` // Janela para zerar o contador de falhas
+
+	// Autenticação em dois fatores (TOTP)
+	TwoFactorIssuer              string `toml:"two_factor_issuer"`                // Nome exibido no aplicativo autenticador
+	TwoFactorLoginTimeoutSeconds int    `toml:"two_factor_login_timeout_seconds"` // Validade da etapa pendente do login
 }
 
 type SMTPConfig struct {
 	Host      string `toml:"host"`
 	Port      int    `toml:"port"`
 	Username  string `toml:"username"`
-	Password  string `toml:"password"`
+	Password  string `toml:"password" secret:"true"`
 	FromEmail string `toml:"from_email"`
 	FromName  string `toml:"from_name"`
 }
@@ -58,23 +88,173 @@ type StorageConfig struct {
 	TempPath    string `toml:"temp_path"`
 }
 
+// Política de senhas
+type PasswordConfig struct {
+	MinLength          int  `toml:"min_length"`
+	RequireUppercase   bool `toml:"require_uppercase"`
+	RequireLowercase   bool `toml:"require_lowercase"`
+	RequireDigit       bool `toml:"require_digit"`
+	RequireSymbol      bool `toml:"require_symbol"`
+	RejectPersonalInfo bool `toml:"reject_personal_info"` // Recusar senhas contendo email ou nome
+	RejectCommon       bool `toml:"reject_common"`        // Recusar senhas da lista de senhas comuns
+	HistoryCount       int  `toml:"history_count"`        // Quantidade de senhas anteriores que não podem ser reutilizadas
+	ExpiryDays         int  `toml:"expiry_days"`          // 0 = senhas não expiram
+	BcryptCost         int  `toml:"bcrypt_cost"`
+}
+
+// Login único (SSO) via OpenID Connect
+type OIDCConfig struct {
+	Enabled              bool     `toml:"enabled"`
+	IssuerURL            string   `toml:"issuer_url"` // Base para /.well-known/openid-configuration
+	ClientID             string   `toml:"client_id"`
+	ClientSecret         string   `toml:"client_secret" secret:"true"`
+	RedirectURL          string   `toml:"redirect_url"`            // URL pública de /api/auth/oidc/callback
+	PostLoginRedirectURL string   `toml:"post_login_redirect_url"` // Para onde o navegador volta após o login (frontend)
+	Scopes               []string `toml:"scopes"`
+	RequestTimeout       int      `toml:"request_timeout"` // em segundos
+
+	// Provisionamento automático (just-in-time)
+	GroupsClaim     string   `toml:"groups_claim"`      // Claim com os grupos do usuário
+	GroupUserTypes  []string `toml:"group_user_types"`  // Mapeamento "grupo=tipo" (ex: "ti-admins=admin")
+	DefaultUserType string   `toml:"default_user_type"` // Tipo atribuído a novos usuários sem grupo mapeado
+	SectorClaim     string   `toml:"sector_claim"`      // Claim com o nome do setor (ex: department)
+
+	// Login local com senha
+	DisableLocalLogin bool     `toml:"disable_local_login"` // Exigir SSO para todos...
+	BreakGlassEmails  []string `toml:"break_glass_emails"`  // ...exceto estas contas de emergência
+}
+
+// Regras das solicitações de cancelamento
+type CancellationConfig struct {
+	AllowedRequesters      []string `toml:"allowed_requesters"`       // owner, sector, manager, admin
+	RejectionCooldownHours int      `toml:"rejection_cooldown_hours"` // Espera após uma reprovação (0 = sem espera)
+}
+
+// Marca campos inteiros não configurados, para que 0 explícito seja respeitado
+const unsetInt = math.MinInt32
+
+// Quem pode solicitar cancelamento
+const (
+	CancellationRequesterOwner   = "owner"
+	CancellationRequesterSector  = "sector"
+	CancellationRequesterManager = "manager"
+	CancellationRequesterAdmin   = "admin"
+)
+
+// Prazos para decisões pendentes e escalonamento
+type SLAConfig struct {
+	SchedulerEnabled          bool     `toml:"scheduler_enabled"`
+	IntervalMinutes           int      `toml:"interval_minutes"`            // Intervalo entre as verificações
+	InitiativeReviewHours     int      `toml:"initiative_review_hours"`     // Iniciativas em "Submetida"
+	CancellationHours         int      `toml:"cancellation_hours"`          // Solicitações de cancelamento
+	PrioritizationChangeHours int      `toml:"prioritization_change_hours"` // Solicitações de mudança de priorização
+	EscalationTiers           []string `toml:"escalation_tiers"`            // Tipos de usuário por nível (o 1º é quem revisa normalmente)
+	AutoExpire                bool     `toml:"auto_expire"`                 // Expirar solicitações sem decisão
+	ExpireAfterHours          int      `toml:"expire_after_hours"`
+}
+
+// Fluxos de aprovação em etapas por tipo de iniciativa.
+// Tipos sem fluxo continuam com a revisão única (admin ou gerente)
+type ApprovalConfig struct {
+	Workflows []ApprovalWorkflow `toml:"workflows"`
+}
+
+type ApprovalWorkflow struct {
+	InitiativeType string         `toml:"initiative_type" json:"initiative_type"`
+	Mode           string         `toml:"mode" json:"mode"` // sequential (uma etapa por vez) ou parallel (todas ao mesmo tempo)
+	Steps          []ApprovalStep `toml:"steps" json:"steps"`
+}
+
+// Etapa atribuída a um tipo de usuário (opcionalmente do mesmo setor do dono) ou a usuários específicos
+type ApprovalStep struct {
+	Name       string   `toml:"name" json:"name"`
+	UserType   string   `toml:"user_type" json:"user_type,omitempty"`
+	SameSector bool     `toml:"same_sector" json:"same_sector,omitempty"`
+	UserEmails []string `toml:"user_emails" json:"user_emails,omitempty"`
+	Quorum     string   `toml:"quorum" json:"quorum"` // any, all, majority ou um número de aprovações
+}
+
+const (
+	ApprovalModeSequential = "sequential"
+	ApprovalModeParallel   = "parallel"
+
+	ApprovalQuorumAny      = "any"
+	ApprovalQuorumAll      = "all"
+	ApprovalQuorumMajority = "majority"
+)
+
+// Ranking consolidado entre setores (comitê executivo)
+type PrioritizationConfig struct {
+	ConsolidationStrategy  string         `toml:"consolidation_strategy"`  // round_robin, borda ou weighted_score
+	ConsolidationApprovers []string       `toml:"consolidation_approvers"` // Tipos de usuário que aprovam o ranking consolidado
+	SectorWeights          []SectorWeight `toml:"sector_weights"`          // Setores sem peso configurado valem 1
+	EmptyOrderBy           string         `toml:"empty_order_by"`          // Ordem inicial do setor sem priorização: created_at ou score
+	CycleSchedulerEnabled  bool           `toml:"cycle_scheduler_enabled"` // Abre/encerra os ciclos de planejamento automaticamente
+	CycleIntervalMinutes   int            `toml:"cycle_interval_minutes"`
+}
+
+type SectorWeight struct {
+	Sector string  `toml:"sector"`
+	Weight float64 `toml:"weight"`
+}
+
+// Pontuação das iniciativas por critérios ponderados
+type ScoringConfig struct {
+	Reviewers []string `toml:"reviewers"` // Tipos de usuário que avaliam as iniciativas
+}
+
+const (
+	EmptyOrderByCreatedAt = "created_at"
+	EmptyOrderByScore     = "score"
+)
+
+const (
+	ConsolidationRoundRobin    = "round_robin"
+	ConsolidationBorda         = "borda"
+	ConsolidationWeightedScore = "weighted_score"
+)
+
+// Provedores de IA disponíveis
+const (
+	AIProviderGemini = "gemini"
+	AIProviderOpenAI = "openai" // Qualquer endpoint compatível com /chat/completions
+	AIProviderOllama = "ollama"
+	AIProviderFake   = "fake" // Respostas determinísticas, sem rede (testes/desenvolvimento)
+)
+
 // NOVO: Configuração de IA
 type AIConfig struct {
-	GeminiAPIKey   string  `toml:"gemini_api_key"`
+	Provider       string  `toml:"provider"`
+	GeminiAPIKey   string  `toml:"gemini_api_key" secret:"true"`
 	GeminiModel    string  `toml:"gemini_model"`
 	MaxTokens      int     `toml:"max_tokens"`
 	Temperature    float64 `toml:"temperature"`
-	RequestTimeout int     `toml:"request_timeout"` // em segundos
+	RequestTimeout int     `toml:"request_timeout"` // em segundos (Gemini e padrão dos demais)
+
+	OpenAIBaseURL  string `toml:"openai_base_url"`
+	OpenAIAPIKey   string `toml:"openai_api_key" secret:"true"`
+	OpenAIModel    string `toml:"openai_model"`
+	OpenAITimeout  int    `toml:"openai_timeout"` // em segundos
+	OllamaBaseURL  string `toml:"ollama_base_url"`
+	OllamaModel    string `toml:"ollama_model"`
+	OllamaTimeout  int    `toml:"ollama_timeout"`   // em segundos (modelos locais costumam ser lentos)
+	MaxRetries     int    `toml:"max_retries"`      // Novas tentativas em 429/5xx
+	RetryBackoffMs int    `toml:"retry_backoff_ms"` // Espera inicial, dobrada a cada tentativa
 }
 
+// NewSettingsLoader carrega as configurações na seguinte ordem de precedência:
+//  1. settings.toml (base)
+//  2. settings.<environment>.toml (overlay opcional por ambiente)
+//  3. variáveis de ambiente HACKATHON_<SECAO>_<CHAVE> ou HACKATHON_<SECAO>_<CHAVE>_FILE
 func NewSettingsLoader() *SettingsLoader {
 	var settings SettingsLoader
+	settings.Cancellation.RejectionCooldownHours = unsetInt
 
-	configPath, err := os.Getwd()
+	configDir, err := os.Getwd()
 	if err != nil {
 		log.Fatal(err)
 	}
-	configPath = filepath.Join(configPath, "settings.toml")
+	configPath := filepath.Join(configDir, "settings.toml")
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		log.Fatalf("Arquivo settings.toml não encontrado em: %s", configPath)
 	}
@@ -82,6 +262,34 @@ func NewSettingsLoader() *SettingsLoader {
 	if _, err := toml.DecodeFile(configPath, &settings); err != nil {
 		log.Fatalf("Erro ao carregar settings. toml: %v", err)
 	}
+	settings.loadedFiles = append(settings.loadedFiles, "settings.toml")
+
+	// Guardar os segredos do arquivo base para detectar valores padrão em produção
+	settings.baseSecretVals = settings.secretValues()
+
+	// Overlay por ambiente (ex: settings.production.toml)
+	environment := settings.App.Environment
+	if env, ok, err := lookupEnv(envKey("App", "environment")); err != nil {
+		log.Fatalf("Erro ao ler variável de ambiente: %v", err)
+	} else if ok {
+		environment = env
+	}
+
+	if environment != "" {
+		overlayName := fmt.Sprintf("settings.%s.toml", environment)
+		overlayPath := filepath.Join(configDir, overlayName)
+		if _, err := os.Stat(overlayPath); err == nil {
+			if _, err := toml.DecodeFile(overlayPath, &settings); err != nil {
+				log.Fatalf("Erro ao carregar %s: %v", overlayName, err)
+			}
+			settings.loadedFiles = append(settings.loadedFiles, overlayName)
+		}
+	}
+
+	// Variáveis de ambiente têm a maior precedência
+	if err := settings.applyEnvOverrides(); err != nil {
+		log.Fatalf("Erro ao aplicar variáveis de ambiente: %v", err)
+	}
 
 	// Aplicar valores padrão para AI se não configurado
 	settings.applyDefaults()
@@ -91,7 +299,8 @@ func NewSettingsLoader() *SettingsLoader {
 		log.Fatalf("Configuração inválida: %v", err)
 	}
 
-	log.Println("✅ Configurações carregadas com sucesso")
+	log.Printf("✅ Configurações carregadas com sucesso (arquivos: %v, overrides via ambiente: %d)",
+		settings.loadedFiles, len(settings.envOverrides))
 	return &settings
 }
 
@@ -110,6 +319,142 @@ func (s *SettingsLoader) applyDefaults() {
 	if s.AI.RequestTimeout == 0 {
 		s.AI.RequestTimeout = 30
 	}
+	if s.AI.Provider == "" {
+		s.AI.Provider = AIProviderGemini
+	}
+	if s.AI.OpenAIBaseURL == "" {
+		s.AI.OpenAIBaseURL = "https://api.openai.com/v1"
+	}
+	if s.AI.OpenAIModel == "" {
+		s.AI.OpenAIModel = "gpt-4o-mini"
+	}
+	if s.AI.OpenAITimeout == 0 {
+		s.AI.OpenAITimeout = s.AI.RequestTimeout
+	}
+	if s.AI.OllamaBaseURL == "" {
+		s.AI.OllamaBaseURL = "http://localhost:11434"
+	}
+	if s.AI.OllamaModel == "" {
+		s.AI.OllamaModel = "llama3.1"
+	}
+	if s.AI.OllamaTimeout == 0 {
+		s.AI.OllamaTimeout = 120
+	}
+	if s.AI.MaxRetries == 0 {
+		s.AI.MaxRetries = 2
+	}
+	if s.AI.RetryBackoffMs == 0 {
+		s.AI.RetryBackoffMs = 500
+	}
+
+	// Defaults para política de senhas
+	if s.Password.MinLength == 0 {
+		s.Password.MinLength = 8
+	}
+	if s.Password.BcryptCost == 0 {
+		s.Password.BcryptCost = 10
+	}
+
+	// Defaults para proteção de login
+	if s.Security.LoginMaxAttempts == 0 {
+		s.Security.LoginMaxAttempts = 5
+	}
+	if s.Security.LoginIPMaxAttempts == 0 {
+		s.Security.LoginIPMaxAttempts = 20
+	}
+	if s.Security.LoginBackoffBaseSeconds == 0 {
+		s.Security.LoginBackoffBaseSeconds = 1
+	}
+	if s.Security.LoginLockoutSeconds == 0 {
+		s.Security.LoginLockoutSeconds = 900
+	}
+	if s.Security.LoginMaxLockoutSeconds == 0 {
+		s.Security.LoginMaxLockoutSeconds = 86400
+	}
+	if s.Security.LoginAttemptWindowSeconds == 0 {
+		s.Security.LoginAttemptWindowSeconds = 900
+	}
+
+	// Defaults para SSO
+	if len(s.OIDC.Scopes) == 0 {
+		s.OIDC.Scopes = []string{"openid", "email", "profile"}
+	}
+	if s.OIDC.GroupsClaim == "" {
+		s.OIDC.GroupsClaim = "groups"
+	}
+	if s.OIDC.DefaultUserType == "" {
+		s.OIDC.DefaultUserType = "user"
+	}
+	if s.OIDC.RequestTimeout == 0 {
+		s.OIDC.RequestTimeout = 10
+	}
+
+	// Defaults para cancelamento
+	if len(s.Cancellation.AllowedRequesters) == 0 {
+		s.Cancellation.AllowedRequesters = []string{CancellationRequesterOwner, CancellationRequesterAdmin}
+	}
+	if s.Cancellation.RejectionCooldownHours == unsetInt {
+		s.Cancellation.RejectionCooldownHours = 72
+	}
+
+	// Defaults para SLA
+	if s.SLA.IntervalMinutes == 0 {
+		s.SLA.IntervalMinutes = 15
+	}
+	if s.SLA.InitiativeReviewHours == 0 {
+		s.SLA.InitiativeReviewHours = 72
+	}
+	if s.SLA.CancellationHours == 0 {
+		s.SLA.CancellationHours = 72
+	}
+	if s.SLA.PrioritizationChangeHours == 0 {
+		s.SLA.PrioritizationChangeHours = 72
+	}
+	if len(s.SLA.EscalationTiers) == 0 {
+		s.SLA.EscalationTiers = []string{"manager", "admin"}
+	}
+	if s.SLA.ExpireAfterHours == 0 {
+		s.SLA.ExpireAfterHours = 720
+	}
+
+	// Defaults para fluxos de aprovação
+	for i := range s.Approval.Workflows {
+		workflow := &s.Approval.Workflows[i]
+		if workflow.Mode == "" {
+			workflow.Mode = ApprovalModeSequential
+		}
+		for j := range workflow.Steps {
+			if workflow.Steps[j].Quorum == "" {
+				workflow.Steps[j].Quorum = ApprovalQuorumAny
+			}
+		}
+	}
+
+	// Defaults para o ranking consolidado
+	if s.Prioritization.ConsolidationStrategy == "" {
+		s.Prioritization.ConsolidationStrategy = ConsolidationWeightedScore
+	}
+	if len(s.Prioritization.ConsolidationApprovers) == 0 {
+		s.Prioritization.ConsolidationApprovers = []string{"director", "admin"}
+	}
+
+	if s.Prioritization.EmptyOrderBy == "" {
+		s.Prioritization.EmptyOrderBy = EmptyOrderByCreatedAt
+	}
+	if s.Prioritization.CycleIntervalMinutes == 0 {
+		s.Prioritization.CycleIntervalMinutes = 5
+	}
+	if len(s.Scoring.Reviewers) == 0 {
+		s.Scoring.Reviewers = []string{"admin", "manager", "architect", "director"}
+	}
+
+	// Defaults para 2FA
+	if s.Security.TwoFactorIssuer == "" {
+		s.Security.TwoFactorIssuer = "Hackathon"
+	}
+	if s.Security.TwoFactorLoginTimeoutSeconds == 0 {
+		s.Security.TwoFactorLoginTimeoutSeconds = 300
+	}
 }
 
 func (s *SettingsLoader) Validate() error {
@@ -130,8 +475,70 @@ func (s *SettingsLoader) Validate() error {
 	}
 
 	// NOVO: Validar AI (apenas aviso, não obrigatório)
-	if s.AI.GeminiAPIKey == "" {
-		log.Println("⚠️  Aviso: ai.gemini_api_key não configurado - funcionalidades de IA estarão desabilitadas")
+	switch s.AI.Provider {
+	case AIProviderGemini, AIProviderOpenAI, AIProviderOllama, AIProviderFake:
+	default:
+		return fmt.Errorf("ai.provider: valor inválido %q (use gemini, openai, ollama ou fake)", s.AI.Provider)
+	}
+	if s.AI.MaxRetries < 0 || s.AI.RetryBackoffMs < 0 || s.AI.RequestTimeout < 1 || s.AI.OpenAITimeout < 1 || s.AI.OllamaTimeout < 1 {
+		return fmt.Errorf("ai: timeouts devem ser positivos e max_retries/retry_backoff_ms não podem ser negativos")
+	}
+	if !s.IsAIEnabled() {
+		log.Printf("⚠️  Aviso: provedor de IA %q sem credenciais - funcionalidades de IA estarão desabilitadas", s.AI.Provider)
+	}
+
+	if s.Password.BcryptCost < 4 || s.Password.BcryptCost > 31 {
+		return fmt.Errorf("password.bcrypt_cost deve estar entre 4 e 31")
+	}
+
+	if s.OIDC.Enabled {
+		if s.OIDC.IssuerURL == "" || s.OIDC.ClientID == "" || s.OIDC.RedirectURL == "" {
+			return fmt.Errorf("oidc.issuer_url, oidc.client_id e oidc.redirect_url são obrigatórios com oidc.enabled")
+		}
+		for _, mapping := range s.OIDC.GroupUserTypes {
+			if group, userType, ok := strings.Cut(mapping, "="); !ok || group == "" || userType == "" {
+				return fmt.Errorf("oidc.group_user_types: mapeamento inválido %q (use \"grupo=tipo\")", mapping)
+			}
+		}
+	}
+
+	for _, requester := range s.Cancellation.AllowedRequesters {
+		switch requester {
+		case CancellationRequesterOwner, CancellationRequesterSector, CancellationRequesterManager, CancellationRequesterAdmin:
+		default:
+			return fmt.Errorf("cancellation.allowed_requesters: valor inválido %q (use owner, sector, manager ou admin)", requester)
+		}
+	}
+	if s.Cancellation.RejectionCooldownHours < 0 {
+		return fmt.Errorf("cancellation.rejection_cooldown_hours não pode ser negativo")
+	}
+
+	if s.SLA.IntervalMinutes < 1 || s.SLA.InitiativeReviewHours < 1 || s.SLA.CancellationHours < 1 ||
+		s.SLA.PrioritizationChangeHours < 1 || s.SLA.ExpireAfterHours < 1 {
+		return fmt.Errorf("sla: intervalos e prazos devem ser positivos")
+	}
+
+	if err := s.validateApprovalWorkflows(); err != nil {
+		return err
+	}
+
+	if !IsValidConsolidationStrategy(s.Prioritization.ConsolidationStrategy) {
+		return fmt.Errorf("prioritization.consolidation_strategy: valor inválido %q (use round_robin, borda ou weighted_score)", s.Prioritization.ConsolidationStrategy)
+	}
+	if s.Prioritization.EmptyOrderBy != EmptyOrderByCreatedAt && s.Prioritization.EmptyOrderBy != EmptyOrderByScore {
+		return fmt.Errorf("prioritization.empty_order_by: valor inválido %q (use created_at ou score)", s.Prioritization.EmptyOrderBy)
+	}
+	if s.Prioritization.CycleIntervalMinutes < 1 {
+		return fmt.Errorf("prioritization.cycle_interval_minutes: deve ser positivo")
+	}
+	for _, sw := range s.Prioritization.SectorWeights {
+		if sw.Sector == "" || sw.Weight <= 0 {
+			return fmt.Errorf("prioritization.sector_weights: informe sector e weight positivo")
+		}
+	}
+
+	if s.IsProduction() {
+		return s.validateProduction()
 	}
 
 	return nil
@@ -177,6 +584,92 @@ func (s *SettingsLoader) GetAIRequestTimeout() int {
 	return s.AI.RequestTimeout
 }
 
+func (s *SettingsLoader) GetAIProvider() string {
+	return s.AI.Provider
+}
+
+// IsAIEnabled indica se o provedor selecionado tem o necessário para ser chamado
 func (s *SettingsLoader) IsAIEnabled() bool {
-	return s.AI.GeminiAPIKey != ""
+	switch s.AI.Provider {
+	case AIProviderGemini:
+		return s.AI.GeminiAPIKey != ""
+	case AIProviderOpenAI:
+		return s.AI.OpenAIBaseURL != "" && s.AI.OpenAIModel != ""
+	case AIProviderOllama:
+		return s.AI.OllamaBaseURL != "" && s.AI.OllamaModel != ""
+	case AIProviderFake:
+		return true
+	}
+	return false
+}
+
+func (s *SettingsLoader) validateApprovalWorkflows() error {
+	seen := make(map[string]bool)
+
+	for _, workflow := range s.Approval.Workflows {
+		if workflow.InitiativeType == "" {
+			return fmt.Errorf("approval.workflows: initiative_type é obrigatório")
+		}
+		if seen[workflow.InitiativeType] {
+			return fmt.Errorf("approval.workflows: mais de um fluxo para o tipo %q", workflow.InitiativeType)
+		}
+		seen[workflow.InitiativeType] = true
+
+		if workflow.Mode != ApprovalModeSequential && workflow.Mode != ApprovalModeParallel {
+			return fmt.Errorf("approval.workflows (%s): mode inválido %q (use sequential ou parallel)", workflow.InitiativeType, workflow.Mode)
+		}
+		if len(workflow.Steps) == 0 {
+			return fmt.Errorf("approval.workflows (%s): informe ao menos uma etapa", workflow.InitiativeType)
+		}
+
+		for _, step := range workflow.Steps {
+			if step.Name == "" {
+				return fmt.Errorf("approval.workflows (%s): toda etapa precisa de name", workflow.InitiativeType)
+			}
+			if (step.UserType == "") == (len(step.UserEmails) == 0) {
+				return fmt.Errorf("approval.workflows (%s): a etapa %q deve ter user_type ou user_emails", workflow.InitiativeType, step.Name)
+			}
+			if step.SameSector && step.UserType == "" {
+				return fmt.Errorf("approval.workflows (%s): same_sector exige user_type na etapa %q", workflow.InitiativeType, step.Name)
+			}
+
+			switch step.Quorum {
+			case ApprovalQuorumAny, ApprovalQuorumAll, ApprovalQuorumMajority:
+			default:
+				if n, err := strconv.Atoi(step.Quorum); err != nil || n < 1 {
+					return fmt.Errorf("approval.workflows (%s): quorum inválido %q na etapa %q", workflow.InitiativeType, step.Quorum, step.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ApprovalWorkflowFor retorna o fluxo de aprovação do tipo de iniciativa (nil = revisão única)
+func (s *SettingsLoader) ApprovalWorkflowFor(initiativeType string) *ApprovalWorkflow {
+	for i := range s.Approval.Workflows {
+		if s.Approval.Workflows[i].InitiativeType == initiativeType {
+			return &s.Approval.Workflows[i]
+		}
+	}
+	return nil
+}
+
+func IsValidConsolidationStrategy(strategy string) bool {
+	switch strategy {
+	case ConsolidationRoundRobin, ConsolidationBorda, ConsolidationWeightedScore:
+		return true
+	}
+	return false
+}
+
+// SectorWeight retorna o peso do setor no ranking consolidado (padrão 1)
+func (s *SettingsLoader) SectorWeight(sector string) float64 {
+	for _, sw := range s.Prioritization.SectorWeights {
+		if sw.Sector == sector {
+			return sw.Weight
+		}
+	}
+	return 1
 }
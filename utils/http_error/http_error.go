@@ -27,6 +27,10 @@ func NotFound(w http.ResponseWriter, message string) {
 	sendError(w, http.StatusNotFound, message)
 }
 
+func TooManyRequests(w http.ResponseWriter, message string) {
+	sendError(w, http.StatusTooManyRequests, message)
+}
+
 func InternalServerError(w http.ResponseWriter, message string) {
 	sendError(w, http.StatusInternalServerError, message)
 }
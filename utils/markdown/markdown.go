@@ -0,0 +1,477 @@
+// Package markdown implementa o subconjunto de Markdown aceito nos campos de texto
+// (descrição, benefícios e comentários): parágrafos, títulos, listas, citações,
+// blocos de código, negrito, itálico, tachado, código inline e links.
+//
+// HTML digitado pelo usuário nunca é repassado: todo texto é escapado e apenas as
+// tags geradas aqui aparecem na saída. Links só são aceitos com http, https, mailto
+// ou caminhos relativos.
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Profundidade máxima de citações aninhadas (> > >)
+const maxQuoteDepth = 4
+
+var (
+	headingPattern   = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*)$`)
+	unorderedPattern = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	orderedPattern   = regexp.MustCompile(`^\s{0,3}(\d{1,9})[.)]\s+(.*)$`)
+	rulePattern      = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+)
+
+type blockKind int
+
+const (
+	blockParagraph blockKind = iota
+	blockHeading
+	blockCode
+	blockRule
+	blockQuote
+	blockList
+	blockOrderedList
+)
+
+type block struct {
+	kind     blockKind
+	level    int // nível do título
+	start    int // número inicial da lista ordenada
+	lines    []string
+	children []block
+}
+
+// Render converte o Markdown em HTML sanitizado
+func Render(src string) string {
+	return renderBlocks(parseBlocks(normalize(src), 0))
+}
+
+// PlainText retorna o texto sem marcação (usado em busca e listagens)
+func PlainText(src string) string {
+	return strings.TrimSpace(plainBlocks(parseBlocks(normalize(src), 0)))
+}
+
+func normalize(src string) string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	return strings.ReplaceAll(src, "\r", "\n")
+}
+
+func parseBlocks(src string, depth int) []block {
+	lines := strings.Split(src, "\n")
+	var blocks []block
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case isFence(trimmed):
+			fence := trimmed[:3]
+			var code []string
+			i++
+			for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+				code = append(code, lines[i])
+				i++
+			}
+			i++ // linha de fechamento (ou fim do texto)
+			blocks = append(blocks, block{kind: blockCode, lines: code})
+
+		case rulePattern.MatchString(line):
+			blocks = append(blocks, block{kind: blockRule})
+			i++
+
+		case headingPattern.MatchString(line):
+			match := headingPattern.FindStringSubmatch(line)
+			blocks = append(blocks, block{
+				kind:  blockHeading,
+				level: len(match[1]),
+				lines: []string{strings.TrimSpace(match[2])},
+			})
+			i++
+
+		case depth < maxQuoteDepth && strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for i < len(lines) {
+				t := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(t, ">") {
+					break
+				}
+				quoted = append(quoted, strings.TrimPrefix(strings.TrimPrefix(t, ">"), " "))
+				i++
+			}
+			blocks = append(blocks, block{
+				kind:     blockQuote,
+				children: parseBlocks(strings.Join(quoted, "\n"), depth+1),
+			})
+
+		case unorderedPattern.MatchString(line) || orderedPattern.MatchString(line):
+			var list block
+			list, i = parseList(lines, i)
+			blocks = append(blocks, list)
+
+		default:
+			var paragraph []string
+			for i < len(lines) {
+				if strings.TrimSpace(lines[i]) == "" || (len(paragraph) > 0 && startsBlock(lines[i], depth)) {
+					break
+				}
+				paragraph = append(paragraph, strings.TrimSpace(lines[i]))
+				i++
+			}
+			blocks = append(blocks, block{kind: blockParagraph, lines: paragraph})
+		}
+	}
+
+	return blocks
+}
+
+// parseList agrupa os itens consecutivos de uma lista. Linhas indentadas que não
+// iniciam um item continuam o item anterior.
+func parseList(lines []string, i int) (block, int) {
+	list := block{kind: blockList}
+	if match := orderedPattern.FindStringSubmatch(lines[i]); match != nil {
+		list.kind = blockOrderedList
+		list.start, _ = strconv.Atoi(match[1])
+	}
+
+	for i < len(lines) {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+
+		if list.kind == blockList {
+			if match := unorderedPattern.FindStringSubmatch(line); match != nil && !rulePattern.MatchString(line) {
+				list.lines = append(list.lines, strings.TrimSpace(match[1]))
+				i++
+				continue
+			}
+		} else if match := orderedPattern.FindStringSubmatch(line); match != nil {
+			list.lines = append(list.lines, strings.TrimSpace(match[2]))
+			i++
+			continue
+		}
+
+		// Continuação do item anterior
+		if (line[0] == ' ' || line[0] == '\t') && !startsBlock(line, 0) {
+			list.lines[len(list.lines)-1] += "\n" + strings.TrimSpace(line)
+			i++
+			continue
+		}
+
+		break
+	}
+
+	return list, i
+}
+
+func isFence(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// startsBlock indica se a linha inicia um novo bloco (encerrando o parágrafo atual)
+func startsBlock(line string, depth int) bool {
+	trimmed := strings.TrimSpace(line)
+	return isFence(trimmed) ||
+		rulePattern.MatchString(line) ||
+		headingPattern.MatchString(line) ||
+		(depth < maxQuoteDepth && strings.HasPrefix(trimmed, ">")) ||
+		unorderedPattern.MatchString(line) ||
+		orderedPattern.MatchString(line)
+}
+
+func renderBlocks(blocks []block) string {
+	parts := make([]string, 0, len(blocks))
+
+	for _, b := range blocks {
+		switch b.kind {
+		case blockParagraph:
+			parts = append(parts, "<p>"+renderMultiline(strings.Join(b.lines, "\n"))+"</p>")
+		case blockHeading:
+			tag := "h" + strconv.Itoa(b.level)
+			parts = append(parts, "<"+tag+">"+renderInline(b.lines[0], false)+"</"+tag+">")
+		case blockCode:
+			parts = append(parts, "<pre><code>"+html.EscapeString(strings.Join(b.lines, "\n"))+"</code></pre>")
+		case blockRule:
+			parts = append(parts, "<hr>")
+		case blockQuote:
+			parts = append(parts, "<blockquote>\n"+renderBlocks(b.children)+"\n</blockquote>")
+		case blockList, blockOrderedList:
+			open, closing := "<ul>", "</ul>"
+			if b.kind == blockOrderedList {
+				open, closing = "<ol>", "</ol>"
+				if b.start != 1 {
+					open = `<ol start="` + strconv.Itoa(b.start) + `">`
+				}
+			}
+
+			var sb strings.Builder
+			sb.WriteString(open + "\n")
+			for _, item := range b.lines {
+				sb.WriteString("<li>" + renderMultiline(item) + "</li>\n")
+			}
+			sb.WriteString(closing)
+			parts = append(parts, sb.String())
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// renderMultiline renderiza o texto inline preservando as quebras de linha
+func renderMultiline(text string) string {
+	return strings.ReplaceAll(renderInline(text, false), "\n", "<br>\n")
+}
+
+func plainBlocks(blocks []block) string {
+	parts := make([]string, 0, len(blocks))
+
+	for _, b := range blocks {
+		switch b.kind {
+		case blockParagraph, blockHeading:
+			parts = append(parts, renderInline(strings.Join(b.lines, "\n"), true))
+		case blockCode:
+			parts = append(parts, strings.Join(b.lines, "\n"))
+		case blockQuote:
+			parts = append(parts, plainBlocks(b.children))
+		case blockList, blockOrderedList:
+			items := make([]string, 0, len(b.lines))
+			for _, item := range b.lines {
+				items = append(items, renderInline(item, true))
+			}
+			parts = append(parts, strings.Join(items, "\n"))
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// renderInline trata a marcação dentro de um bloco. Com plain=true retorna apenas o texto.
+func renderInline(s string, plain bool) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		if c == '\\' && i+1 < len(s) && strings.IndexByte(`\`+"`*_~[]()#>+-.!", s[i+1]) >= 0 {
+			writeText(&b, s[i+1:i+2], plain)
+			i += 2
+			continue
+		}
+
+		if c == '`' {
+			if end := strings.IndexByte(s[i+1:], '`'); end > 0 {
+				code := s[i+1 : i+1+end]
+				if plain {
+					b.WriteString(code)
+				} else {
+					b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+				}
+				i += end + 2
+				continue
+			}
+		}
+
+		if tag, delim := emphasisAt(s, i); tag != "" {
+			if inner, ok := delimited(s, i, delim); ok {
+				if plain {
+					b.WriteString(renderInline(inner, true))
+				} else {
+					b.WriteString("<" + tag + ">" + renderInline(inner, false) + "</" + tag + ">")
+				}
+				i += len(inner) + 2*len(delim)
+				continue
+			}
+		}
+
+		if c == '[' {
+			if text, href, n, ok := parseLink(s[i:]); ok {
+				if safe, ok := safeURL(href); ok && !plain {
+					b.WriteString(`<a href="` + html.EscapeString(safe) + `" rel="nofollow noopener noreferrer" target="_blank">` +
+						renderInline(text, false) + "</a>")
+				} else {
+					b.WriteString(renderInline(text, plain))
+				}
+				i += n
+				continue
+			}
+		}
+
+		if (c == 'h' || c == 'H') && (i == 0 || !isWordByte(s[i-1])) {
+			if link := autolinkAt(s[i:]); link != "" {
+				if safe, ok := safeURL(link); ok && !plain {
+					b.WriteString(`<a href="` + html.EscapeString(safe) + `" rel="nofollow noopener noreferrer" target="_blank">` +
+						html.EscapeString(link) + "</a>")
+				} else {
+					writeText(&b, link, plain)
+				}
+				i += len(link)
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		writeText(&b, s[i:i+size], plain)
+		i += size
+	}
+
+	return b.String()
+}
+
+func writeText(b *strings.Builder, text string, plain bool) {
+	if plain {
+		b.WriteString(text)
+		return
+	}
+	b.WriteString(html.EscapeString(text))
+}
+
+// emphasisAt identifica um delimitador de ênfase na posição i
+func emphasisAt(s string, i int) (tag, delim string) {
+	rest := s[i:]
+	switch {
+	case strings.HasPrefix(rest, "**"), strings.HasPrefix(rest, "__"):
+		return "strong", rest[:2]
+	case strings.HasPrefix(rest, "~~"):
+		return "del", "~~"
+	case rest[0] == '*':
+		return "em", "*"
+	case rest[0] == '_' && (i == 0 || !isWordByte(s[i-1])):
+		// snake_case não vira itálico
+		return "em", "_"
+	}
+	return "", ""
+}
+
+// delimited busca o fechamento do delimitador aberto em i. O conteúdo não pode
+// começar nem terminar com espaço.
+func delimited(s string, i int, delim string) (string, bool) {
+	start := i + len(delim)
+	if start >= len(s) || s[start] == ' ' || s[start] == '\n' {
+		return "", false
+	}
+
+	for offset := 1; start+offset < len(s); {
+		idx := strings.Index(s[start+offset:], delim)
+		if idx < 0 {
+			return "", false
+		}
+
+		end := start + offset + idx
+		closingOK := s[end-1] != ' ' && s[end-1] != '\n'
+		if delim == "_" && end+1 < len(s) && isWordByte(s[end+1]) {
+			closingOK = false
+		}
+		if delim == "*" && end+1 < len(s) && s[end+1] == '*' {
+			closingOK = false
+		}
+
+		if closingOK {
+			return s[start:end], true
+		}
+		offset = end - start + 1
+	}
+
+	return "", false
+}
+
+// parseLink interpreta [texto](url) no início de s
+func parseLink(s string) (text, href string, n int, ok bool) {
+	closeText := strings.IndexByte(s, ']')
+	if closeText <= 1 || closeText+1 >= len(s) || s[closeText+1] != '(' {
+		return "", "", 0, false
+	}
+
+	// Parênteses balanceados são permitidos na URL
+	closeHref, depth := -1, 0
+	for j := closeText + 2; j < len(s) && closeHref < 0; j++ {
+		switch s[j] {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				closeHref = j - (closeText + 2)
+			}
+			depth--
+		case '\n':
+			return "", "", 0, false
+		}
+	}
+	if closeHref < 0 {
+		return "", "", 0, false
+	}
+
+	text = s[1:closeText]
+	href = strings.TrimSpace(s[closeText+2 : closeText+2+closeHref])
+	if strings.ContainsAny(text, "\n") || href == "" {
+		return "", "", 0, false
+	}
+
+	return text, href, closeText + 2 + closeHref + 1, true
+}
+
+// autolinkAt retorna a URL http(s) no início de s, sem a pontuação final
+func autolinkAt(s string) string {
+	lower := strings.ToLower(s[:min(len(s), 8)])
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return ""
+	}
+
+	end := strings.IndexAny(s, " \t\n<>\"")
+	if end < 0 {
+		end = len(s)
+	}
+
+	link := strings.TrimRight(s[:end], ".,;:!?')]*_~")
+	if strings.HasSuffix(strings.ToLower(link), "://") {
+		return ""
+	}
+	return link
+}
+
+// safeURL aceita apenas http, https, mailto e caminhos relativos
+func safeURL(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.ContainsAny(raw, " <>\"'`\\") {
+		return "", false
+	}
+
+	for _, r := range raw {
+		if r < 0x20 || r == 0x7f {
+			return "", false
+		}
+	}
+
+	if (strings.HasPrefix(raw, "/") && !strings.HasPrefix(raw, "//")) || strings.HasPrefix(raw, "#") {
+		return raw, true
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		if u.Host == "" {
+			return "", false
+		}
+		return raw, true
+	case "mailto":
+		return raw, true
+	}
+
+	return "", false
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c >= 0x80
+}